@@ -27,6 +27,7 @@ GTE: '>=';
 GT: '>';
 
 AMPERSAND: '&';
+ARROW: '=>';
 
 TEXT: '"' (~["] | '\\"')* '"';
 INTEGER: [0-9]+;
@@ -57,7 +58,8 @@ expression:
 	| (INTEGER | DECIMAL)								# numberLiteral
 	| TRUE												# true
 	| FALSE												# false
-	| NULL												# null;
+	| NULL												# null
+	| LPAREN (NAME (COMMA NAME)*)? RPAREN ARROW expression	# anonymousFunction;
 
 // a subset of expressions which can be followed by (), [] or .
 atom:
@@ -41,13 +41,13 @@ func TestGenerateDocs(t *testing.T) {
 	assert.Contains(t, completion, "root")
 
 	types := completion["types"].([]interface{})
-	assert.Equal(t, 12, len(types))
+	assert.Equal(t, 14, len(types))
 
 	root := completion["root"].([]interface{})
-	assert.Equal(t, 10, len(root))
+	assert.Equal(t, 11, len(root))
 
 	functions := readJSONOutput(t, outputDir, "functions.json").([]interface{})
-	assert.Equal(t, 76, len(functions))
+	assert.Equal(t, 88, len(functions))
 }
 
 func readJSONOutput(t *testing.T, outputDir string, name string) interface{} {
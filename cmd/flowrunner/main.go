@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/nyaruka/goflow/services/classification/wit"
 	"github.com/nyaruka/goflow/services/webhooks"
 	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/httpx"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/pkg/errors"
@@ -44,12 +46,14 @@ const contactJSON = `{
 const usage = `usage: flowrunner [flags] <assets.json> <flow_uuid>`
 
 func main() {
-	var initialMsg, contactLang, witToken string
+	var initialMsg, contactLang, witToken, mocksPath, scriptPath string
 	var printRepro bool
 	flags := flag.NewFlagSet("", flag.ExitOnError)
 	flags.StringVar(&initialMsg, "msg", "", "initial message to trigger session with")
 	flags.StringVar(&contactLang, "lang", "eng", "initial language of the contact")
 	flags.StringVar(&witToken, "wit.token", "", "access token for wit.ai")
+	flags.StringVar(&mocksPath, "mocks", "", "file of mocked webhook, classifier, airtime, LLM and credential responses")
+	flags.StringVar(&scriptPath, "script", "", "file of inputs to run without prompting, printing a runner test fixture")
 	flags.BoolVar(&printRepro, "repro", false, "print repro afterwards")
 	flags.Parse(os.Args[1:])
 	args := flags.Args()
@@ -57,13 +61,35 @@ func main() {
 	if len(args) != 2 {
 		fmt.Println(usage)
 		flags.PrintDefaults()
+		fmt.Println(CommandsHelp)
 		os.Exit(1)
 	}
 
 	assetsPath := args[0]
 	flowUUID := assets.FlowUUID(args[1])
 
-	engine := createEngine(witToken)
+	var mocks *Mocks
+	if mocksPath != "" {
+		var err error
+		if mocks, err = LoadMocks(mocksPath); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if scriptPath != "" {
+		if err := runScript(assetsPath, flowUUID, initialMsg, envs.Language(contactLang), scriptPath, mocks); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if mocks != nil && mocks.HTTP != nil {
+		httpx.SetRequestor(mocks.HTTP)
+	}
+
+	engine := CreateEngine(witToken, mocks)
 
 	repro, err := RunFlow(engine, assetsPath, flowUUID, initialMsg, envs.Language(contactLang), os.Stdin, os.Stdout)
 
@@ -79,11 +105,42 @@ func main() {
 	}
 }
 
-func createEngine(witToken string) flows.Engine {
+func runScript(assetsPath string, flowUUID assets.FlowUUID, initialMsg string, contactLang envs.Language, scriptPath string, mocks *Mocks) error {
+	script, err := os.Open(scriptPath)
+	if err != nil {
+		return err
+	}
+	defer script.Close()
+
+	var httpMocks *httpx.MockRequestor
+	if mocks != nil {
+		httpMocks = mocks.HTTP
+	}
+
+	fixture, err := RunScript(assetsPath, flowUUID, initialMsg, contactLang, script, httpMocks)
+	if err != nil {
+		return err
+	}
+
+	fixtureJSON, err := MarshalFixture(fixture)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(fixtureJSON))
+	return nil
+}
+
+// CreateEngine creates the engine to run flows with, using mocked services where they are provided
+func CreateEngine(witToken string, mocks *Mocks) flows.Engine {
 	builder := engine.NewBuilder().
-		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-runner", 10000))
+		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-runner", 10000, nil, nil, nil))
 
-	if witToken != "" {
+	if mocks != nil && mocks.Classifications != nil {
+		builder.WithClassificationServiceFactory(func(session flows.Session, classifier *flows.Classifier) (flows.ClassificationService, error) {
+			return &mockClassificationService{classifications: mocks.Classifications}, nil
+		})
+	} else if witToken != "" {
 		builder.WithClassificationServiceFactory(func(session flows.Session, classifier *flows.Classifier) (flows.ClassificationService, error) {
 			if classifier.Type() == "wit" {
 				return wit.NewService(classifier, witToken), nil
@@ -92,49 +149,37 @@ func createEngine(witToken string) flows.Engine {
 		})
 	}
 
-	return builder.Build()
-}
-
-// RunFlow steps through a flow
-func RunFlow(eng flows.Engine, assetsPath string, flowUUID assets.FlowUUID, initialMsg string, contactLang envs.Language, in io.Reader, out io.Writer) (*Repro, error) {
-	source, err := static.LoadSource(assetsPath)
-	if err != nil {
-		return nil, err
+	if mocks != nil && mocks.Airtime != nil {
+		builder.WithAirtimeServiceFactory(func(flows.Session) (flows.AirtimeService, error) {
+			return &mockAirtimeService{mock: mocks.Airtime}, nil
+		})
 	}
 
-	sa, err := engine.NewSessionAssets(source)
-	if err != nil {
-		return nil, errors.Wrap(err, "error parsing assets")
+	if mocks != nil && mocks.LLM != nil {
+		builder.WithLLMServiceFactory(func(flows.Session) (flows.LLMService, error) {
+			return &mockLLMService{outputs: mocks.LLM}, nil
+		})
 	}
 
-	flow, err := sa.Flows().Get(flowUUID)
-	if err != nil {
-		return nil, err
+	if mocks != nil && mocks.Credentials != nil {
+		builder.WithCredentialServiceFactory(func(flows.Session) (flows.CredentialService, error) {
+			return &mockCredentialService{tokens: mocks.Credentials}, nil
+		})
 	}
 
-	if err := flow.ValidateRecursive(sa, nil); err != nil {
-		return nil, err
-	}
+	return builder.Build()
+}
 
-	contact, err := flows.ReadContact(sa, json.RawMessage(contactJSON), assets.PanicOnMissing)
+// RunFlow steps through a flow
+func RunFlow(eng flows.Engine, assetsPath string, flowUUID assets.FlowUUID, initialMsg string, contactLang envs.Language, in io.Reader, out io.Writer) (*Repro, error) {
+	sa, flow, trigger, err := createTrigger(assetsPath, flowUUID, initialMsg, contactLang)
 	if err != nil {
 		return nil, err
 	}
-	contact.SetLanguage(contactLang)
-
-	// create our environment
-	la, _ := time.LoadLocation("America/Los_Angeles")
-	languages := []envs.Language{flow.Language(), contact.Language()}
-	env := envs.NewBuilder().WithTimezone(la).WithAllowedLanguages(languages).Build()
+	contact := trigger.Contact()
 
-	repro := &Repro{}
+	repro := &Repro{Trigger: trigger}
 
-	if initialMsg != "" {
-		msg := createMessage(contact, initialMsg)
-		repro.Trigger = triggers.NewMsg(env, flow.Reference(), contact, msg, nil)
-	} else {
-		repro.Trigger = triggers.NewManual(env, flow.Reference(), contact, nil)
-	}
 	fmt.Fprintf(out, "Starting flow '%s'....\n---------------------------------------\n", flow.Name())
 
 	// start our session
@@ -150,17 +195,15 @@ func RunFlow(eng flows.Engine, assetsPath string, flowUUID assets.FlowUUID, init
 
 		// ask for input
 		fmt.Fprintf(out, "> ")
-		scanner.Scan()
-
-		text := scanner.Text()
-		var resume flows.Resume
+		if !scanner.Scan() {
+			break
+		}
 
 		// create our resume
-		if text == "/timeout" {
-			resume = resumes.NewWaitTimeout(nil, nil)
-		} else {
-			msg := createMessage(contact, scanner.Text())
-			resume = resumes.NewMsg(nil, nil, msg)
+		resume, err := createResume(contact, scanner.Text())
+		if err != nil {
+			fmt.Fprintln(out, err.Error())
+			continue
 		}
 
 		repro.Resumes = append(repro.Resumes, resume)
@@ -176,14 +219,119 @@ func RunFlow(eng flows.Engine, assetsPath string, flowUUID assets.FlowUUID, init
 	return repro, nil
 }
 
-func createMessage(contact *flows.Contact, text string) *flows.MsgIn {
-	return flows.NewMsgIn(flows.MsgUUID(uuids.New()), contact.URNs()[0].URN(), nil, text, []utils.Attachment{})
+// CommandsHelp describes the commands which can be used instead of messages
+const CommandsHelp = `commands:
+  /timeout                      time out the current wait
+  /expire                       expire the current run
+  /attach <type:url> [text]     send a message with an attachment, e.g. /attach image/jpeg:http://example.com/cat.jpg
+  /location <lat>,<lng> [text]  send a message with a location, e.g. /location -2.90875,-79.0117686
+  /dial <status> [duration]     end the current dial wait, e.g. /dial answered 20
+  anything else is sent as a message`
+
+// creates a resume from a line of input which can be a command or the text of a message
+func createResume(contact *flows.Contact, input string) (flows.Resume, error) {
+	if !strings.HasPrefix(input, "/") {
+		return resumes.NewMsg(nil, nil, createMessage(contact, input, nil)), nil
+	}
+
+	parts := strings.SplitN(input, " ", 3)
+	command, args := parts[0], parts[1:]
+
+	switch command {
+	case "/timeout":
+		return resumes.NewWaitTimeout(nil, nil), nil
+	case "/expire":
+		return resumes.NewRunExpiration(nil, nil), nil
+	case "/attach", "/location":
+		if len(args) < 1 {
+			return nil, errors.Errorf("%s requires an argument", command)
+		}
+		attachment := utils.Attachment(args[0])
+		if command == "/location" {
+			attachment = utils.Attachment("geo:" + args[0])
+		}
+		if attachment.ContentType() == "" || attachment.URL() == "" {
+			return nil, errors.Errorf("'%s' is not a valid attachment", args[0])
+		}
+
+		text := ""
+		if len(args) > 1 {
+			text = args[1]
+		}
+		return resumes.NewMsg(nil, nil, createMessage(contact, text, []utils.Attachment{attachment})), nil
+	case "/dial":
+		if len(args) < 1 {
+			return nil, errors.New("/dial requires a status")
+		}
+		duration := 0
+		if len(args) > 1 {
+			var err error
+			if duration, err = strconv.Atoi(args[1]); err != nil {
+				return nil, errors.Errorf("'%s' is not a valid duration", args[1])
+			}
+		}
+		dial := flows.NewDial(flows.DialStatus(args[0]), duration)
+		if err := utils.Validate(dial); err != nil {
+			return nil, errors.Errorf("'%s' is not a valid dial status", args[0])
+		}
+		return resumes.NewDial(nil, nil, dial), nil
+	}
+
+	return nil, errors.Errorf("unknown command %s\n%s", command, CommandsHelp)
+}
+
+// loads the assets and creates a trigger for the given flow
+func createTrigger(assetsPath string, flowUUID assets.FlowUUID, initialMsg string, contactLang envs.Language) (flows.SessionAssets, flows.Flow, flows.Trigger, error) {
+	source, err := static.LoadSource(assetsPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	sa, err := engine.NewSessionAssets(source)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error parsing assets")
+	}
+
+	flow, err := sa.Flows().Get(flowUUID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := flow.ValidateRecursive(sa, nil); err != nil {
+		return nil, nil, nil, err
+	}
+
+	contact, err := flows.ReadContact(sa, json.RawMessage(contactJSON), assets.PanicOnMissing)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	contact.SetLanguage(contactLang)
+
+	// create our environment
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	languages := []envs.Language{flow.Language(), contact.Language()}
+	env := envs.NewBuilder().WithTimezone(la).WithAllowedLanguages(languages).Build()
+
+	if initialMsg != "" {
+		msg := createMessage(contact, initialMsg, nil)
+		return sa, flow, triggers.NewMsg(env, flow.Reference(), contact, msg, nil), nil
+	}
+	return sa, flow, triggers.NewManual(env, flow.Reference(), contact, nil), nil
+}
+
+func createMessage(contact *flows.Contact, text string, attachments []utils.Attachment) *flows.MsgIn {
+	if attachments == nil {
+		attachments = []utils.Attachment{}
+	}
+	return flows.NewMsgIn(flows.MsgUUID(uuids.New()), contact.URNs()[0].URN(), nil, text, attachments)
 }
 
 func printEvents(log []flows.Event, out io.Writer) {
 	for _, event := range log {
 		var msg string
 		switch typed := event.(type) {
+		case *events.AirtimeTransferredEvent:
+			msg = fmt.Sprintf("💸 %s %s of airtime transferred to %s", typed.ActualAmount, typed.Currency, typed.Recipient)
 		case *events.BroadcastCreatedEvent:
 			text := typed.Translations[typed.BaseLanguage].Text
 			msg = fmt.Sprintf("🔉 broadcasted '%s' to ...", text)
@@ -222,6 +370,10 @@ func printEvents(log []flows.Event, out io.Writer) {
 			msg = "👤 contact refreshed on resume"
 		case *events.ContactTimezoneChangedEvent:
 			msg = fmt.Sprintf("🕑 timezone changed to '%s'", typed.Timezone)
+		case *events.DialEndedEvent:
+			msg = fmt.Sprintf("☎️ dial ended with status '%s'", typed.Dial.Status)
+		case *events.DialWaitEvent:
+			msg = fmt.Sprintf("⏳ waiting for dial to %s (type /dial <status> to simulate)....", typed.URN)
 		case *events.EnvironmentRefreshedEvent:
 			msg = "⚙️ environment refreshed on resume"
 		case *events.ErrorEvent:
@@ -238,10 +390,15 @@ func printEvents(log []flows.Event, out io.Writer) {
 			msg = fmt.Sprintf("🏷️ labeled with %s", strings.Join(labels, ", "))
 		case *events.IVRCreatedEvent:
 			msg = fmt.Sprintf("📞 IVR created \"%s\"", typed.Msg.Text())
+		case *events.LLMCalledEvent:
+			msg = fmt.Sprintf("🤖 LLM called with instructions '%s'", truncate(typed.Instructions, 50))
 		case *events.MsgCreatedEvent:
 			msg = fmt.Sprintf("💬 message created \"%s\"", typed.Msg.Text())
 		case *events.MsgReceivedEvent:
 			msg = fmt.Sprintf("📥 message received \"%s\"", typed.Msg.Text())
+			for _, attachment := range typed.Msg.Attachments() {
+				msg += fmt.Sprintf(" 📎 %s", attachment)
+			}
 		case *events.MsgWaitEvent:
 			if typed.TimeoutSeconds != nil {
 				msg = fmt.Sprintf("⏳ waiting for message (%d sec timeout, type /timeout to simulate)....", *typed.TimeoutSeconds)
@@ -6,9 +6,11 @@ import (
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils/httpx"
 
 	main "github.com/nyaruka/goflow/cmd/flowrunner"
 
+	"github.com/buger/jsonparser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,3 +42,92 @@ func TestRunFlow(t *testing.T) {
 		"",
 	}, lines)
 }
+
+func TestRunFlowCommands(t *testing.T) {
+	in := strings.NewReader("/foo\n/attach image/jpeg:http://example.com/a.jpg I like red\n/location -2.90875,-79.0117686\n/dial foo\n/dial busy\n/expire\n")
+	out := &strings.Builder{}
+
+	repro, err := main.RunFlow(test.NewEngine(), "testdata/two_questions.json", assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"), "", "eng", in, out)
+	require.NoError(t, err)
+
+	// invalid commands don't create resumes
+	require.Equal(t, 4, len(repro.Resumes))
+	assert.Equal(t, "msg", repro.Resumes[0].Type())
+	assert.Equal(t, "msg", repro.Resumes[1].Type())
+	assert.Equal(t, "dial", repro.Resumes[2].Type())
+	assert.Equal(t, "run_expiration", repro.Resumes[3].Type())
+
+	output := strings.Replace(out.String(), "> ", "", -1)
+
+	assert.Contains(t, output, "unknown command /foo\ncommands:\n")
+	assert.Contains(t, output, "📥 message received \"I like red\" 📎 image/jpeg:http://example.com/a.jpg\n")
+	assert.Contains(t, output, "📥 message received \"\" 📎 geo:-2.90875,-79.0117686\n")
+	assert.Contains(t, output, "'foo' is not a valid dial status\n")
+	assert.Contains(t, output, "⚠️ can't end a msg wait with a dial resume\n")
+	assert.Contains(t, output, "📆 exiting due to expiration\n")
+}
+
+func TestRunFlowWithMocks(t *testing.T) {
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	mocks, err := main.LoadMocks("testdata/mocks.json")
+	require.NoError(t, err)
+
+	httpx.SetRequestor(mocks.HTTP)
+
+	out := &strings.Builder{}
+
+	_, err = main.RunFlow(main.CreateEngine("", mocks), "testdata/services.json", assets.FlowUUID("8b4c0b5e-5b94-4a63-8a4a-8b3b1e0ac3a1"), "book a flight", "eng", strings.NewReader(""), out)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Starting flow 'Services'....",
+		"---------------------------------------",
+		"📥 message received \"book a flight\"",
+		"☁️ called http://example.com/",
+		"📈 run result 'Webhook' changed to '200' with category 'Success'",
+		"📈 run result 'Intent' changed to 'book_flight' with category 'Success'",
+		"💸 500 RWF of airtime transferred to tel:+12065551212",
+		"📈 run result 'Reward' changed to '500' with category 'Success'",
+		"☁️ called http://example.com/secure",
+		"📈 run result 'Secure' changed to '200' with category 'Success'",
+		"🤖 LLM called with instructions 'Translate to French'",
+		"📈 run result 'Translation' changed to 'réserver un vol' with category 'Success'",
+		"💬 message created \"Success book_flight Success Success réserver un vol\"",
+		"",
+	}, strings.Split(out.String(), "\n"))
+
+	_, err = main.LoadMocks("testdata/missing.json")
+	assert.Error(t, err)
+}
+
+func TestRunScript(t *testing.T) {
+	script := strings.NewReader("# answer the questions\nI like red\n\n/attach image/jpeg:http://example.com/pepsi.jpg pepsi\n")
+
+	fixture, err := main.RunScript("testdata/two_questions.json", assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"), "", "eng", script, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, len(fixture.Resumes))
+	assert.Equal(t, 3, len(fixture.Outputs))
+	assert.Nil(t, fixture.HTTPMocks)
+
+	// outputs are generated with the same UUIDs and timestamps as the runner tests
+	status, _ := jsonparser.GetString(fixture.Outputs[2].Session, "status")
+	assert.Equal(t, "completed", status)
+	sessionUUID, _ := jsonparser.GetString(fixture.Outputs[0].Session, "uuid")
+	assert.Equal(t, "d2f852ec-7b4e-457f-ae7f-f8b243c49ff5", sessionUUID)
+	createdOn, _ := jsonparser.GetString(fixture.Outputs[0].Events[0], "created_on")
+	assert.Equal(t, "2018-07-06T12:30:04.123456789Z", createdOn)
+
+	fixtureJSON, err := main.MarshalFixture(fixture)
+	require.NoError(t, err)
+	assert.Contains(t, string(fixtureJSON), `"image/jpeg:http://example.com/pepsi.jpg"`)
+
+	// script inputs must be valid
+	_, err = main.RunScript("testdata/two_questions.json", assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"), "", "eng", strings.NewReader("red\n/foo\n"), nil)
+	assert.EqualError(t, err, "error on line 2 of script: unknown command /foo\n"+main.CommandsHelp)
+
+	// and there can't be more of them than waits
+	_, err = main.RunScript("testdata/two_questions.json", assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"), "", "eng", strings.NewReader("red\npepsi\nthanks\n"), nil)
+	assert.EqualError(t, err, "session is not waiting, have unused script inputs: 1")
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils/httpx"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Mocks are mocked responses for the services used by flows, loaded from a file like:
+//
+//   {
+//     "http": {
+//       "http://example.com/": [{"status": 200, "body": "{\"ok\": true}"}]
+//     },
+//     "classifications": {
+//       "book a flight": {"intents": [{"name": "book_flight", "confidence": 0.9}]},
+//       "*": {"intents": [{"name": "other", "confidence": 0.5}]}
+//     },
+//     "airtime": {"currency": "RWF"},
+//     "llm": {
+//       "hello": "bonjour",
+//       "*": "je ne sais pas"
+//     },
+//     "credentials": {"acme_api": "sesame"}
+//   }
+//
+// Classifications and LLM outputs are matched against the input and the value for "*" is used if there's no match.
+// Credentials are added to requests as bearer tokens.
+type Mocks struct {
+	HTTP            *httpx.MockRequestor             `json:"http,omitempty"`
+	Classifications map[string]*flows.Classification `json:"classifications,omitempty"`
+	Airtime         *AirtimeMock                     `json:"airtime,omitempty"`
+	LLM             map[string]string                `json:"llm,omitempty"`
+	Credentials     map[string]string                `json:"credentials,omitempty"`
+}
+
+// AirtimeMock describes how mocked airtime transfers behave
+type AirtimeMock struct {
+	Currency string `json:"currency"`
+	Fail     bool   `json:"fail,omitempty"`
+}
+
+// LoadMocks loads mocks from the given file
+func LoadMocks(path string) (*Mocks, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mocks := &Mocks{}
+	if err := json.Unmarshal(data, mocks); err != nil {
+		return nil, errors.Wrapf(err, "unable to read mocks from %s", path)
+	}
+	return mocks, nil
+}
+
+// classification service which returns mocked classifications
+type mockClassificationService struct {
+	classifications map[string]*flows.Classification
+}
+
+func (s *mockClassificationService) Classify(session flows.Session, input string, logHTTP flows.HTTPLogCallback) (*flows.Classification, error) {
+	if c, exists := s.classifications[input]; exists {
+		return c, nil
+	}
+	if c, exists := s.classifications["*"]; exists {
+		return c, nil
+	}
+	return nil, errors.Errorf("no mocked classification for input '%s'", input)
+}
+
+var _ flows.ClassificationService = (*mockClassificationService)(nil)
+
+// airtime service which pretends to transfer airtime in a fixed currency
+type mockAirtimeService struct {
+	mock *AirtimeMock
+}
+
+func (s *mockAirtimeService) Transfer(session flows.Session, sender urns.URN, recipient urns.URN, amounts map[string]decimal.Decimal, logHTTP flows.HTTPLogCallback) (*flows.AirtimeTransfer, error) {
+	if s.mock.Fail {
+		return nil, errors.New("mocked airtime transfer failure")
+	}
+
+	amount, hasAmount := amounts[s.mock.Currency]
+	if !hasAmount {
+		return nil, errors.Errorf("no amount configured for transfers in %s", s.mock.Currency)
+	}
+
+	return &flows.AirtimeTransfer{
+		Sender:        sender,
+		Recipient:     recipient,
+		Currency:      s.mock.Currency,
+		DesiredAmount: amount,
+		ActualAmount:  amount,
+	}, nil
+}
+
+var _ flows.AirtimeService = (*mockAirtimeService)(nil)
+
+// LLM service which returns mocked outputs
+type mockLLMService struct {
+	outputs map[string]string
+}
+
+func (s *mockLLMService) Response(session flows.Session, instructions string, input string, logHTTP flows.HTTPLogCallback) (*flows.LLMResponse, error) {
+	if output, exists := s.outputs[input]; exists {
+		return &flows.LLMResponse{Output: output}, nil
+	}
+	if output, exists := s.outputs["*"]; exists {
+		return &flows.LLMResponse{Output: output}, nil
+	}
+	return nil, errors.Errorf("no mocked LLM output for input '%s'", input)
+}
+
+var _ flows.LLMService = (*mockLLMService)(nil)
+
+// credential service which provides mocked bearer tokens
+type mockCredentialService struct {
+	tokens map[string]string
+}
+
+func (s *mockCredentialService) Credential(session flows.Session, name string) (flows.Credential, error) {
+	token, exists := s.tokens[name]
+	if !exists {
+		return nil, errors.Errorf("no mocked credential named '%s'", name)
+	}
+	return &mockCredential{token: token}, nil
+}
+
+var _ flows.CredentialService = (*mockCredentialService)(nil)
+
+// credential which adds a fixed bearer token to requests
+type mockCredential struct {
+	token string
+}
+
+func (c *mockCredential) Authorize(request *http.Request) ([]string, error) {
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	return []string{c.token}, nil
+}
+
+var _ flows.Credential = (*mockCredential)(nil)
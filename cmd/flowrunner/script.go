@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/triggers"
+	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/httpx"
+	"github.com/nyaruka/goflow/utils/uuids"
+
+	"github.com/pkg/errors"
+)
+
+// Fixture is a flow test in the format used by the runner tests in test/testdata/runner
+type Fixture struct {
+	Trigger   json.RawMessage      `json:"trigger"`
+	Resumes   []json.RawMessage    `json:"resumes"`
+	Outputs   []*FixtureOutput     `json:"outputs"`
+	HTTPMocks *httpx.MockRequestor `json:"http_mocks,omitempty"`
+}
+
+// FixtureOutput is the session and events after a sprint in a flow test
+type FixtureOutput struct {
+	Session json.RawMessage   `json:"session"`
+	Events  []json.RawMessage `json:"events"`
+}
+
+// RunScript runs a flow without prompting for input, using each line of the given script as the input at each wait, and
+// returns a runner test fixture. Lines are interpreted the same as in interactive mode and blank lines or those starting
+// with # are ignored. The flow is run the same way as the runner tests so the fixture can be added to them as is.
+func RunScript(assetsPath string, flowUUID assets.FlowUUID, initialMsg string, contactLang envs.Language, script io.Reader, httpMocks *httpx.MockRequestor) (*Fixture, error) {
+	sa, _, trigger, err := createTrigger(assetsPath, flowUUID, initialMsg, contactLang)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{}
+	if httpMocks != nil {
+		fixture.HTTPMocks = httpMocks.Clone()
+	}
+
+	if fixture.Trigger, err = utils.JSONMarshal(trigger); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(script)
+	for line := 1; scanner.Scan(); line++ {
+		input := scanner.Text()
+		if strings.TrimSpace(input) == "" || strings.HasPrefix(input, "#") {
+			continue
+		}
+
+		resume, err := createResume(trigger.Contact(), input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error on line %d of script", line)
+		}
+
+		resumeJSON, err := utils.JSONMarshal(resume)
+		if err != nil {
+			return nil, err
+		}
+		fixture.Resumes = append(fixture.Resumes, resumeJSON)
+	}
+
+	// now run the trigger and resumes the same way the runner tests do, i.e. from JSON and with the same generators
+	defer uuids.SetGenerator(uuids.DefaultGenerator)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
+	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+
+	if httpMocks != nil {
+		httpx.SetRequestor(httpMocks)
+		defer httpx.SetRequestor(httpx.DefaultRequestor)
+	}
+
+	eng := test.NewRunnerEngine().Build()
+
+	if trigger, err = triggers.ReadTrigger(sa, fixture.Trigger, assets.PanicOnMissing); err != nil {
+		return nil, err
+	}
+
+	session, sprint, err := eng.NewSession(sa, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, resumeJSON := range fixture.Resumes {
+		output, err := newFixtureOutput(session, sprint)
+		if err != nil {
+			return nil, err
+		}
+		fixture.Outputs = append(fixture.Outputs, output)
+
+		if session, err = eng.ReadSession(sa, output.Session, assets.PanicOnMissing); err != nil {
+			return nil, err
+		}
+
+		if session.Wait() == nil {
+			return nil, errors.Errorf("session is not waiting, have unused script inputs: %d", len(fixture.Resumes[i:]))
+		}
+
+		resume, err := resumes.ReadResume(sa, resumeJSON, assets.PanicOnMissing)
+		if err != nil {
+			return nil, err
+		}
+
+		if sprint, err = session.Resume(resume); err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := newFixtureOutput(session, sprint)
+	if err != nil {
+		return nil, err
+	}
+	fixture.Outputs = append(fixture.Outputs, output)
+
+	return fixture, nil
+}
+
+func newFixtureOutput(session flows.Session, sprint flows.Sprint) (*FixtureOutput, error) {
+	sessionJSON, err := utils.JSONMarshalPretty(session)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling session")
+	}
+
+	events := make([]json.RawMessage, len(sprint.Events()))
+	for i, event := range sprint.Events() {
+		if events[i], err = utils.JSONMarshal(event); err != nil {
+			return nil, errors.Wrap(err, "error marshalling event")
+		}
+	}
+
+	return &FixtureOutput{Session: sessionJSON, Events: events}, nil
+}
+
+// MarshalFixture marshals the given fixture as formatted JSON like the runner tests
+func MarshalFixture(fixture *Fixture) ([]byte, error) {
+	fixtureJSON, err := utils.JSONMarshalPretty(fixture)
+	if err != nil {
+		return nil, err
+	}
+	return test.NormalizeJSON(fixtureJSON)
+}
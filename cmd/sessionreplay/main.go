@@ -0,0 +1,358 @@
+package main
+
+// go install github.com/nyaruka/goflow/cmd/sessionreplay
+// flowrunner -repro assets.json <flow_uuid> > repro.json
+// sessionreplay assets.json repro.json
+// sessionreplay -expected expected.json assets.json repro.json
+// sessionreplay -coverage text assets.json repro1.json repro2.json
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/coverage"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/triggers"
+	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/httpx"
+	"github.com/nyaruka/goflow/utils/uuids"
+
+	"github.com/pkg/errors"
+	diff "github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const usage = `usage: sessionreplay [flags] <assets.json> <repro.json>...`
+
+func main() {
+	var expectedPath, coverageFormat string
+	var seed int
+	flags := flag.NewFlagSet("", flag.ExitOnError)
+	flags.StringVar(&expectedPath, "expected", "", "file of expected outputs to diff against")
+	flags.StringVar(&coverageFormat, "coverage", "", "print coverage of flows as text or json")
+	flags.IntVar(&seed, "seed", 123456, "seed for generated UUIDs")
+	flags.Parse(os.Args[1:])
+	args := flags.Args()
+
+	if len(args) < 2 || (expectedPath != "" && len(args) != 2) {
+		fmt.Println(usage)
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+	if coverageFormat != "" && coverageFormat != "text" && coverageFormat != "json" {
+		fmt.Printf("unknown coverage format '%s'\n", coverageFormat)
+		os.Exit(1)
+	}
+
+	eng := test.NewEngine()
+	finalSessions := make([]json.RawMessage, 0, len(args)-1)
+
+	for _, reproPath := range args[1:] {
+		// make generated UUIDs and timestamps deterministic so outputs can be compared
+		uuids.SetGenerator(uuids.NewSeededGenerator(int64(seed)))
+		dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+
+		reproJSON, err := ioutil.ReadFile(reproPath)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		outputs, err := Replay(eng, args[0], reproJSON)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		if len(args) > 2 {
+			fmt.Printf("Repro %s\n=======================================\n", reproPath)
+		}
+
+		PrintOutputs(outputs, os.Stdout)
+
+		if len(outputs) > 0 {
+			finalSessions = append(finalSessions, outputs[len(outputs)-1].Session)
+		}
+
+		if expectedPath != "" {
+			expectedJSON, err := ioutil.ReadFile(expectedPath)
+			if err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+
+			same, err := Diff(expectedJSON, outputs, os.Stdout)
+			if err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+			if !same {
+				os.Exit(1)
+			}
+		}
+	}
+
+	if coverageFormat != "" {
+		if err := PrintCoverage(eng, args[0], finalSessions, coverageFormat == "json", os.Stdout); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
+}
+
+// Repro describes how to reproduce a session, either from a trigger or an existing session, and is
+// compatible with the repro output of flowrunner
+type Repro struct {
+	Trigger   json.RawMessage      `json:"trigger,omitempty"`
+	Session   json.RawMessage      `json:"session,omitempty"`
+	Resumes   []json.RawMessage    `json:"resumes"`
+	HTTPMocks *httpx.MockRequestor `json:"http_mocks,omitempty"`
+}
+
+// Output is the events and resulting session of a single sprint
+type Output struct {
+	Session json.RawMessage   `json:"session"`
+	Events  []json.RawMessage `json:"events"`
+}
+
+// Expected is a file of expected outputs, e.g. a flow test from test/testdata/runner
+type Expected struct {
+	Outputs []*Output `json:"outputs"`
+}
+
+// Replay replays the given trigger or session and resumes, returning the output of each sprint
+func Replay(eng flows.Engine, assetsPath string, reproJSON json.RawMessage) ([]*Output, error) {
+	replay := &Repro{}
+	if err := json.Unmarshal(reproJSON, replay); err != nil {
+		return nil, errors.Wrap(err, "unable to read repro")
+	}
+	if (replay.Trigger == nil) == (replay.Session == nil) {
+		return nil, errors.New("repro must have a trigger or a session")
+	}
+
+	sa, err := loadAssets(assetsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if replay.HTTPMocks != nil {
+		httpx.SetRequestor(replay.HTTPMocks)
+		defer httpx.SetRequestor(httpx.DefaultRequestor)
+	}
+
+	outputs := make([]*Output, 0, len(replay.Resumes)+1)
+	var session flows.Session
+
+	if replay.Trigger != nil {
+		trigger, err := triggers.ReadTrigger(sa, replay.Trigger, assets.PanicOnMissing)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read trigger")
+		}
+
+		var sprint flows.Sprint
+		session, sprint, err = eng.NewSession(sa, trigger)
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := newOutput(session, sprint)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	} else {
+		session, err = eng.ReadSession(sa, replay.Session, assets.PanicOnMissing)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read session")
+		}
+	}
+
+	for i, rawResume := range replay.Resumes {
+		if session.Wait() == nil {
+			return nil, errors.Errorf("session is not waiting, have unused resumes: %d", len(replay.Resumes[i:]))
+		}
+
+		resume, err := resumes.ReadResume(sa, rawResume, assets.PanicOnMissing)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read resume %d", i)
+		}
+
+		sprint, err := session.Resume(resume)
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := newOutput(session, sprint)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+func loadAssets(path string) (flows.SessionAssets, error) {
+	source, err := static.LoadSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sa, err := engine.NewSessionAssets(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing assets")
+	}
+	return sa, nil
+}
+
+func newOutput(session flows.Session, sprint flows.Sprint) (*Output, error) {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling session")
+	}
+
+	events := make([]json.RawMessage, len(sprint.Events()))
+	for i, event := range sprint.Events() {
+		if events[i], err = json.Marshal(event); err != nil {
+			return nil, errors.Wrap(err, "error marshalling event")
+		}
+	}
+
+	return &Output{Session: sessionJSON, Events: events}, nil
+}
+
+// PrintOutputs prints the event log of each sprint
+func PrintOutputs(outputs []*Output, out io.Writer) {
+	for i, output := range outputs {
+		fmt.Fprintf(out, "Sprint %d\n---------------------------------------\n", i+1)
+
+		for _, event := range output.Events {
+			fmt.Fprintln(out, string(event))
+		}
+	}
+}
+
+// Diff compares the given outputs with the expected outputs, printing any differences in the events of each
+// sprint and in the final session, and returns whether they are the same
+func Diff(expectedJSON json.RawMessage, outputs []*Output, out io.Writer) (bool, error) {
+	expected := &Expected{}
+	if err := json.Unmarshal(expectedJSON, expected); err != nil {
+		return false, errors.Wrap(err, "unable to read expected outputs")
+	}
+
+	if len(expected.Outputs) != len(outputs) {
+		fmt.Fprintf(out, "❌ expected %d sprints but got %d\n", len(expected.Outputs), len(outputs))
+		return false, nil
+	}
+
+	same := true
+
+	for i, output := range outputs {
+		// runner tests omit events for sprints without any
+		if expected.Outputs[i].Events == nil {
+			expected.Outputs[i].Events = []json.RawMessage{}
+		}
+
+		expectedEvents, _ := json.Marshal(expected.Outputs[i].Events)
+		actualEvents, _ := json.Marshal(output.Events)
+
+		different, err := diffJSON(expectedEvents, actualEvents, fmt.Sprintf("events of sprint %d", i+1), out)
+		if err != nil {
+			return false, err
+		}
+		same = same && !different
+	}
+
+	last := len(outputs) - 1
+	if last >= 0 {
+		different, err := diffJSON(expected.Outputs[last].Session, outputs[last].Session, "final session", out)
+		if err != nil {
+			return false, err
+		}
+		same = same && !different
+	}
+
+	if same {
+		fmt.Fprintln(out, "✅ outputs match expected")
+	}
+
+	return same, nil
+}
+
+// prints the difference between two JSON documents if they differ and returns whether they did
+func diffJSON(expected, actual json.RawMessage, description string, out io.Writer) (bool, error) {
+	expectedNormalized, err := test.NormalizeJSON(expected)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to normalize expected %s", description)
+	}
+	actualNormalized, err := test.NormalizeJSON(actual)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to normalize actual %s", description)
+	}
+
+	differ := diff.New()
+	diffs := differ.DiffMain(string(expectedNormalized), string(actualNormalized), false)
+
+	if len(diffs) == 1 && diffs[0].Type == diff.DiffEqual {
+		return false, nil
+	}
+
+	fmt.Fprintf(out, "❌ %s different from expected:\n%s\n", description, differ.DiffPrettyText(diffs))
+	return true, nil
+}
+
+// PrintCoverage prints a coverage report as text or JSON for each flow which has runs in the given sessions
+func PrintCoverage(eng flows.Engine, assetsPath string, sessions []json.RawMessage, asJSON bool, out io.Writer) error {
+	sa, err := loadAssets(assetsPath)
+	if err != nil {
+		return err
+	}
+
+	coverages := make(map[assets.FlowUUID]*coverage.Coverage)
+	flowUUIDs := make([]assets.FlowUUID, 0)
+
+	for _, sessionJSON := range sessions {
+		session, err := eng.ReadSession(sa, sessionJSON, assets.PanicOnMissing)
+		if err != nil {
+			return errors.Wrap(err, "unable to read session")
+		}
+
+		for _, run := range session.Runs() {
+			flow := run.Flow()
+			if coverages[flow.UUID()] == nil {
+				coverages[flow.UUID()] = coverage.New(flow)
+				flowUUIDs = append(flowUUIDs, flow.UUID())
+			}
+			coverages[flow.UUID()].AddRun(run)
+		}
+	}
+
+	reports := make([]*coverage.Report, len(flowUUIDs))
+	for i, flowUUID := range flowUUIDs {
+		reports[i] = coverages[flowUUID].Report()
+	}
+
+	if asJSON {
+		reportsJSON, err := utils.JSONMarshalPretty(reports)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(reportsJSON))
+		return nil
+	}
+
+	for _, report := range reports {
+		report.WriteText(out)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	main "github.com/nyaruka/goflow/cmd/sessionreplay"
+	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/uuids"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const assetsPath = "../../test/testdata/runner/two_questions.json"
+
+func resetGenerators() {
+	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
+	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+}
+
+func TestReplay(t *testing.T) {
+	defer uuids.SetGenerator(uuids.DefaultGenerator)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	// runner tests can be used as both the repro and the expected outputs
+	testJSON, err := ioutil.ReadFile("../../test/testdata/runner/two_questions.test.json")
+	require.NoError(t, err)
+
+	resetGenerators()
+
+	outputs, err := main.Replay(test.NewEngine(), assetsPath, testJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(outputs))
+
+	out := &strings.Builder{}
+	main.PrintOutputs(outputs, out)
+	assert.Contains(t, out.String(), "Sprint 1\n---------------------------------------\n{\"type\":\"msg_created\"")
+	assert.Contains(t, out.String(), "Sprint 3\n")
+
+	out = &strings.Builder{}
+	same, err := main.Diff(testJSON, outputs, out)
+	require.NoError(t, err)
+	assert.True(t, same)
+	assert.Equal(t, "✅ outputs match expected\n", out.String())
+
+	// change an expected event and the final session
+	changed := test.JSONReplace(testJSON, []string{"outputs", "[1]", "events", "[0]", "msg", "text"}, []byte(`"I like green"`))
+	changed = test.JSONReplace(changed, []string{"outputs", "[2]", "session", "status"}, []byte(`"waiting"`))
+
+	out = &strings.Builder{}
+	same, err = main.Diff(changed, outputs, out)
+	require.NoError(t, err)
+	assert.False(t, same)
+	assert.Contains(t, out.String(), "❌ events of sprint 2 different from expected:")
+	assert.Contains(t, out.String(), "❌ final session different from expected:")
+	assert.NotContains(t, out.String(), "sprint 1")
+
+	// replay from the session after the first sprint with the remaining resumes
+	repro := &struct {
+		Session json.RawMessage   `json:"session"`
+		Resumes []json.RawMessage `json:"resumes"`
+	}{}
+	require.NoError(t, json.Unmarshal(testJSON, repro))
+	repro.Session = outputs[0].Session
+	reproJSON, err := json.Marshal(repro)
+	require.NoError(t, err)
+
+	fromSession, err := main.Replay(test.NewEngine(), assetsPath, reproJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(fromSession))
+	assert.Equal(t, len(outputs[1].Events), len(fromSession[0].Events))
+	assert.Equal(t, len(outputs[2].Events), len(fromSession[1].Events))
+
+	// number of sprints must match
+	out = &strings.Builder{}
+	same, err = main.Diff(testJSON, fromSession, out)
+	require.NoError(t, err)
+	assert.False(t, same)
+	assert.Equal(t, "❌ expected 3 sprints but got 2\n", out.String())
+
+	// repro needs a trigger or a session
+	_, err = main.Replay(test.NewEngine(), assetsPath, []byte(`{"resumes": []}`))
+	assert.EqualError(t, err, "repro must have a trigger or a session")
+}
+
+func TestPrintCoverage(t *testing.T) {
+	defer uuids.SetGenerator(uuids.DefaultGenerator)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	testJSON, err := ioutil.ReadFile("../../test/testdata/runner/two_questions.test.json")
+	require.NoError(t, err)
+
+	resetGenerators()
+
+	outputs, err := main.Replay(test.NewEngine(), assetsPath, testJSON)
+	require.NoError(t, err)
+
+	sessions := []json.RawMessage{outputs[0].Session, outputs[2].Session}
+
+	out := &strings.Builder{}
+	err = main.PrintCoverage(test.NewEngine(), assetsPath, sessions, false, out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Two Questions (615b8a0f-588c-4d20-a05f-363b0b4ce6f4)\nruns: 2, nodes reached: 3/3 (100.0%)\n")
+	assert.Contains(t, out.String(), "    category Blue: 1\n")
+
+	out = &strings.Builder{}
+	err = main.PrintCoverage(test.NewEngine(), assetsPath, sessions, true, out)
+	require.NoError(t, err)
+
+	reports := []struct {
+		Runs           int      `json:"runs"`
+		NodesReached   int      `json:"nodes_reached"`
+		UnreachedNodes []string `json:"unreached_nodes"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &reports))
+	assert.Equal(t, 1, len(reports))
+	assert.Equal(t, 2, reports[0].Runs)
+	assert.Equal(t, 3, reports[0].NodesReached)
+	assert.Equal(t, []string{}, reports[0].UnreachedNodes)
+
+	err = main.PrintCoverage(test.NewEngine(), assetsPath, []json.RawMessage{[]byte(`{}`)}, false, out)
+	assert.Error(t, err)
+}
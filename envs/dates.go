@@ -102,6 +102,16 @@ func dateFromFormats(currentYear int, pattern *regexp.Regexp, d int, m int, y in
 // DateTimeFromString returns a datetime constructed from the passed in string, or an error if we
 // are unable to extract one
 func DateTimeFromString(env Environment, str string, fillTime bool) (time.Time, error) {
+	return dateTimeFromString(env, str, fillTime, false)
+}
+
+// DateTimeFromNaturalString is like DateTimeFromString but if there's no numeric date in the passed in
+// string, it also looks for one written in words in the environment's languages, e.g. "next friday"
+func DateTimeFromNaturalString(env Environment, str string, fillTime bool) (time.Time, error) {
+	return dateTimeFromString(env, str, fillTime, true)
+}
+
+func dateTimeFromString(env Environment, str string, fillTime bool, natural bool) (time.Time, error) {
 	str = strings.Trim(str, " \n\r\t")
 
 	// first see if we can parse in any known ISO formats, if so return that
@@ -113,7 +123,7 @@ func DateTimeFromString(env Environment, str string, fillTime bool) (time.Time,
 	}
 
 	// otherwise, try to parse according to their env settings
-	date, remainder, err := parseDate(env, str)
+	date, remainder, err := parseDate(env, str, natural)
 
 	// couldn't find a date? bail
 	if err != nil {
@@ -133,7 +143,14 @@ func DateTimeFromString(env Environment, str string, fillTime bool) (time.Time,
 // DateFromString returns a date constructed from the passed in string, or an error if we
 // are unable to extract one
 func DateFromString(env Environment, str string) (dates.Date, error) {
-	parsed, _, err := parseDate(env, str)
+	parsed, _, err := parseDate(env, str, false)
+	return parsed, err
+}
+
+// DateFromNaturalString is like DateFromString but if there's no numeric date in the passed in string,
+// it also looks for one written in words in the environment's languages, e.g. "tomorrow" or "15 de marzo"
+func DateFromNaturalString(env Environment, str string) (dates.Date, error) {
+	parsed, _, err := parseDate(env, str, true)
 	return parsed, err
 }
 
@@ -147,7 +164,7 @@ func TimeFromString(str string) (dates.TimeOfDay, error) {
 	return timeOfDay, nil
 }
 
-func parseDate(env Environment, str string) (dates.Date, string, error) {
+func parseDate(env Environment, str string, natural bool) (dates.Date, string, error) {
 	str = strings.Trim(str, " \n\r\t")
 
 	// try to parse as ISO date
@@ -159,16 +176,28 @@ func parseDate(env Environment, str string) (dates.Date, string, error) {
 	// otherwise, try to parse according to their env settings
 	currentYear := dates.Now().Year()
 
+	var date dates.Date
+	var remainder string
+
 	switch env.DateFormat() {
 	case DateFormatYearMonthDay:
-		return dateFromFormats(currentYear, patternYearMonthDay, 3, 2, 1, str)
+		date, remainder, err = dateFromFormats(currentYear, patternYearMonthDay, 3, 2, 1, str)
 	case DateFormatDayMonthYear:
-		return dateFromFormats(currentYear, patternDayMonthYear, 1, 2, 3, str)
+		date, remainder, err = dateFromFormats(currentYear, patternDayMonthYear, 1, 2, 3, str)
 	case DateFormatMonthDayYear:
-		return dateFromFormats(currentYear, patternMonthDayYear, 2, 1, 3, str)
+		date, remainder, err = dateFromFormats(currentYear, patternMonthDayYear, 2, 1, 3, str)
+	default:
+		return dates.ZeroDate, "", errors.Errorf("unknown date format: %s", env.DateFormat())
+	}
+
+	// if there's no numeric date, look for one written in words, e.g. "tomorrow" or "15 march"
+	if err != nil && natural {
+		if natural, naturalRemainder, found := parseNaturalDate(env, str); found {
+			return natural, naturalRemainder, nil
+		}
 	}
 
-	return dates.ZeroDate, "", errors.Errorf("unknown date format: %s", env.DateFormat())
+	return date, remainder, err
 }
 
 func parseTime(str string) (bool, dates.TimeOfDay) {
@@ -0,0 +1,398 @@
+package envs
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nyaruka/goflow/utils/dates"
+)
+
+// the words used to write dates in a particular language, with accents removed
+type dateWords struct {
+	days     map[string]int // relative days, e.g. tomorrow is 1
+	weekdays [7][]string    // Sunday first like time.Weekday
+	bare     []string       // weekdays which are also ordinary words, e.g. segunda (second), so need a modifier
+	months   [12][]string
+	next     []string // modifiers for the next weekday, e.g. next friday
+	last     []string // modifiers for the previous weekday, e.g. last friday
+	in       []string // words before an amount of time in the future, e.g. in 3 days
+	ago      []string // words before an amount of time in the past, e.g. hace 3 dias
+	agoAfter []string // words after an amount of time in the past, e.g. 3 days ago
+	dayUnits []string
+	weekUnit []string
+}
+
+var naturalDateWords = map[Language]*dateWords{
+	"eng": {
+		days: map[string]int{"today": 0, "tomorrow": 1, "yesterday": -1, "day after tomorrow": 2, "day before yesterday": -2},
+		weekdays: [7][]string{
+			{"sunday"}, {"monday"}, {"tuesday"}, {"wednesday"}, {"thursday"}, {"friday"}, {"saturday"},
+		},
+		months: [12][]string{
+			{"january", "jan"}, {"february", "feb"}, {"march", "mar"}, {"april", "apr"}, {"may"}, {"june", "jun"},
+			{"july", "jul"}, {"august", "aug"}, {"september", "sept", "sep"}, {"october", "oct"}, {"november", "nov"},
+			{"december", "dec"},
+		},
+		next:     []string{"next", "coming"},
+		last:     []string{"last", "past"},
+		in:       []string{"in"},
+		agoAfter: []string{"ago"},
+		dayUnits: []string{"day", "days"},
+		weekUnit: []string{"week", "weeks"},
+	},
+	"fra": {
+		days: map[string]int{
+			"aujourd'hui": 0, "aujourdhui": 0, "demain": 1, "hier": -1, "apres-demain": 2, "apres demain": 2,
+			"avant-hier": -2, "avant hier": -2,
+		},
+		weekdays: [7][]string{
+			{"dimanche"}, {"lundi"}, {"mardi"}, {"mercredi"}, {"jeudi"}, {"vendredi"}, {"samedi"},
+		},
+		months: [12][]string{
+			{"janvier", "janv"}, {"fevrier", "fevr", "fev"}, {"mars"}, {"avril", "avr"}, {"mai"}, {"juin"},
+			{"juillet", "juil"}, {"aout"}, {"septembre", "sept"}, {"octobre", "oct"}, {"novembre", "nov"},
+			{"decembre", "dec"},
+		},
+		next:     []string{"prochain"},
+		last:     []string{"dernier"},
+		in:       []string{"dans"},
+		ago:      []string{"il y a"},
+		dayUnits: []string{"jour", "jours"},
+		weekUnit: []string{"semaine", "semaines"},
+	},
+	"spa": {
+		days: map[string]int{
+			"hoy": 0, "manana": 1, "ayer": -1, "pasado manana": 2, "anteayer": -2, "antier": -2, "antes de ayer": -2,
+		},
+		weekdays: [7][]string{
+			{"domingo"}, {"lunes"}, {"martes"}, {"miercoles"}, {"jueves"}, {"viernes"}, {"sabado"},
+		},
+		months: [12][]string{
+			{"enero", "ene"}, {"febrero", "feb"}, {"marzo", "mar"}, {"abril", "abr"}, {"mayo", "may"}, {"junio", "jun"},
+			{"julio", "jul"}, {"agosto", "ago"}, {"septiembre", "setiembre", "sept", "sep"}, {"octubre", "oct"},
+			{"noviembre", "nov"}, {"diciembre", "dic"},
+		},
+		next:     []string{"proximo", "que viene"},
+		last:     []string{"pasado"},
+		in:       []string{"en", "dentro de"},
+		ago:      []string{"hace"},
+		dayUnits: []string{"dia", "dias"},
+		weekUnit: []string{"semana", "semanas"},
+	},
+	"por": {
+		days: map[string]int{
+			"hoje": 0, "amanha": 1, "ontem": -1, "depois de amanha": 2, "anteontem": -2, "antes de ontem": -2,
+		},
+		weekdays: [7][]string{
+			{"domingo"}, {"segunda-feira", "segunda feira", "segunda"}, {"terca-feira", "terca feira", "terca"},
+			{"quarta-feira", "quarta feira", "quarta"}, {"quinta-feira", "quinta feira", "quinta"},
+			{"sexta-feira", "sexta feira", "sexta"}, {"sabado"},
+		},
+		bare: []string{"segunda", "terca", "quarta", "quinta", "sexta"},
+		months: [12][]string{
+			{"janeiro", "jan"}, {"fevereiro", "fev"}, {"marco", "mar"}, {"abril", "abr"}, {"maio", "mai"}, {"junho", "jun"},
+			{"julho", "jul"}, {"agosto", "ago"}, {"setembro", "set"}, {"outubro", "out"}, {"novembro", "nov"},
+			{"dezembro", "dez"},
+		},
+		next:     []string{"proxima", "proximo", "que vem"},
+		last:     []string{"ultima", "ultimo", "passada", "passado"},
+		in:       []string{"em", "daqui a", "dentro de"},
+		ago:      []string{"ha", "faz"},
+		agoAfter: []string{"atras"},
+		dayUnits: []string{"dia", "dias"},
+		weekUnit: []string{"semana", "semanas"},
+	},
+}
+
+// a natural date parser for a single language
+type naturalDateParser struct {
+	words *dateWords
+
+	relative *regexp.Regexp
+	weekday  *regexp.Regexp
+	dayMonth *regexp.Regexp
+	monthDay *regexp.Regexp
+	inUnits  *regexp.Regexp
+	agoUnits *regexp.Regexp
+
+	weekdays map[string]time.Weekday
+	bare     map[string]bool
+	months   map[string]time.Month
+	units    map[string]int
+	nexts    map[string]bool
+}
+
+var naturalDateParsers = make(map[Language]*naturalDateParser, len(naturalDateWords))
+
+func init() {
+	for lang, words := range naturalDateWords {
+		naturalDateParsers[lang] = newNaturalDateParser(words)
+	}
+}
+
+const ordinalSuffix = `(?:st|nd|rd|th|er|e|º|°)?`
+
+func newNaturalDateParser(w *dateWords) *naturalDateParser {
+	p := &naturalDateParser{
+		words:    w,
+		weekdays: make(map[string]time.Weekday),
+		bare:     make(map[string]bool),
+		months:   make(map[string]time.Month),
+		units:    make(map[string]int),
+		nexts:    make(map[string]bool),
+	}
+
+	days := make([]string, 0, len(w.days))
+	for phrase := range w.days {
+		days = append(days, phrase)
+	}
+	var weekdays, months []string
+	for d, names := range w.weekdays {
+		for _, name := range names {
+			p.weekdays[name] = time.Weekday(d)
+			weekdays = append(weekdays, name)
+		}
+	}
+	for _, name := range w.bare {
+		p.bare[name] = true
+	}
+	for m, names := range w.months {
+		for _, name := range names {
+			p.months[name] = time.Month(m + 1)
+			months = append(months, name)
+		}
+	}
+	for _, unit := range w.dayUnits {
+		p.units[unit] = 1
+	}
+	for _, unit := range w.weekUnit {
+		p.units[unit] = 7
+	}
+	for _, modifier := range w.next {
+		p.nexts[modifier] = true
+	}
+	units := append(append([]string(nil), w.dayUnits...), w.weekUnit...)
+	modifiers := alternation(append(append([]string(nil), w.next...), w.last...))
+	month := alternation(months)
+
+	p.relative = regexp.MustCompile(`\b(` + alternation(days) + `)\b`)
+	p.weekday = regexp.MustCompile(`\b(?:(` + modifiers + `)\s+)?(` + alternation(weekdays) + `)\b(?:\s+(` + modifiers + `)\b)?`)
+	p.dayMonth = regexp.MustCompile(`\b(\d{1,2})` + ordinalSuffix + `\s*(?:(?:of|de)\s+)?(` + month + `)\b\.?(?:,?\s*(?:(?:of|de|del)\s+)?(\d{4})\b)?`)
+	p.monthDay = regexp.MustCompile(`\b(` + month + `)\.?\s+(\d{1,2})` + ordinalSuffix + `\b(?:,?\s*(\d{4})\b)?`)
+	p.inUnits = regexp.MustCompile(`\b(?:` + alternation(w.in) + `)\s+(\d{1,3})\s+(` + alternation(units) + `)\b`)
+
+	var ago []string
+	if len(w.ago) > 0 {
+		ago = append(ago, `\b(?:`+alternation(w.ago)+`)\s+(\d{1,3})\s+(`+alternation(units)+`)\b`)
+	}
+	if len(w.agoAfter) > 0 {
+		ago = append(ago, `\b(\d{1,3})\s+(`+alternation(units)+`)\s+(?:`+alternation(w.agoAfter)+`)\b`)
+	}
+	p.agoUnits = regexp.MustCompile(strings.Join(ago, "|"))
+
+	return p
+}
+
+// builds a regex alternation of the given words with longer words first so that they take precedence
+func alternation(words []string) string {
+	sorted := make([]string, len(words))
+	for i, word := range words {
+		sorted[i] = regexp.QuoteMeta(word)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	return strings.Join(sorted, "|")
+}
+
+// a date found in some text, with the span of normalized text it was written in, and how to resolve it
+// against the current date
+type naturalDateMatch struct {
+	start, end int
+	resolve    func(today dates.Date) dates.Date
+}
+
+// finds the first date in the given normalized text
+func (p *naturalDateParser) find(text string) *naturalDateMatch {
+	var best *naturalDateMatch
+	consider := func(start, end int, resolve func(dates.Date) dates.Date) {
+		if best == nil || start < best.start || (start == best.start && end > best.end) {
+			best = &naturalDateMatch{start: start, end: end, resolve: resolve}
+		}
+	}
+	inDays := func(days int) func(dates.Date) dates.Date {
+		return func(today dates.Date) dates.Date { return addDays(today, days) }
+	}
+
+	if m := p.relative.FindStringSubmatchIndex(text); m != nil {
+		consider(m[0], m[1], inDays(p.words.days[text[m[2]:m[3]]]))
+	}
+
+	for _, m := range p.weekday.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[4]:m[5]]
+		weekday := p.weekdays[name]
+
+		modifier := ""
+		if m[2] >= 0 {
+			modifier = text[m[2]:m[3]]
+		} else if m[6] >= 0 {
+			modifier = text[m[6]:m[7]]
+		}
+
+		// weekdays which are also ordinary words are only weekdays with a modifier
+		if modifier == "" && p.bare[name] {
+			continue
+		}
+
+		consider(m[0], m[1], func(today dates.Date) dates.Date {
+			ahead := (int(weekday) - int(today.Weekday()) + 7) % 7
+
+			// a weekday on its own is the next one including today, but next and last never mean today
+			switch {
+			case modifier == "":
+				return addDays(today, ahead)
+			case p.nexts[modifier]:
+				if ahead == 0 {
+					ahead = 7
+				}
+				return addDays(today, ahead)
+			default:
+				return addDays(today, ahead-7)
+			}
+		})
+		break
+	}
+
+	for _, m := range p.dayMonth.FindAllStringSubmatchIndex(text, -1) {
+		if resolve := p.dateFromParts(text, m, 2, 4, 6); resolve != nil {
+			consider(m[0], m[1], resolve)
+			break
+		}
+	}
+	for _, m := range p.monthDay.FindAllStringSubmatchIndex(text, -1) {
+		if resolve := p.dateFromParts(text, m, 4, 2, 6); resolve != nil {
+			consider(m[0], m[1], resolve)
+			break
+		}
+	}
+
+	if m := p.inUnits.FindStringSubmatchIndex(text); m != nil {
+		amount, _ := strconv.Atoi(text[m[2]:m[3]])
+		consider(m[0], m[1], inDays(amount*p.units[text[m[4]:m[5]]]))
+	}
+
+	if m := p.agoUnits.FindStringSubmatchIndex(text); m != nil {
+		// whichever of the alternatives matched has its amount and unit in the first non-empty groups
+		var groups []string
+		for g := 2; g < len(m); g += 2 {
+			if m[g] >= 0 {
+				groups = append(groups, text[m[g]:m[g+1]])
+			}
+		}
+		amount, _ := strconv.Atoi(groups[0])
+		consider(m[0], m[1], inDays(-amount*p.units[groups[1]]))
+	}
+
+	return best
+}
+
+// reads the day, month and optional year groups of a match, returning nil if they can't make a valid date.
+// Dates without a year are in the current year.
+func (p *naturalDateParser) dateFromParts(text string, m []int, dayGroup, monthGroup, yearGroup int) func(dates.Date) dates.Date {
+	day, _ := strconv.Atoi(text[m[dayGroup]:m[dayGroup+1]])
+	month := p.months[text[m[monthGroup]:m[monthGroup+1]]]
+	year := 0
+	if m[yearGroup] >= 0 {
+		year, _ = strconv.Atoi(text[m[yearGroup]:m[yearGroup+1]])
+	}
+
+	if day < 1 || day > daysInMonth(year, month) {
+		return nil
+	}
+
+	return func(today dates.Date) dates.Date {
+		if year == 0 {
+			// 29 feb outside of a leap year becomes 1 mar
+			if day > daysInMonth(today.Year, month) {
+				return addDays(dates.NewDate(today.Year, int(month), day-1), 1)
+			}
+			return dates.NewDate(today.Year, int(month), day)
+		}
+		return dates.NewDate(year, int(month), day)
+	}
+}
+
+// the number of days in the given month, where year zero is treated as a leap year
+func daysInMonth(year int, month time.Month) int {
+	if year == 0 {
+		year = 2000
+	}
+	// the day zero of the next month is the last day of this month
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func addDays(d dates.Date, days int) dates.Date {
+	return dates.ExtractDate(time.Date(d.Year, time.Month(d.Month), d.Day+days, 0, 0, 0, 0, time.UTC))
+}
+
+// the languages we try to read dates in, which are those of the environment, e.g. the languages of a run, that we
+// have words for
+func naturalDateLanguages(env Environment) []Language {
+	languages := make([]Language, 0, 1)
+	seen := make(map[Language]bool)
+	for _, lang := range LanguagesOf(env) {
+		if naturalDateParsers[lang] != nil && !seen[lang] {
+			languages = append(languages, lang)
+			seen[lang] = true
+		}
+	}
+	if len(languages) == 0 {
+		languages = append(languages, "eng")
+	}
+	return languages
+}
+
+// unaccented equivalents of the accented letters used in our supported languages
+var unaccented = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e', 'í': 'i', 'ì': 'i',
+	'î': 'i', 'ï': 'i', 'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n', '’': '\'',
+}
+
+// lowercases and removes accents from the given text, rune for rune so that positions can be mapped back
+func normalizeWords(s string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if replacement, found := unaccented[r]; found {
+			return replacement
+		}
+		return r
+	}, s)
+}
+
+// parses a date written in words, e.g. "tomorrow", "next friday" or "15 de marzo", in the languages of the
+// given environment. Relative dates are resolved against the current date in the environment's timezone.
+func parseNaturalDate(env Environment, str string) (dates.Date, string, bool) {
+	text := normalizeWords(str)
+
+	var best *naturalDateMatch
+	for _, lang := range naturalDateLanguages(env) {
+		match := naturalDateParsers[lang].find(text)
+		if match != nil && (best == nil || match.start < best.start || (match.start == best.start && match.end > best.end)) {
+			best = match
+		}
+	}
+	if best == nil {
+		return dates.ZeroDate, str, false
+	}
+
+	// only now do we need the current date
+	date := best.resolve(dates.ExtractDate(env.Now()))
+
+	// normalizing doesn't change the number of runes so we can find where the match ends in the original
+	end := utf8.RuneCountInString(text[:best.end])
+	return date, string([]rune(str)[end:]), true
+}
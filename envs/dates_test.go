@@ -152,6 +152,102 @@ func TestDateFromString(t *testing.T) {
 	}
 }
 
+func TestNaturalDateFromString(t *testing.T) {
+	// a Thursday
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 9, 13, 13, 36, 30, 123456789, time.UTC)))
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	testCases := []struct {
+		languages []envs.Language
+		value     string
+		expected  dates.Date
+		hasError  bool
+	}{
+		{nil, "today", dates.NewDate(2018, 9, 13), false},
+		{nil, "I'll come Tomorrow", dates.NewDate(2018, 9, 14), false},
+		{nil, "yesterday", dates.NewDate(2018, 9, 12), false},
+		{nil, "the day after tomorrow", dates.NewDate(2018, 9, 15), false},
+		{nil, "day before yesterday", dates.NewDate(2018, 9, 11), false},
+		{nil, "thursday", dates.NewDate(2018, 9, 13), false},
+		{nil, "friday", dates.NewDate(2018, 9, 14), false},
+		{nil, "next thursday", dates.NewDate(2018, 9, 20), false},
+		{nil, "next friday", dates.NewDate(2018, 9, 14), false},
+		{nil, "last friday", dates.NewDate(2018, 9, 7), false},
+		{nil, "last thursday", dates.NewDate(2018, 9, 6), false},
+		{nil, "15 march", dates.NewDate(2018, 3, 15), false},
+		{nil, "on the 1st of Jan 2020", dates.NewDate(2020, 1, 1), false},
+		{nil, "March 15th, 2017", dates.NewDate(2017, 3, 15), false},
+		{nil, "sept 3", dates.NewDate(2018, 9, 3), false},
+		{nil, "29 feb 2016", dates.NewDate(2016, 2, 29), false},
+		{nil, "29 feb", dates.NewDate(2018, 3, 1), false},
+		{nil, "in 3 days", dates.NewDate(2018, 9, 16), false},
+		{nil, "in 2 weeks", dates.NewDate(2018, 9, 27), false},
+		{nil, "10 days ago", dates.NewDate(2018, 9, 3), false},
+		{nil, "tomorrow or 15 march", dates.NewDate(2018, 9, 14), false},
+
+		// languages other than English must be enabled in the environment
+		{nil, "demain", dates.ZeroDate, true},
+		{[]envs.Language{"fra"}, "demain", dates.NewDate(2018, 9, 14), false},
+		{[]envs.Language{"fra"}, "hier", dates.NewDate(2018, 9, 12), false},
+		{[]envs.Language{"fra"}, "après-demain", dates.NewDate(2018, 9, 15), false},
+		{[]envs.Language{"fra"}, "Aujourd’hui", dates.NewDate(2018, 9, 13), false},
+		{[]envs.Language{"fra"}, "vendredi prochain", dates.NewDate(2018, 9, 14), false},
+		{[]envs.Language{"fra"}, "le 1er février 2019", dates.NewDate(2019, 2, 1), false},
+		{[]envs.Language{"fra"}, "il y a 2 jours", dates.NewDate(2018, 9, 11), false},
+		{[]envs.Language{"fra", "spa"}, "pasado mañana", dates.NewDate(2018, 9, 15), false},
+		{[]envs.Language{"spa"}, "el viernes pasado", dates.NewDate(2018, 9, 7), false},
+		{[]envs.Language{"spa"}, "15 de marzo de 2019", dates.NewDate(2019, 3, 15), false},
+		{[]envs.Language{"spa"}, "dentro de 1 semana", dates.NewDate(2018, 9, 20), false},
+		{[]envs.Language{"spa"}, "hace 3 días", dates.NewDate(2018, 9, 10), false},
+		{[]envs.Language{"por"}, "amanhã", dates.NewDate(2018, 9, 14), false},
+		{[]envs.Language{"por"}, "próxima segunda-feira", dates.NewDate(2018, 9, 17), false},
+		{[]envs.Language{"por"}, "7 de setembro", dates.NewDate(2018, 9, 7), false},
+		{[]envs.Language{"por"}, "5 dias atrás", dates.NewDate(2018, 9, 8), false},
+		{[]envs.Language{"por"}, "sexta feira", dates.NewDate(2018, 9, 14), false},
+		{[]envs.Language{"por"}, "terça que vem", dates.NewDate(2018, 9, 18), false},
+		{[]envs.Language{"por"}, "a segunda opção, quinta-feira", dates.NewDate(2018, 9, 13), false},
+
+		// weekdays which are also ordinals in Portuguese need a modifier or -feira
+		{[]envs.Language{"por"}, "a segunda opção", dates.ZeroDate, true},
+		{[]envs.Language{"por"}, "terça", dates.ZeroDate, true},
+
+		// numeric dates take precedence
+		{nil, "tomorrow not 01-02-2001", dates.NewDate(2001, 2, 1), false},
+
+		{nil, "no date here", dates.ZeroDate, true},
+		{nil, "31 february", dates.ZeroDate, true},
+		{nil, "mayday", dates.ZeroDate, true},
+		{nil, "in a few days", dates.ZeroDate, true},
+	}
+
+	for _, tc := range testCases {
+		env := envs.NewBuilder().WithDateFormat(envs.DateFormatDayMonthYear).WithAllowedLanguages(tc.languages).Build()
+		parsed, err := envs.DateFromNaturalString(env, tc.value)
+
+		if tc.hasError {
+			assert.Error(t, err, "expected error parsing %s", tc.value)
+		} else {
+			require.NoError(t, err, "error parsing date %s", tc.value)
+			assert.Equal(t, tc.expected, parsed, "mismatch for date input %s", tc.value)
+		}
+	}
+
+	// relative dates are resolved in the environment's timezone and times can follow
+	auckland, _ := time.LoadLocation("Pacific/Auckland")
+	env := envs.NewBuilder().WithTimezone(auckland).Build()
+
+	value, err := envs.DateTimeFromNaturalString(env, "tomorrow at 10:30pm", false)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 9, 15, 22, 30, 0, 0, auckland), value)
+
+	// dates written in words are only parsed when asked for
+	_, err = envs.DateFromString(env, "tomorrow")
+	assert.EqualError(t, err, "string 'tomorrow' couldn't be parsed as a date")
+
+	_, err = envs.DateTimeFromString(env, "15 march at 10:30pm", false)
+	assert.EqualError(t, err, "string '15 march at 10:30pm' couldn't be parsed as a date")
+}
+
 func TestTimeFromString(t *testing.T) {
 	testCases := []struct {
 		value    string
@@ -6,6 +6,8 @@ import (
 
 	"github.com/nyaruka/goflow/utils"
 	"github.com/nyaruka/goflow/utils/dates"
+
+	"github.com/pkg/errors"
 )
 
 type RedactionPolicy string
@@ -24,6 +26,10 @@ type NumberFormat struct {
 // DefaultNumberFormat is the default number formatting, e.g. 1,234.567
 var DefaultNumberFormat = &NumberFormat{DecimalSymbol: `.`, DigitGroupingSymbol: `,`}
 
+// NumberFormatAuto is the value of number_format in an environment's JSON which means that the number format is
+// inferred from the default language and country
+const NumberFormatAuto = "auto"
+
 // Environment defines the environment that the Excellent function is running in, this includes
 // the timezone the user is in as well as the preferred date and time formats.
 type Environment interface {
@@ -51,6 +57,7 @@ type environment struct {
 	allowedLanguages []Language
 	defaultCountry   Country
 	numberFormat     *NumberFormat
+	autoNumberFormat bool
 	redactionPolicy  RedactionPolicy
 	maxValueLength   int
 }
@@ -65,6 +72,13 @@ func (e *environment) NumberFormat() *NumberFormat      { return e.numberFormat
 func (e *environment) RedactionPolicy() RedactionPolicy { return e.redactionPolicy }
 func (e *environment) MaxValueLength() int              { return e.maxValueLength }
 
+// if the number format is to be inferred, infers it from the default language and country
+func (e *environment) resolveNumberFormat() {
+	if e.autoNumberFormat {
+		e.numberFormat = NewLocale(e.defaultLanguage, e.defaultCountry).NumberFormat()
+	}
+}
+
 func (e *environment) Now() time.Time { return dates.Now().In(e.Timezone()) }
 
 // Equal returns true if this instance is equal to the given instance
@@ -84,7 +98,7 @@ type envEnvelope struct {
 	Timezone         string          `json:"timezone"`
 	DefaultLanguage  Language        `json:"default_language,omitempty" validate:"omitempty,language"`
 	AllowedLanguages []Language      `json:"allowed_languages,omitempty" validate:"omitempty,dive,language"`
-	NumberFormat     *NumberFormat   `json:"number_format,omitempty"`
+	NumberFormat     json.RawMessage `json:"number_format,omitempty"`
 	DefaultCountry   Country         `json:"default_country,omitempty" validate:"omitempty,country"`
 	RedactionPolicy  RedactionPolicy `json:"redaction_policy" validate:"omitempty,eq=none|eq=urns"`
 	MaxValuelength   int             `json:"max_value_length"`
@@ -100,14 +114,33 @@ func ReadEnvironment(data json.RawMessage) (Environment, error) {
 		return nil, err
 	}
 
+	// number format is either an explicit format or auto, and if missing or null is the default format
+	numberFormat := DefaultNumberFormat
+	autoNumberFormat := false
+
+	var numberFormatName string
+	if json.Unmarshal(envelope.NumberFormat, &numberFormatName) == nil && numberFormatName != "" {
+		if numberFormatName != NumberFormatAuto {
+			return nil, errors.Errorf("unable to read number format: '%s' isn't a valid number format", numberFormatName)
+		}
+		autoNumberFormat = true
+	} else if string(envelope.NumberFormat) != "null" {
+		numberFormat = &NumberFormat{}
+		if err := utils.UnmarshalAndValidate(envelope.NumberFormat, numberFormat); err != nil {
+			return nil, errors.Wrap(err, "unable to read number format")
+		}
+	}
+
 	env.dateFormat = envelope.DateFormat
 	env.timeFormat = envelope.TimeFormat
 	env.defaultLanguage = envelope.DefaultLanguage
 	env.allowedLanguages = envelope.AllowedLanguages
 	env.defaultCountry = envelope.DefaultCountry
-	env.numberFormat = envelope.NumberFormat
+	env.numberFormat = numberFormat
+	env.autoNumberFormat = autoNumberFormat
 	env.redactionPolicy = envelope.RedactionPolicy
 	env.maxValueLength = envelope.MaxValuelength
+	env.resolveNumberFormat()
 
 	tz, err := time.LoadLocation(envelope.Timezone)
 	if err != nil {
@@ -119,6 +152,14 @@ func ReadEnvironment(data json.RawMessage) (Environment, error) {
 }
 
 func (e *environment) toEnvelope() *envEnvelope {
+	// a number format which is inferred is written as auto so that it continues to be inferred
+	var numberFormat json.RawMessage
+	if e.autoNumberFormat {
+		numberFormat, _ = json.Marshal(NumberFormatAuto)
+	} else {
+		numberFormat, _ = json.Marshal(e.numberFormat)
+	}
+
 	return &envEnvelope{
 		DateFormat:       e.dateFormat,
 		TimeFormat:       e.timeFormat,
@@ -126,7 +167,7 @@ func (e *environment) toEnvelope() *envEnvelope {
 		DefaultLanguage:  e.defaultLanguage,
 		AllowedLanguages: e.allowedLanguages,
 		DefaultCountry:   e.defaultCountry,
-		NumberFormat:     e.numberFormat,
+		NumberFormat:     numberFormat,
 		RedactionPolicy:  e.redactionPolicy,
 		MaxValuelength:   e.maxValueLength,
 	}
@@ -137,6 +178,28 @@ func (e *environment) MarshalJSON() ([]byte, error) {
 	return json.Marshal(e.toEnvelope())
 }
 
+// an environment which gets the current time from a source other than the global one
+type clockedEnvironment struct {
+	Environment
+
+	nowSource dates.NowSource
+}
+
+// NewClockedEnvironment wraps the given environment so that Now() gets the current time from the given source
+func NewClockedEnvironment(env Environment, nowSource dates.NowSource) Environment {
+	if clocked, isClocked := env.(*clockedEnvironment); isClocked {
+		env = clocked.Environment
+	}
+	return &clockedEnvironment{Environment: env, nowSource: nowSource}
+}
+
+func (e *clockedEnvironment) Now() time.Time { return e.nowSource.Now().In(e.Timezone()) }
+
+// MarshalJSON marshals this environment into JSON
+func (e *clockedEnvironment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Environment)
+}
+
 //------------------------------------------------------------------------------------------
 // Builder
 //------------------------------------------------------------------------------------------
@@ -195,8 +258,16 @@ func (b *EnvironmentBuilder) WithDefaultCountry(defaultCountry Country) *Environ
 	return b
 }
 
+// WithNumberFormat sets the number format
 func (b *EnvironmentBuilder) WithNumberFormat(numberFormat *NumberFormat) *EnvironmentBuilder {
 	b.env.numberFormat = numberFormat
+	b.env.autoNumberFormat = false
+	return b
+}
+
+// WithAutoNumberFormat sets the number format to be inferred from the default language and country
+func (b *EnvironmentBuilder) WithAutoNumberFormat() *EnvironmentBuilder {
+	b.env.autoNumberFormat = true
 	return b
 }
 
@@ -211,4 +282,7 @@ func (b *EnvironmentBuilder) WithMaxValueLength(maxValueLength int) *Environment
 }
 
 // Build returns the final environment
-func (b *EnvironmentBuilder) Build() Environment { return b.env }
+func (b *EnvironmentBuilder) Build() Environment {
+	b.env.resolveNumberFormat()
+	return b.env
+}
@@ -58,6 +58,55 @@ func TestEnvironmentMarshaling(t *testing.T) {
 	assert.Equal(t, string(data), `{"date_format":"DD-MM-YYYY","time_format":"tt:mm:ss","timezone":"Africa/Kigali","default_language":"eng","allowed_languages":["eng","fra"],"number_format":{"decimal_symbol":".","digit_grouping_symbol":","},"default_country":"RW","redaction_policy":"none","max_value_length":640}`)
 }
 
+func TestNumberFormatInference(t *testing.T) {
+	// a missing or null number format means the default
+	env, err := envs.ReadEnvironment(json.RawMessage(`{"default_language": "spa", "default_country": "EC"}`))
+	require.NoError(t, err)
+	assert.Equal(t, envs.DefaultNumberFormat, env.NumberFormat())
+
+	env, err = envs.ReadEnvironment(json.RawMessage(`{"default_language": "spa", "default_country": "EC", "number_format": null}`))
+	require.NoError(t, err)
+	assert.Equal(t, envs.DefaultNumberFormat, env.NumberFormat())
+
+	// but auto means it's inferred from the language and country
+	env, err = envs.ReadEnvironment(json.RawMessage(`{"default_language": "spa", "default_country": "EC", "number_format": "auto"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: "."}, env.NumberFormat())
+
+	// and is serialized as auto so that it continues to be inferred
+	data, err := json.Marshal(env)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"number_format":"auto"`)
+
+	env, err = envs.ReadEnvironment(data)
+	require.NoError(t, err)
+	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: "."}, env.NumberFormat())
+
+	// an explicit number format is always used
+	env, err = envs.ReadEnvironment(json.RawMessage(`{"default_language": "spa", "number_format": {"decimal_symbol": ".", "digit_grouping_symbol": " "}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: " "}, env.NumberFormat())
+	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: ","}, envs.DefaultNumberFormat)
+
+	_, err = envs.ReadEnvironment(json.RawMessage(`{"number_format": "x"}`))
+	assert.EqualError(t, err, "unable to read number format: 'x' isn't a valid number format")
+
+	_, err = envs.ReadEnvironment(json.RawMessage(`{"number_format": 123}`))
+	assert.EqualError(t, err, "unable to read number format: json: cannot unmarshal number into Go value of type envs.NumberFormat")
+
+	// environments can also be built with an inferred number format, which is inferred after the language is set
+	env = envs.NewBuilder().WithAutoNumberFormat().WithDefaultLanguage("fra").Build()
+	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: " "}, env.NumberFormat())
+
+	data, err = json.Marshal(env)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"number_format":"auto"`)
+
+	// setting an explicit number format replaces an inferred one
+	env = envs.NewBuilder().WithAutoNumberFormat().WithDefaultLanguage("fra").WithNumberFormat(envs.DefaultNumberFormat).Build()
+	assert.Equal(t, envs.DefaultNumberFormat, env.NumberFormat())
+}
+
 func TestEnvironmentEqual(t *testing.T) {
 	env1, err := envs.ReadEnvironment(json.RawMessage(`{"date_format": "DD-MM-YYYY", "time_format": "tt:mm:ss", "timezone": "Africa/Kigali"}`))
 	require.NoError(t, err)
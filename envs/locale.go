@@ -0,0 +1,204 @@
+package envs
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Locale is the combination of a language and country which decides how numbers and currency amounts are
+// formatted, using the CLDR data provided by golang.org/x/text. Either can be empty.
+type Locale struct {
+	Language Language
+	Country  Country
+}
+
+// NewLocale creates a new locale
+func NewLocale(language Language, country Country) Locale {
+	return Locale{Language: language, Country: country}
+}
+
+// the BCP 47 tag for this locale, where a missing language is guessed from the country if possible
+func (l Locale) tag() language.Tag {
+	var parts []interface{}
+
+	if base, err := language.ParseBase(string(l.Language)); l.Language != NilLanguage && err == nil {
+		parts = append(parts, base)
+	}
+	if region, err := language.ParseRegion(string(l.Country)); l.Country != NilCountry && err == nil {
+		parts = append(parts, region)
+	}
+
+	tag, _ := language.Compose(parts...)
+
+	if l.Language == NilLanguage && l.Country != NilCountry {
+		if base, confidence := tag.Base(); confidence != language.No {
+			tag, _ = language.Compose(tag, base)
+		}
+	}
+	return tag
+}
+
+// LocalizedEnvironment is implemented by environments which are localized for someone, e.g. the contact of a run,
+// whose languages and locale can differ from the default language and country
+type LocalizedEnvironment interface {
+	Environment
+
+	Languages() []Language
+	Locale() Locale
+}
+
+// LanguagesOf returns the languages of the given environment in order of preference, which unless it's localized
+// for someone are its default language followed by its allowed languages
+func LanguagesOf(env Environment) []Language {
+	if localized, isLocalized := env.(LocalizedEnvironment); isLocalized {
+		return localized.Languages()
+	}
+	return append([]Language{env.DefaultLanguage()}, env.AllowedLanguages()...)
+}
+
+// LocaleOf returns the locale of the given environment, which unless it's localized for someone is made up of its
+// default language and country
+func LocaleOf(env Environment) Locale {
+	if localized, isLocalized := env.(LocalizedEnvironment); isLocalized {
+		return localized.Locale()
+	}
+	return NewLocale(env.DefaultLanguage(), env.DefaultCountry())
+}
+
+var numberFormatCache sync.Map
+
+// NumberFormat returns the number format used in this locale, or the default number format if nothing
+// is known about the locale
+func (l Locale) NumberFormat() *NumberFormat {
+	if l.Language == NilLanguage && l.Country == NilCountry {
+		return DefaultNumberFormat
+	}
+	if cached, found := numberFormatCache.Load(l); found {
+		return cached.(*NumberFormat)
+	}
+
+	format := DefaultNumberFormat
+
+	// format a number with grouped digits and a fraction, and read back the symbols used
+	formatted := []rune(message.NewPrinter(l.tag()).Sprint(number.Decimal(1234567.5)))
+	var symbols []string
+	for i := 0; i < len(formatted); {
+		if unicode.IsDigit(formatted[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(formatted) && !unicode.IsDigit(formatted[j]) {
+			j++
+		}
+		symbols = append(symbols, normalizeSymbol(string(formatted[i:j])))
+		i = j
+	}
+	if len(symbols) == 3 && symbols[0] == symbols[1] && symbols[1] != symbols[2] {
+		format = &NumberFormat{DecimalSymbol: symbols[2], DigitGroupingSymbol: symbols[0]}
+	}
+
+	numberFormatCache.Store(l, format)
+	return format
+}
+
+// CLDR uses non-breaking spaces which are best kept as regular spaces in messages
+func normalizeSymbol(s string) string {
+	if strings.TrimFunc(s, unicode.IsSpace) == "" {
+		return " "
+	}
+	return s
+}
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code   string
+	Digits int
+
+	unit currency.Unit
+}
+
+// ParseCurrency parses the given ISO 4217 currency code
+func ParseCurrency(code string) (*Currency, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil || unit == currency.XXX {
+		return nil, errors.Errorf("%s is not a valid currency code", code)
+	}
+
+	digits, _ := currency.Standard.Rounding(unit)
+	return &Currency{Code: unit.String(), Digits: digits, unit: unit}, nil
+}
+
+// languages whose CLDR currency pattern puts the symbol after the amount, with the countries where those
+// languages do the opposite
+var currencyAfterAmount = map[Language]map[Country]bool{
+	"fra": {},
+	"deu": {"CH": false, "LI": false, "AT": false},
+	"ita": {"CH": false},
+	"spa": {},
+	"por": {"BR": false, NilCountry: false},
+	"rus": {},
+	"ukr": {},
+	"pol": {},
+	"ces": {},
+	"slk": {},
+	"swe": {},
+	"nob": {},
+	"dan": {},
+	"fin": {},
+	"ron": {},
+	"hun": {},
+	"vie": {},
+}
+
+// countries where Spanish follows the Latin American pattern which puts the symbol first
+var latinAmericanCountries = map[Country]bool{
+	"AR": true, "BO": true, "CL": true, "CO": true, "CR": true, "CU": true, "DO": true, "EC": true, "GT": true,
+	"HN": true, "MX": true, "NI": true, "PA": true, "PE": true, "PR": true, "PY": true, "SV": true, "US": true,
+	"UY": true, "VE": true, "PH": true,
+}
+
+// FormatCurrency combines the given formatted amount with the symbol of the given currency as is customary
+// in this locale, e.g. $1,234.50 or 1 234,50 €
+func (l Locale) FormatCurrency(amount string, negative bool, c *Currency) string {
+	symbol := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, message.NewPrinter(l.tag()).Sprint(currency.Symbol(c.unit)))
+
+	after, known := currencyAfterAmount[l.Language]
+	symbolAfter := known
+	if exception, isException := after[l.Country]; isException {
+		symbolAfter = exception
+	}
+	if l.Language == "spa" && latinAmericanCountries[l.Country] {
+		symbolAfter = false
+	}
+
+	var formatted string
+	if symbolAfter {
+		formatted = amount + " " + symbol
+	} else {
+		// symbols which are letters, e.g. RWF, are kept apart from the amount
+		last := []rune(symbol)[len([]rune(symbol))-1]
+		if unicode.IsLetter(last) {
+			formatted = symbol + " " + amount
+		} else {
+			formatted = symbol + amount
+		}
+	}
+
+	if negative {
+		return "-" + formatted
+	}
+	return formatted
+}
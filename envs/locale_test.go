@@ -0,0 +1,86 @@
+package envs_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleOf(t *testing.T) {
+	assert.Equal(t, envs.NewLocale(envs.NilLanguage, envs.NilCountry), envs.LocaleOf(envs.NewBuilder().Build()))
+
+	env := envs.NewBuilder().WithDefaultLanguage("fra").WithDefaultCountry("CA").Build()
+	assert.Equal(t, envs.NewLocale("fra", "CA"), envs.LocaleOf(env))
+}
+
+func TestLocaleNumberFormat(t *testing.T) {
+	tests := []struct {
+		language envs.Language
+		country  envs.Country
+		decimal  string
+		grouping string
+	}{
+		{envs.NilLanguage, envs.NilCountry, ".", ","},
+		{"eng", envs.NilCountry, ".", ","},
+		{"eng", "US", ".", ","},
+		{"fra", "FR", ",", " "},
+		{"spa", "EC", ",", "."},
+		{"por", "BR", ",", "."},
+		{"kin", "RW", ",", "."},
+		{"deu", "CH", ".", "’"},
+		{envs.NilLanguage, "FR", ",", " "},
+		{"xyz", envs.NilCountry, ".", ","},
+	}
+
+	for _, tc := range tests {
+		format := envs.NewLocale(tc.language, tc.country).NumberFormat()
+		assert.Equal(t, tc.decimal, format.DecimalSymbol, "decimal symbol mismatch for %s-%s", tc.language, tc.country)
+		assert.Equal(t, tc.grouping, format.DigitGroupingSymbol, "grouping symbol mismatch for %s-%s", tc.language, tc.country)
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	c, err := envs.ParseCurrency("usd")
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", c.Code)
+	assert.Equal(t, 2, c.Digits)
+
+	c, err = envs.ParseCurrency("RWF")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.Digits)
+
+	_, err = envs.ParseCurrency("XYZ")
+	assert.EqualError(t, err, "XYZ is not a valid currency code")
+}
+
+func TestLocaleFormatCurrency(t *testing.T) {
+	usd, _ := envs.ParseCurrency("USD")
+	eur, _ := envs.ParseCurrency("EUR")
+	rwf, _ := envs.ParseCurrency("RWF")
+
+	tests := []struct {
+		language envs.Language
+		country  envs.Country
+		currency *envs.Currency
+		negative bool
+		expected string
+	}{
+		{envs.NilLanguage, envs.NilCountry, usd, false, "US$1,234.50"},
+		{"eng", "US", usd, true, "-$1,234.50"},
+		{"eng", "US", rwf, false, "RWF 1,234.50"},
+		{"kin", "RW", rwf, false, "RF 1,234.50"},
+		{"fra", "FR", eur, false, "1,234.50 €"},
+		{"fra", "CA", usd, false, "1,234.50 $ US"},
+		{"spa", "ES", eur, false, "1,234.50 €"},
+		{"spa", "EC", usd, false, "$1,234.50"},
+		{"por", "BR", usd, false, "US$1,234.50"},
+		{"por", "PT", eur, false, "1,234.50 €"},
+	}
+
+	for _, tc := range tests {
+		formatted := envs.NewLocale(tc.language, tc.country).FormatCurrency("1,234.50", tc.negative, tc.currency)
+		assert.Equal(t, tc.expected, formatted, "format mismatch for %s-%s", tc.language, tc.country)
+	}
+}
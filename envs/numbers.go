@@ -0,0 +1,334 @@
+package envs
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxSpelledNumber is the largest number which can be spelled out in words
+const MaxSpelledNumber = 999999999999999
+
+// a speller spells out a positive number in a particular language
+type speller struct {
+	minus string
+	spell func(n int64) string
+}
+
+var spellers = map[Language]*speller{
+	"eng": {minus: "minus", spell: spellEnglish},
+	"fra": {minus: "moins", spell: spellFrench},
+	"spa": {minus: "menos", spell: spellSpanish},
+	"por": {minus: "menos", spell: spellPortuguese},
+}
+
+// SpellNumber spells out the given whole number in words in the given language, falling back to English
+// if the language isn't supported, e.g. 123 in English is "one hundred twenty-three"
+func SpellNumber(lang Language, n int64) (string, error) {
+	if n > MaxSpelledNumber || n < -MaxSpelledNumber {
+		return "", errors.Errorf("can't spell out numbers larger than %d", int64(MaxSpelledNumber))
+	}
+
+	s := spellers[lang]
+	if s == nil {
+		s = spellers["eng"]
+	}
+
+	if n < 0 {
+		return s.minus + " " + s.spell(-n), nil
+	}
+	return s.spell(n), nil
+}
+
+// splits a number into groups of three digits, least significant first
+func digitGroups(n int64) []int {
+	groups := []int{int(n % 1000)}
+	for n >= 1000 {
+		n /= 1000
+		groups = append(groups, int(n%1000))
+	}
+	return groups
+}
+
+//------------------------------------------------------------------------------------------
+// English
+//------------------------------------------------------------------------------------------
+
+var englishOnes = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve",
+	"thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+var englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+var englishScales = []string{"", "thousand", "million", "billion", "trillion"}
+
+func spellEnglish(n int64) string {
+	if n == 0 {
+		return englishOnes[0]
+	}
+
+	groups := digitGroups(n)
+	words := make([]string, 0, len(groups)*2)
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		words = append(words, englishUnder1000(groups[i]))
+		if i > 0 {
+			words = append(words, englishScales[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func englishUnder1000(n int) string {
+	words := make([]string, 0, 3)
+	if n >= 100 {
+		words = append(words, englishOnes[n/100], "hundred")
+	}
+	if r := n % 100; r >= 20 && r%10 != 0 {
+		words = append(words, englishTens[r/10]+"-"+englishOnes[r%10])
+	} else if r >= 20 {
+		words = append(words, englishTens[r/10])
+	} else if r > 0 {
+		words = append(words, englishOnes[r])
+	}
+	return strings.Join(words, " ")
+}
+
+//------------------------------------------------------------------------------------------
+// French
+//------------------------------------------------------------------------------------------
+
+var frenchOnes = []string{
+	"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix", "onze", "douze",
+	"treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf",
+}
+var frenchTens = []string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante"}
+var frenchScales = []string{"", "mille", "million", "milliard", "billion"}
+
+func spellFrench(n int64) string {
+	if n == 0 {
+		return frenchOnes[0]
+	}
+
+	groups := digitGroups(n)
+	words := make([]string, 0, len(groups)*2)
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		switch {
+		case g == 0:
+			continue
+		case i == 0:
+			words = append(words, frenchUnder1000(g, true))
+		case i == 1:
+			// mille is invariable and never preceded by un, and quatre-vingts and cents lose their s before it
+			if g > 1 {
+				words = append(words, frenchUnder1000(g, false))
+			}
+			words = append(words, frenchScales[i])
+		default:
+			scale := frenchScales[i]
+			if g > 1 {
+				scale += "s"
+			}
+			words = append(words, frenchUnder1000(g, true), scale)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func frenchUnder1000(n int, final bool) string {
+	words := make([]string, 0, 3)
+	h, r := n/100, n%100
+	if h > 1 {
+		hundreds := frenchOnes[h] + " cent"
+		if r == 0 && final {
+			hundreds += "s"
+		}
+		words = append(words, hundreds)
+	} else if h == 1 {
+		words = append(words, "cent")
+	}
+	if r > 0 {
+		words = append(words, frenchUnder100(r, final))
+	}
+	return strings.Join(words, " ")
+}
+
+func frenchUnder100(n int, final bool) string {
+	switch {
+	case n < 20:
+		return frenchOnes[n]
+	case n < 70:
+		t, u := n/10, n%10
+		if u == 0 {
+			return frenchTens[t]
+		} else if u == 1 {
+			return frenchTens[t] + " et un"
+		}
+		return frenchTens[t] + "-" + frenchOnes[u]
+	case n < 80:
+		if n == 71 {
+			return "soixante et onze"
+		}
+		return "soixante-" + frenchOnes[n-60]
+	case n == 80 && final:
+		return "quatre-vingts"
+	case n == 80:
+		return "quatre-vingt"
+	default:
+		return "quatre-vingt-" + frenchOnes[n-80]
+	}
+}
+
+//------------------------------------------------------------------------------------------
+// Spanish
+//------------------------------------------------------------------------------------------
+
+var spanishUnder30 = []string{
+	"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez", "once", "doce",
+	"trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve", "veinte", "veintiuno",
+	"veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+}
+var spanishTens = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+var spanishHundreds = []string{
+	"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos", "setecientos",
+	"ochocientos", "novecientos",
+}
+
+// Spanish uses the long scale so a millón is followed by a billón at a million millones
+func spellSpanish(n int64) string {
+	if n == 0 {
+		return spanishUnder30[0]
+	}
+
+	billions, millions, rest := n/1000000000000, (n/1000000)%1000000, int(n%1000000)
+
+	words := make([]string, 0, 5)
+	if billions == 1 {
+		words = append(words, "un billón")
+	} else if billions > 1 {
+		words = append(words, spanishApocope(spanishUnderMillion(int(billions)))+" billones")
+	}
+	if millions == 1 {
+		words = append(words, "un millón")
+	} else if millions > 1 {
+		words = append(words, spanishApocope(spanishUnderMillion(int(millions)))+" millones")
+	}
+	if rest > 0 {
+		words = append(words, spanishUnderMillion(rest))
+	}
+	return strings.Join(words, " ")
+}
+
+func spanishUnderMillion(n int) string {
+	thousands, rest := n/1000, n%1000
+
+	words := make([]string, 0, 3)
+	if thousands == 1 {
+		words = append(words, "mil")
+	} else if thousands > 1 {
+		words = append(words, spanishApocope(spanishUnder1000(thousands)), "mil")
+	}
+	if rest > 0 {
+		words = append(words, spanishUnder1000(rest))
+	}
+	return strings.Join(words, " ")
+}
+
+func spanishUnder1000(n int) string {
+	if n == 100 {
+		return "cien"
+	}
+
+	words := make([]string, 0, 2)
+	h, r := n/100, n%100
+	if h > 0 {
+		words = append(words, spanishHundreds[h])
+	}
+	if r >= 30 && r%10 != 0 {
+		words = append(words, spanishTens[r/10]+" y "+spanishUnder30[r%10])
+	} else if r >= 30 {
+		words = append(words, spanishTens[r/10])
+	} else if r > 0 {
+		words = append(words, spanishUnder30[r])
+	}
+	return strings.Join(words, " ")
+}
+
+// uno is shortened when it comes before a noun like mil or millones
+func spanishApocope(s string) string {
+	if strings.HasSuffix(s, "veintiuno") {
+		return strings.TrimSuffix(s, "uno") + "ún"
+	} else if strings.HasSuffix(s, "uno") {
+		return strings.TrimSuffix(s, "o")
+	}
+	return s
+}
+
+//------------------------------------------------------------------------------------------
+// Portuguese
+//------------------------------------------------------------------------------------------
+
+var portugueseOnes = []string{
+	"zero", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove", "dez", "onze", "doze",
+	"treze", "catorze", "quinze", "dezesseis", "dezessete", "dezoito", "dezenove",
+}
+var portugueseTens = []string{"", "", "vinte", "trinta", "quarenta", "cinquenta", "sessenta", "setenta", "oitenta", "noventa"}
+var portugueseHundreds = []string{
+	"", "cento", "duzentos", "trezentos", "quatrocentos", "quinhentos", "seiscentos", "setecentos", "oitocentos",
+	"novecentos",
+}
+var portugueseScales = [][2]string{{"", ""}, {"mil", "mil"}, {"milhão", "milhões"}, {"bilhão", "bilhões"}, {"trilhão", "trilhões"}}
+
+func spellPortuguese(n int64) string {
+	if n == 0 {
+		return portugueseOnes[0]
+	}
+
+	groups := digitGroups(n)
+	parts := make([]string, 0, len(groups))
+	last := 0
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		switch {
+		case g == 0:
+			continue
+		case i == 0:
+			parts = append(parts, portugueseUnder1000(g))
+		case i == 1 && g == 1:
+			parts = append(parts, "mil")
+		case g == 1:
+			parts = append(parts, "um "+portugueseScales[i][0])
+		default:
+			parts = append(parts, portugueseUnder1000(g)+" "+portugueseScales[i][1])
+		}
+		last = g
+	}
+
+	// the last part is joined with e if it's less than a hundred or a round number of hundreds
+	if len(parts) > 1 && (last < 100 || last%100 == 0) {
+		return strings.Join(parts[:len(parts)-1], " ") + " e " + parts[len(parts)-1]
+	}
+	return strings.Join(parts, " ")
+}
+
+func portugueseUnder1000(n int) string {
+	if n == 100 {
+		return "cem"
+	}
+
+	words := make([]string, 0, 2)
+	h, r := n/100, n%100
+	if h > 0 {
+		words = append(words, portugueseHundreds[h])
+	}
+	if r >= 20 && r%10 != 0 {
+		words = append(words, portugueseTens[r/10]+" e "+portugueseOnes[r%10])
+	} else if r >= 20 {
+		words = append(words, portugueseTens[r/10])
+	} else if r > 0 {
+		words = append(words, portugueseOnes[r])
+	}
+	return strings.Join(words, " e ")
+}
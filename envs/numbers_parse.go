@@ -0,0 +1,330 @@
+package envs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// the words used to write numbers in a particular language, with accents removed
+type numberWords struct {
+	values     map[string]int64 // numbers under a thousand, including some two word phrases
+	hundreds   map[string]bool  // words which multiply by a hundred
+	scales     map[string]int64
+	connectors map[string]bool
+	articles   map[string]bool // words for one which are more often used as articles, e.g. une question
+	minus      string
+}
+
+func newNumberWords(minus string, connectors ...string) *numberWords {
+	w := &numberWords{
+		values:     make(map[string]int64),
+		hundreds:   make(map[string]bool),
+		scales:     make(map[string]int64),
+		connectors: make(map[string]bool),
+		articles:   make(map[string]bool),
+		minus:      minus,
+	}
+	for _, connector := range connectors {
+		w.connectors[connector] = true
+	}
+	return w
+}
+
+func (w *numberWords) addArticles(words ...string) {
+	for _, word := range words {
+		w.articles[word] = true
+	}
+}
+
+// adds words whose values are their position in the given list multiplied by the given step
+func (w *numberWords) addValues(words []string, step int64) {
+	for i, word := range words {
+		if word != "" {
+			w.values[strings.Replace(normalizeWords(word), "-", " ", -1)] = int64(i) * step
+		}
+	}
+}
+
+func (w *numberWords) addValue(word string, value int64) {
+	w.values[normalizeWords(word)] = value
+}
+
+func (w *numberWords) addScale(value int64, words ...string) {
+	for _, word := range words {
+		w.scales[normalizeWords(word)] = value
+	}
+}
+
+var numberWordsByLanguage = map[Language]*numberWords{}
+
+func init() {
+	eng := newNumberWords("minus", "and")
+	eng.addValues(englishOnes, 1)
+	eng.addValues(englishTens, 10)
+	eng.hundreds["hundred"] = true
+	eng.addScale(1000, "thousand")
+	eng.addScale(1000000, "million")
+	eng.addScale(1000000000, "billion")
+	eng.addScale(1000000000000, "trillion")
+	eng.addArticles("one")
+	numberWordsByLanguage["eng"] = eng
+
+	fra := newNumberWords("moins", "et")
+	fra.addValues(frenchOnes, 1)
+	fra.addValues(frenchTens, 10)
+	fra.addValue("une", 1)
+	fra.addValue("quatre vingt", 80)
+	fra.addValue("quatre vingts", 80)
+	fra.hundreds["cent"] = true
+	fra.hundreds["cents"] = true
+	fra.addScale(1000, "mille")
+	fra.addScale(1000000, "million", "millions")
+	fra.addScale(1000000000, "milliard", "milliards")
+	fra.addScale(1000000000000, "billion", "billions")
+	fra.addArticles("un", "une")
+	numberWordsByLanguage["fra"] = fra
+
+	spa := newNumberWords("menos", "y")
+	spa.addValues(spanishUnder30, 1)
+	spa.addValues(spanishTens, 10)
+	spa.addValues(spanishHundreds, 100)
+	spa.addValue("un", 1)
+	spa.addValue("una", 1)
+	spa.addValue("veintiún", 21)
+	spa.addValue("cien", 100)
+	spa.addScale(1000, "mil")
+	spa.addScale(1000000, "millón", "millones")
+	spa.addScale(1000000000000, "billón", "billones")
+	spa.addArticles("un", "una")
+	numberWordsByLanguage["spa"] = spa
+
+	por := newNumberWords("menos", "e")
+	por.addValues(portugueseOnes, 1)
+	por.addValues(portugueseTens, 10)
+	por.addValues(portugueseHundreds, 100)
+	por.addValue("uma", 1)
+	por.addValue("duas", 2)
+	por.addValue("quatorze", 14)
+	por.addValue("cem", 100)
+	for i, scale := range portugueseScales[1:] {
+		por.addScale(pow1000(i+1), scale[0], scale[1])
+	}
+	por.addArticles("um", "uma")
+	numberWordsByLanguage["por"] = por
+}
+
+func pow1000(n int) int64 {
+	value := int64(1)
+	for i := 0; i < n; i++ {
+		value *= 1000
+	}
+	return value
+}
+
+// FindSpelledNumbers finds the whole numbers written in words in the given text, e.g. "twenty-five" or
+// "vingt-cinq", using the first of the given languages in which any are found. English is used if none of
+// the languages are supported. Numbers are only found if they make up at least half of the words in the text,
+// and words like "une" which are more often articles, are only read as numbers if they're the whole text.
+func FindSpelledNumbers(languages []Language, text string) []int64 {
+	supported := false
+	for _, lang := range languages {
+		if words := numberWordsByLanguage[lang]; words != nil {
+			supported = true
+			if numbers := words.find(text); len(numbers) > 0 {
+				return numbers
+			}
+		}
+	}
+	if !supported {
+		return numberWordsByLanguage["eng"].find(text)
+	}
+	return nil
+}
+
+// a word in some text and its position
+type wordToken struct {
+	word       string
+	start, end int
+}
+
+// splits normalized text into words of letters
+func tokenizeWords(text string) []wordToken {
+	var tokens []wordToken
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			tokens = append(tokens, wordToken{text[start:i], start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, wordToken{text[start:], start, len(text)})
+	}
+	return tokens
+}
+
+func (w *numberWords) find(str string) []int64 {
+	text := normalizeWords(str)
+	tokens := tokenizeWords(text)
+
+	// the words of a number can only be separated by spaces and hyphens
+	joined := func(i int) bool {
+		return i > 0 && strings.Trim(text[tokens[i-1].end:tokens[i].start], " -") == ""
+	}
+
+	// looks up the value of the word at the given position, which may be the first word of a two word phrase
+	valueAt := func(i int) (int64, int, bool) {
+		if i+1 < len(tokens) && joined(i+1) {
+			if value, found := w.values[tokens[i].word+" "+tokens[i+1].word]; found {
+				return value, 2, true
+			}
+		}
+		value, found := w.values[tokens[i].word]
+		return value, 1, found
+	}
+
+	// the number being read is made up of scaled parts like two million, and the part under a thousand
+	type scaledPart struct{ scale, amount int64 }
+	var numbers []int64
+	var scaled []scaledPart
+	var small int64
+	inNumber, negative, minus := false, false, false
+
+	// the words of the number being read, and of all the numbers found
+	var words []string
+	numberWords := 0
+
+	finish := func() {
+		// a lone article isn't a number unless it's all there is
+		isArticle := len(words) == 1 && w.articles[words[0]] && len(tokens) > 1
+
+		if inNumber && !isArticle {
+			number := small
+			for _, part := range scaled {
+				number += part.amount
+			}
+			if negative {
+				number = -number
+			}
+			numbers = append(numbers, number)
+			numberWords += len(words)
+		}
+		scaled, small, words = nil, 0, nil
+		inNumber, negative = false, false
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		word := tokens[i].word
+		if inNumber && !joined(i) {
+			finish()
+		}
+
+		// a minus only counts if it comes right before the number
+		if minus && joined(i) {
+			negative = true
+			words = append(words, tokens[i-1].word)
+		}
+		minus = false
+
+		if value, length, isValue := valueAt(i); isValue {
+			if inNumber && !canAddNumber(small, value) {
+				finish()
+			}
+			small += value
+			inNumber = true
+			for _, token := range tokens[i : i+length] {
+				words = append(words, token.word)
+			}
+			i += length - 1
+			continue
+		}
+
+		switch {
+		case w.hundreds[word]:
+			if small >= 100 {
+				finish()
+			}
+			if small == 0 {
+				small = 1
+			}
+			small *= 100
+			inNumber = true
+			words = append(words, word)
+
+		case w.scales[word] > 0:
+			scale := w.scales[word]
+
+			// a repeated scale must be the start of a new number
+			if len(scaled) > 0 && scaled[len(scaled)-1].scale == scale {
+				amount := small
+				small = 0
+				finish()
+				small = amount
+			}
+
+			// a larger scale applies to the smaller scaled parts before it, e.g. mil millones in Spanish
+			amount := small
+			for len(scaled) > 0 && scaled[len(scaled)-1].scale < scale {
+				amount += scaled[len(scaled)-1].amount
+				scaled = scaled[:len(scaled)-1]
+			}
+			if amount == 0 {
+				amount = 1
+			}
+
+			scaled = append(scaled, scaledPart{scale: scale, amount: amount * scale})
+			small = 0
+			inNumber = true
+			words = append(words, word)
+
+		case w.connectors[word] && inNumber:
+			// a connector only continues the number if what follows can be added to it
+			if i+1 < len(tokens) && joined(i+1) {
+				if next, _, isValue := valueAt(i + 1); isValue && canAddNumber(small, next) {
+					words = append(words, word)
+					continue
+				}
+			}
+			finish()
+
+		default:
+			finish()
+			minus = word == w.minus
+		}
+	}
+	finish()
+
+	// numbers in words are only believable if the text is mostly the number
+	if numberWords*2 < len(tokens) {
+		return nil
+	}
+
+	return numbers
+}
+
+// whether a value can be added to the part of a number under a thousand so far, e.g. five can be added to
+// twenty, but not to twenty-one
+func canAddNumber(small int64, value int64) bool {
+	if small == 0 {
+		return true
+	}
+	if value >= 100 {
+		return false
+	}
+
+	tensAndUnits := small % 100
+	switch {
+	case tensAndUnits == 0:
+		return true
+	case value < 10 && tensAndUnits%10 == 0:
+		return true
+	case value < 20 && tensAndUnits >= 60 && tensAndUnits%20 == 0:
+		// French counts in twenties above sixty, e.g. soixante-dix
+		return true
+	}
+	return false
+}
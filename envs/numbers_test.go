@@ -0,0 +1,145 @@
+package envs_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpellNumber(t *testing.T) {
+	tests := []struct {
+		language envs.Language
+		number   int64
+		expected string
+	}{
+		{"eng", 0, "zero"},
+		{"eng", 7, "seven"},
+		{"eng", 42, "forty-two"},
+		{"eng", 100, "one hundred"},
+		{"eng", 123, "one hundred twenty-three"},
+		{"eng", 2001, "two thousand one"},
+		{"eng", 1000000, "one million"},
+		{"eng", 3456000789, "three billion four hundred fifty-six million seven hundred eighty-nine"},
+		{"eng", -15, "minus fifteen"},
+		{envs.NilLanguage, 12, "twelve"},
+		{"kin", 12, "twelve"},
+
+		{"fra", 0, "zéro"},
+		{"fra", 21, "vingt et un"},
+		{"fra", 22, "vingt-deux"},
+		{"fra", 71, "soixante et onze"},
+		{"fra", 77, "soixante-dix-sept"},
+		{"fra", 80, "quatre-vingts"},
+		{"fra", 81, "quatre-vingt-un"},
+		{"fra", 99, "quatre-vingt-dix-neuf"},
+		{"fra", 100, "cent"},
+		{"fra", 200, "deux cents"},
+		{"fra", 201, "deux cent un"},
+		{"fra", 1000, "mille"},
+		{"fra", 80000, "quatre-vingt mille"},
+		{"fra", 200000, "deux cent mille"},
+		{"fra", 1000000, "un million"},
+		{"fra", 2000000, "deux millions"},
+		{"fra", -3, "moins trois"},
+
+		{"spa", 0, "cero"},
+		{"spa", 16, "dieciséis"},
+		{"spa", 21, "veintiuno"},
+		{"spa", 31, "treinta y uno"},
+		{"spa", 100, "cien"},
+		{"spa", 101, "ciento uno"},
+		{"spa", 500, "quinientos"},
+		{"spa", 1000, "mil"},
+		{"spa", 21000, "veintiún mil"},
+		{"spa", 31000, "treinta y un mil"},
+		{"spa", 1000000, "un millón"},
+		{"spa", 2500000, "dos millones quinientos mil"},
+		{"spa", 1000000000, "mil millones"},
+		{"spa", 1000000000000, "un billón"},
+		{"spa", -1, "menos uno"},
+
+		{"por", 0, "zero"},
+		{"por", 16, "dezesseis"},
+		{"por", 21, "vinte e um"},
+		{"por", 100, "cem"},
+		{"por", 123, "cento e vinte e três"},
+		{"por", 1001, "mil e um"},
+		{"por", 1100, "mil e cem"},
+		{"por", 1234, "mil duzentos e trinta e quatro"},
+		{"por", 1000000, "um milhão"},
+		{"por", 2005000, "dois milhões e cinco mil"},
+		{"por", -2, "menos dois"},
+	}
+
+	for _, tc := range tests {
+		spelled, err := envs.SpellNumber(tc.language, tc.number)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, spelled, "spelling mismatch for %d in %s", tc.number, tc.language)
+	}
+
+	_, err := envs.SpellNumber("eng", 1000000000000000)
+	assert.EqualError(t, err, "can't spell out numbers larger than 999999999999999")
+}
+
+func TestFindSpelledNumbers(t *testing.T) {
+	tests := []struct {
+		languages []envs.Language
+		text      string
+		expected  []int64
+	}{
+		{nil, "twenty five", []int64{25}},
+		{nil, "Twenty-Five cows and three goats", []int64{25, 3}},
+		{nil, "one hundred and five", []int64{105}},
+		{nil, "nineteen hundred", []int64{1900}},
+		{nil, "a thousand", []int64{1000}},
+		{nil, "two million three thousand and one", []int64{2003001}},
+		{nil, "one million two million", []int64{1000000, 2000000}},
+		{nil, "five six", []int64{5, 6}},
+		{nil, "twenty twenty", []int64{20, 20}},
+		{nil, "twenty, five", []int64{20, 5}},
+		{nil, "minus ten", []int64{-10}},
+		{nil, "minus, ten", []int64{10}},
+		{nil, "nothing here", nil},
+		{nil, "I have twenty five cows and no goats", nil}, // mostly not the number
+		{nil, "one", []int64{1}},
+		{nil, "one please", nil}, // one on its own could be an article
+		{nil, "no one knows", nil},
+		{nil, "vingt-cinq", nil},
+		{[]envs.Language{"kin"}, "twelve", []int64{12}},
+
+		{[]envs.Language{"fra"}, "vingt-cinq", []int64{25}},
+		{[]envs.Language{"fra"}, "j'ai soixante et onze ans", []int64{71}},
+		{[]envs.Language{"fra"}, "quatre-vingt-dix-neuf", []int64{99}},
+		{[]envs.Language{"fra"}, "deux mille", []int64{2000}},
+		{[]envs.Language{"fra"}, "Une", []int64{1}},
+		{[]envs.Language{"fra"}, "une question", nil},
+		{[]envs.Language{"fra"}, "j'ai une question", nil},
+		{[]envs.Language{"fra", "eng"}, "twenty five", []int64{25}},
+		{[]envs.Language{"spa"}, "treinta y cinco", []int64{35}},
+		{[]envs.Language{"spa"}, "uno y dos", []int64{1, 2}},
+		{[]envs.Language{"spa"}, "mil millones", []int64{1000000000}},
+		{[]envs.Language{"spa"}, "dieciséis", []int64{16}},
+		{[]envs.Language{"spa"}, "tengo una pregunta", nil},
+		{[]envs.Language{"spa"}, "once upon a time", nil},
+		{[]envs.Language{"por"}, "mil e quinhentos", []int64{1500}},
+		{[]envs.Language{"por"}, "dois e três", []int64{2, 3}},
+		{[]envs.Language{"por"}, "duas", []int64{2}},
+		{[]envs.Language{"por"}, "um", []int64{1}},
+		{[]envs.Language{"por"}, "tenho um problema", nil},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, envs.FindSpelledNumbers(tc.languages, tc.text), "mismatch for %s in %v", tc.text, tc.languages)
+	}
+
+	// whatever we can spell out, we can read back
+	for _, lang := range []envs.Language{"eng", "fra", "spa", "por"} {
+		for _, n := range []int64{0, 1, 16, 21, 71, 80, 81, 99, 100, 101, 180, 200, 999, 1000, 1001, 1021, 21000, 80000, 100000, 123456, 1000000, 2000001, 21000000, 1000000000, 3456000789, 1000000000000, 999999999999999, -42} {
+			spelled, err := envs.SpellNumber(lang, n)
+			assert.NoError(t, err)
+			assert.Equal(t, []int64{n}, envs.FindSpelledNumbers([]envs.Language{lang}, spelled), "mismatch for %s in %s", spelled, lang)
+		}
+	}
+}
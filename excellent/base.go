@@ -10,6 +10,16 @@ import (
 
 // VisitExpression parses and visits the given expression with the given visitor
 func VisitExpression(expression string, visitor antlr.ParseTreeVisitor) (interface{}, error) {
+	tree, err := parseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return visitor.Visit(tree), nil
+}
+
+// parses the given expression into a tree which can be visited
+func parseExpression(expression string) (antlr.ParseTree, error) {
 	errListener := NewErrorListener(expression)
 
 	input := antlr.NewInputStream(expression)
@@ -25,7 +35,7 @@ func VisitExpression(expression string, visitor antlr.ParseTreeVisitor) (interfa
 		return nil, errListener.Errors()[0]
 	}
 
-	return visitor.Visit(tree), nil
+	return tree, nil
 }
 
 // VisitTemplate scans the given template and calls the callback for each token encountered
@@ -38,7 +48,13 @@ func VisitTemplate(template string, allowedTopLevels []string, callback func(XTo
 	scanner := NewXScanner(strings.NewReader(template), allowedTopLevels)
 	errors := NewTemplateErrors()
 
-	for tokenType, token := scanner.Scan(); tokenType != EOF; tokenType, token = scanner.Scan() {
+	for {
+		offset := scanner.Position()
+		tokenType, token := scanner.Scan()
+		if tokenType == EOF {
+			break
+		}
+
 		err := callback(tokenType, token)
 		if err != nil {
 			var repr string
@@ -48,7 +64,7 @@ func VisitTemplate(template string, allowedTopLevels []string, callback func(XTo
 				repr = "@(" + token + ")"
 			}
 
-			errors.Add(repr, err.Error())
+			errors.AddError(repr, offset, err)
 		}
 	}
 
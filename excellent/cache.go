@@ -0,0 +1,85 @@
+package excellent
+
+import (
+	"container/list"
+	"sync"
+)
+
+// TemplateCache is a fixed size cache of compiled templates keyed by their text, which evicts the least
+// recently used template when full. It is safe for concurrent use.
+type TemplateCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	recency  *list.List // front is most recently used
+	mutex    sync.Mutex
+}
+
+// NewTemplateCache creates a new template cache which holds up to the given number of templates
+func NewTemplateCache(capacity int) *TemplateCache {
+	return &TemplateCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		recency:  list.New(),
+	}
+}
+
+// Get returns the compiled version of the given template, compiling and caching it if necessary
+func (c *TemplateCache) Get(template string, allowedTopLevels []string) *Template {
+	if c == nil || c.capacity <= 0 {
+		return CompileTemplate(template, allowedTopLevels)
+	}
+
+	c.mutex.Lock()
+	if element, found := c.entries[template]; found {
+		compiled := element.Value.(*Template)
+
+		// scanning depends on the allowed top levels so a template compiled for a different context can't be used
+		if sameTopLevels(compiled.allowedTopLevels, allowedTopLevels) {
+			c.recency.MoveToFront(element)
+			c.mutex.Unlock()
+			return compiled
+		}
+	}
+	c.mutex.Unlock()
+
+	// compile outside of the lock as that's the expensive part
+	compiled := CompileTemplate(template, allowedTopLevels)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[template]; found {
+		element.Value = compiled
+		c.recency.MoveToFront(element)
+	} else {
+		c.entries[template] = c.recency.PushFront(compiled)
+
+		if c.recency.Len() > c.capacity {
+			oldest := c.recency.Back()
+			c.recency.Remove(oldest)
+			delete(c.entries, oldest.Value.(*Template).source)
+		}
+	}
+
+	return compiled
+}
+
+// Len returns the number of templates currently in the cache
+func (c *TemplateCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.recency.Len()
+}
+
+func sameTopLevels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,427 @@
+package checker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/gen"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// ProblemCode is the type of a problem found by the checker
+type ProblemCode string
+
+// possible problem codes
+const (
+	ProblemSyntax           ProblemCode = "syntax"
+	ProblemUnknownFunction  ProblemCode = "unknown_function"
+	ProblemWrongArity       ProblemCode = "wrong_arity"
+	ProblemArgumentMismatch ProblemCode = "argument_mismatch"
+	ProblemTypeMismatch     ProblemCode = "type_mismatch"
+	ProblemUnknownPath      ProblemCode = "unknown_path"
+)
+
+// Problem is a problem found in an expression, with the character positions of the part of the template it relates to
+type Problem struct {
+	Code    ProblemCode `json:"code"`
+	Message string      `json:"message"`
+	Start   int         `json:"start"`
+	End     int         `json:"end"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("%s at %d-%d", p.Message, p.Start, p.End)
+}
+
+// CheckTemplate statically checks all the expressions in the given template against the given context shape
+func CheckTemplate(env envs.Environment, context *Shape, template string) []*Problem {
+	problems := make([]*Problem, 0)
+
+	scanner := excellent.NewXScanner(strings.NewReader(template), context.PropertyNames())
+	scanner.SetUnescapeBody(false)
+
+	// track our position in the template in characters
+	pos := 0
+
+	for tokenType, token := scanner.Scan(); tokenType != excellent.EOF; tokenType, token = scanner.Scan() {
+		switch tokenType {
+		case excellent.IDENTIFIER:
+			problems = append(problems, checkExpression(env, context, token, pos+1)...)
+			pos += 1 + utf8.RuneCountInString(token)
+		case excellent.EXPRESSION:
+			problems = append(problems, checkExpression(env, context, token, pos+2)...)
+			pos += 3 + utf8.RuneCountInString(token)
+		default:
+			pos += utf8.RuneCountInString(token)
+		}
+	}
+
+	return problems
+}
+
+// CheckExpression statically checks the given expression against the given context shape
+func CheckExpression(env envs.Environment, context *Shape, expression string) []*Problem {
+	return checkExpression(env, context, expression, 0)
+}
+
+func checkExpression(env envs.Environment, context *Shape, expression string, offset int) []*Problem {
+	v := &visitor{env: env, context: context, offset: offset, problems: make([]*Problem, 0)}
+
+	if _, err := excellent.VisitExpression(expression, v); err != nil {
+		return []*Problem{{
+			Code:    ProblemSyntax,
+			Message: err.Error(),
+			Start:   offset,
+			End:     offset + utf8.RuneCountInString(expression),
+		}}
+	}
+
+	return v.problems
+}
+
+// visitor which infers the shape of each part of an expression
+type visitor struct {
+	gen.BaseExcellent2Visitor
+
+	env      envs.Environment
+	context  *Shape
+	offset   int
+	problems []*Problem
+
+	// parameters of the anonymous functions we're inside of
+	locals map[string]*Shape
+}
+
+func (v *visitor) addProblem(ctx antlr.ParserRuleContext, code ProblemCode, message string, args ...interface{}) {
+	v.problems = append(v.problems, &Problem{
+		Code:    code,
+		Message: fmt.Sprintf(message, args...),
+		Start:   v.offset + ctx.GetStart().GetStart(),
+		End:     v.offset + ctx.GetStop().GetStop() + 1,
+	})
+}
+
+func (v *visitor) shape(tree antlr.ParseTree) *Shape {
+	s, _ := v.Visit(tree).(*Shape)
+	if s == nil {
+		return AnyShape
+	}
+	return s
+}
+
+// Visit the top level parse tree
+func (v *visitor) Visit(tree antlr.ParseTree) interface{} {
+	return tree.Accept(v)
+}
+
+// VisitParse handles our top level parser
+func (v *visitor) VisitParse(ctx *gen.ParseContext) interface{} {
+	return v.Visit(ctx.Expression())
+}
+
+// VisitTextLiteral deals with string literals such as "asdf"
+func (v *visitor) VisitTextLiteral(ctx *gen.TextLiteralContext) interface{} {
+	value := ctx.GetText()
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		unquoted = value[1 : len(value)-1]
+	}
+	return newLiteral(functions.TypeText, types.NewXText(unquoted))
+}
+
+// VisitNumberLiteral deals with numbers like 123 or 1.5
+func (v *visitor) VisitNumberLiteral(ctx *gen.NumberLiteralContext) interface{} {
+	return newLiteral(functions.TypeNumber, types.RequireXNumberFromString(ctx.GetText()))
+}
+
+// VisitTrue deals with the `true` reserved word
+func (v *visitor) VisitTrue(ctx *gen.TrueContext) interface{} {
+	return newLiteral(functions.TypeBoolean, types.XBooleanTrue)
+}
+
+// VisitFalse deals with the `false` reserved word
+func (v *visitor) VisitFalse(ctx *gen.FalseContext) interface{} {
+	return newLiteral(functions.TypeBoolean, types.XBooleanFalse)
+}
+
+// VisitNull deals with the `null` reserved word
+func (v *visitor) VisitNull(ctx *gen.NullContext) interface{} {
+	return AnyShape
+}
+
+// VisitContextReference deals with identifiers which are function names or root variables in the context
+func (v *visitor) VisitContextReference(ctx *gen.ContextReferenceContext) interface{} {
+	name := strings.ToLower(ctx.GetText())
+
+	if local, isLocal := v.locals[name]; isLocal {
+		return local
+	}
+
+	if functions.LookupIn(v.env, name) != nil {
+		return NewShape(functions.TypeFunction)
+	}
+
+	shape, exists := v.context.Property(name)
+	if !exists {
+		v.addProblem(ctx, ProblemUnknownPath, "context has no property '%s'", name)
+		return AnyShape
+	}
+	return shape
+}
+
+// VisitDotLookup deals with property lookups like foo.bar
+func (v *visitor) VisitDotLookup(ctx *gen.DotLookupContext) interface{} {
+	container := v.shape(ctx.Atom())
+
+	if ctx.NAME() != nil {
+		return v.lookup(ctx, container, ctx.NAME().GetText(), true)
+	}
+	return v.lookup(ctx, container, ctx.INTEGER().GetText(), true)
+}
+
+// VisitArrayLookup deals with lookups such as foo[5] or foo["key with spaces"]
+func (v *visitor) VisitArrayLookup(ctx *gen.ArrayLookupContext) interface{} {
+	container := v.shape(ctx.Atom())
+	lookup := v.shape(ctx.Expression())
+
+	if lookup.value != nil {
+		key, _ := types.ToXText(v.env, lookup.value)
+		return v.lookup(ctx, container, key.Native(), false)
+	}
+
+	if container.Type == functions.TypeArray && container.Items != nil {
+		return container.Items
+	}
+	return AnyShape
+}
+
+func (v *visitor) lookup(ctx antlr.ParserRuleContext, container *Shape, key string, strict bool) *Shape {
+	switch container.Type {
+	case functions.TypeAny:
+		return AnyShape
+	case functions.TypeArray:
+		if _, err := strconv.Atoi(key); err != nil {
+			v.addProblem(ctx, ProblemTypeMismatch, "'%s' isn't a valid index for an array", key)
+			return AnyShape
+		}
+		if container.Items != nil {
+			return container.Items
+		}
+		return AnyShape
+	case functions.TypeObject:
+		shape, exists := container.Property(key)
+		if !exists {
+			// [] notation doesn't error for non-existent properties, . does
+			if strict {
+				v.addProblem(ctx, ProblemUnknownPath, "%s has no property '%s'", strings.ToLower(ctx.GetChild(0).(antlr.ParseTree).GetText()), strings.ToLower(key))
+			}
+			return AnyShape
+		}
+		return shape
+	default:
+		v.addProblem(ctx, ProblemTypeMismatch, "%s doesn't support lookups", container.Describe())
+		return AnyShape
+	}
+}
+
+// VisitFunctionCall deals with function calls like TITLE(foo.bar)
+func (v *visitor) VisitFunctionCall(ctx *gen.FunctionCallContext) interface{} {
+	var args []*Shape
+	var argCtxs []gen.IExpressionContext
+	if ctx.Parameters() != nil {
+		argCtxs = ctx.Parameters().(*gen.FunctionParametersContext).AllExpression()
+		for _, argCtx := range argCtxs {
+			args = append(args, v.shape(argCtx))
+		}
+	}
+
+	// if this is a call by name, we can check the function exists and its signature
+	if ref, isRef := ctx.Atom().(*gen.ContextReferenceContext); isRef {
+		name := strings.ToLower(ref.GetText())
+
+		if functions.LookupIn(v.env, name) == nil {
+			if _, isProperty := v.context.Property(name); !isProperty {
+				v.addProblem(ref, ProblemUnknownFunction, "%s is not a function", name)
+				return AnyShape
+			}
+		}
+
+		if signature := functions.LookupSignatureIn(v.env, name); signature != nil {
+			return v.checkCall(ctx, name, signature, args, argCtxs)
+		}
+		return AnyShape
+	}
+
+	function := v.shape(ctx.Atom())
+	if function.Type != functions.TypeAny && function.Type != functions.TypeFunction {
+		v.addProblem(ctx.Atom(), ProblemTypeMismatch, "%s is not a function", ctx.Atom().GetText())
+	}
+	return AnyShape
+}
+
+func (v *visitor) checkCall(ctx *gen.FunctionCallContext, name string, signature *functions.Signature, args []*Shape, argCtxs []gen.IExpressionContext) *Shape {
+	min, max := signature.MinArgs(), signature.MaxArgs()
+
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		var expected string
+		if min == max {
+			expected = fmt.Sprintf("%d", min)
+		} else if max < 0 {
+			expected = fmt.Sprintf("at least %d", min)
+		} else {
+			expected = fmt.Sprintf("%d to %d", min, max)
+		}
+		v.addProblem(ctx, ProblemWrongArity, "%s takes %s argument(s), got %d", name, expected, len(args))
+	} else {
+		for i, arg := range args {
+			paramType := signature.ParamType(i)
+
+			if !v.convertible(arg, paramType) {
+				v.addProblem(argCtxs[i], ProblemArgumentMismatch, "argument %d of %s must be %s, got %s", i+1, name, paramType, arg.Describe())
+			}
+		}
+	}
+
+	if signature.Returns == functions.TypeObject {
+		return NewDynamicObjectShape("")
+	}
+	return NewShape(signature.Returns)
+}
+
+// VisitAnonymousFunction deals with anonymous functions like (x) => x * 2
+func (v *visitor) VisitAnonymousFunction(ctx *gen.AnonymousFunctionContext) interface{} {
+	outer := v.locals
+
+	// we don't know what the function will be called with so its parameters could be anything
+	v.locals = make(map[string]*Shape, len(outer)+len(ctx.AllNAME()))
+	for name, shape := range outer {
+		v.locals[name] = shape
+	}
+	for _, name := range ctx.AllNAME() {
+		v.locals[strings.ToLower(name.GetText())] = AnyShape
+	}
+
+	v.Visit(ctx.Expression())
+
+	v.locals = outer
+
+	return NewShape(functions.TypeFunction)
+}
+
+// VisitFunctionParameters deals with the parameters to a function call
+func (v *visitor) VisitFunctionParameters(ctx *gen.FunctionParametersContext) interface{} {
+	for _, expression := range ctx.AllExpression() {
+		v.Visit(expression)
+	}
+	return nil
+}
+
+// VisitParentheses deals with expressions in parentheses such as (1+2)
+func (v *visitor) VisitParentheses(ctx *gen.ParenthesesContext) interface{} {
+	return v.Visit(ctx.Expression())
+}
+
+// VisitAtomReference deals with visiting a single atom in our expression
+func (v *visitor) VisitAtomReference(ctx *gen.AtomReferenceContext) interface{} {
+	return v.Visit(ctx.Atom())
+}
+
+// VisitNegation deals with negations such as -5
+func (v *visitor) VisitNegation(ctx *gen.NegationContext) interface{} {
+	v.checkOperand(ctx.Expression(), functions.TypeNumber, "-")
+	return NewShape(functions.TypeNumber)
+}
+
+// VisitExponent deals with exponenets such as 5^5
+func (v *visitor) VisitExponent(ctx *gen.ExponentContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeNumber, functions.TypeNumber, "^")
+}
+
+// VisitConcatenation deals with string concatenations like "foo" & "bar"
+func (v *visitor) VisitConcatenation(ctx *gen.ConcatenationContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeText, functions.TypeText, "&")
+}
+
+// VisitAdditionOrSubtraction deals with addition and subtraction like 5+5 and 5-3
+func (v *visitor) VisitAdditionOrSubtraction(ctx *gen.AdditionOrSubtractionContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeNumber, functions.TypeNumber, ctx.GetOp().GetText())
+}
+
+// VisitMultiplicationOrDivision deals with division and multiplication such as 5*5 or 5/2
+func (v *visitor) VisitMultiplicationOrDivision(ctx *gen.MultiplicationOrDivisionContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeNumber, functions.TypeNumber, ctx.GetOp().GetText())
+}
+
+// VisitEquality deals with equality or inequality tests 5 = 5 and 5 != 5
+func (v *visitor) VisitEquality(ctx *gen.EqualityContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeText, functions.TypeBoolean, ctx.GetOp().GetText())
+}
+
+// VisitComparison deals with visiting a comparison between two values, such as 5<3 or 3>5
+func (v *visitor) VisitComparison(ctx *gen.ComparisonContext) interface{} {
+	return v.binary(ctx.Expression(0), ctx.Expression(1), functions.TypeNumber, functions.TypeBoolean, ctx.GetOp().GetText())
+}
+
+func (v *visitor) binary(arg1, arg2 gen.IExpressionContext, operandType, resultType functions.Type, operator string) *Shape {
+	v.checkOperand(arg1, operandType, operator)
+	v.checkOperand(arg2, operandType, operator)
+	return NewShape(resultType)
+}
+
+func (v *visitor) checkOperand(arg gen.IExpressionContext, operandType functions.Type, operator string) {
+	shape := v.shape(arg)
+	if !v.convertible(shape, operandType) {
+		v.addProblem(arg, ProblemArgumentMismatch, "operand of %s must be %s, got %s", operator, operandType, shape.Describe())
+	}
+}
+
+// checks whether a value of the given shape could be converted to the given type
+func (v *visitor) convertible(from *Shape, to functions.Type) bool {
+	if to == functions.TypeAny || to == functions.TypeText || to == functions.TypeBoolean || from.Type == functions.TypeAny {
+		return true
+	}
+
+	// if we know the value, we can try to convert it
+	if from.value != nil {
+		var xerr types.XError
+		switch to {
+		case functions.TypeNumber:
+			_, xerr = types.ToXNumber(v.env, from.value)
+		case functions.TypeDate:
+			_, xerr = types.ToXDate(v.env, from.value)
+		case functions.TypeDateTime:
+			_, xerr = types.ToXDateTime(v.env, from.value)
+		case functions.TypeTime:
+			_, xerr = types.ToXTime(v.env, from.value)
+		default:
+			return from.Type == to
+		}
+		return xerr == nil
+	}
+
+	if from.Type == to {
+		return true
+	}
+
+	// objects with default values can be converted to whatever their default can be converted to
+	if from.Type == functions.TypeObject && from.Default != "" {
+		return v.convertible(NewShape(from.Default), to)
+	}
+
+	switch to {
+	case functions.TypeNumber:
+		return from.Type == functions.TypeText
+	case functions.TypeDate, functions.TypeDateTime:
+		return from.Type == functions.TypeText || from.Type == functions.TypeDate || from.Type == functions.TypeDateTime
+	case functions.TypeTime:
+		return from.Type == functions.TypeText || from.Type == functions.TypeDateTime || from.Type == functions.TypeNumber
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/checker"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTemplate(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	text := checker.NewShape(functions.TypeText)
+
+	context := checker.NewObjectShape(map[string]*checker.Shape{
+		"contact": checker.NewObjectShape(map[string]*checker.Shape{
+			"name":       text,
+			"created_on": checker.NewShape(functions.TypeDateTime),
+			"urns":       checker.NewArrayShape(text),
+			"fields": checker.NewObjectShape(map[string]*checker.Shape{
+				"age": checker.NewShape(functions.TypeNumber),
+			}, functions.TypeText),
+		}, functions.TypeText),
+		"webhook": checker.AnyShape,
+		"results": checker.NewDynamicObjectShape(functions.TypeText),
+	}, "")
+
+	tcs := []struct {
+		template string
+		problems []*checker.Problem
+	}{
+		{``, []*checker.Problem{}},
+		{`Hi @contact.name`, []*checker.Problem{}},
+		{`@contact @(upper(contact.name)) @(contact.fields.age + 1) @(datetime_add(contact.created_on, 1, "D"))`, []*checker.Problem{}},
+		{`@webhook.foo.bar @(webhook[0].x) @results.anything.value`, []*checker.Problem{}},
+		{`@(contact.urns[0]) @(contact.urns.0) @(contact.fields["foo"]) @(foreach(contact.urns, upper))`, []*checker.Problem{}},
+		{`@(format_date("2020-01-01")) @(word("a b", "1")) @(date("x"))`, []*checker.Problem{}},
+		{
+			`@(datetime_add(contact.name, "x", "D"))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemArgumentMismatch, Message: `argument 2 of datetime_add must be number, got "x"`, Start: 29, End: 32},
+			},
+		},
+		{
+			`Hi @contact.nmae!`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact has no property 'nmae'`, Start: 4, End: 16},
+			},
+		},
+		{
+			`@(contact.fields.gender & "x")`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact.fields has no property 'gender'`, Start: 2, End: 23},
+			},
+		},
+		{
+			`@(foo(1)) @(upper("a", "b")) @(rand(1))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownFunction, Message: `foo is not a function`, Start: 2, End: 5},
+				{Code: checker.ProblemWrongArity, Message: `upper takes 1 argument(s), got 2`, Start: 12, End: 27},
+				{Code: checker.ProblemWrongArity, Message: `rand takes 0 argument(s), got 1`, Start: 31, End: 38},
+			},
+		},
+		{
+			`@(max()) @(word("a")) @(round(1, 2, 3))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemWrongArity, Message: `max takes at least 1 argument(s), got 0`, Start: 2, End: 7},
+				{Code: checker.ProblemWrongArity, Message: `word takes 2 to 3 argument(s), got 1`, Start: 11, End: 20},
+				{Code: checker.ProblemWrongArity, Message: `round takes 1 to 2 argument(s), got 3`, Start: 24, End: 38},
+			},
+		},
+		{
+			`@(contact.urns - 1) @(weekday(contact.fields.age)) @(join(contact.name, ","))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemArgumentMismatch, Message: `operand of - must be number, got array`, Start: 2, End: 14},
+				{Code: checker.ProblemArgumentMismatch, Message: `argument 1 of weekday must be date, got number`, Start: 30, End: 48},
+				{Code: checker.ProblemArgumentMismatch, Message: `argument 1 of join must be array, got text`, Start: 58, End: 70},
+			},
+		},
+		{
+			`@(contact.name.first) @(contact.urns.foo) @(upper)(1)`,
+			[]*checker.Problem{
+				{Code: checker.ProblemTypeMismatch, Message: `text doesn't support lookups`, Start: 2, End: 20},
+				{Code: checker.ProblemTypeMismatch, Message: `'foo' isn't a valid index for an array`, Start: 24, End: 40},
+			},
+		},
+		{
+			`@(1 + ) and @foo.bar`,
+			[]*checker.Problem{
+				{Code: checker.ProblemSyntax, Message: `syntax error at `, Start: 2, End: 6},
+			},
+		},
+		{
+			`@@contact.nmae @(contact.nmae)`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact has no property 'nmae'`, Start: 17, End: 29},
+			},
+		},
+		{
+			`@(map(contact.urns, (u) => upper(u))) @(filter(contact.urns, (x) => x.path = contact.nmae))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact has no property 'nmae'`, Start: 77, End: 89},
+			},
+		},
+		{
+			`Ça @(contact.nmae)`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact has no property 'nmae'`, Start: 5, End: 17},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		problems := checker.CheckTemplate(env, context, tc.template)
+
+		assert.Equal(t, tc.problems, problems, "problems mismatch for template: %s", tc.template)
+	}
+}
+
+func TestCheckWithRegisteredFunctions(t *testing.T) {
+	registry := functions.NewRegistry()
+	registry.Register("shout", functions.OneTextFunction(func(env envs.Environment, s types.XText) types.XValue { return s }))
+	registry.Register("upper", functions.TwoTextFunction(func(env envs.Environment, s1, s2 types.XText) types.XValue { return s1 }))
+
+	context := checker.NewObjectShape(map[string]*checker.Shape{"name": checker.NewShape(functions.TypeText)}, "")
+
+	// outside of an environment carrying the registry, engine functions are unknown
+	env := envs.NewBuilder().Build()
+	assert.Equal(t, []*checker.Problem{
+		{Code: checker.ProblemUnknownFunction, Message: `shout is not a function`, Start: 2, End: 7},
+	}, checker.CheckTemplate(env, context, `@(shout(name))`))
+
+	// engine functions are known, and aren't checked against the signatures of builtins they replace
+	env = functions.NewScopedEnvironment(env, registry)
+	assert.Equal(t, []*checker.Problem{}, checker.CheckTemplate(env, context, `@(shout(name)) @(upper(name, "x"))`))
+}
+
+func TestCheckExpression(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	context := checker.NewObjectShape(map[string]*checker.Shape{"name": checker.NewShape(functions.TypeText)}, "")
+
+	assert.Equal(t, []*checker.Problem{}, checker.CheckExpression(env, context, `upper(name)`))
+	assert.Equal(t, []*checker.Problem{
+		{Code: checker.ProblemUnknownPath, Message: `context has no property 'nmae'`, Start: 6, End: 10},
+	}, checker.CheckExpression(env, context, `upper(nmae)`))
+
+	problem := checker.CheckExpression(env, context, `upper(nmae)`)[0]
+	assert.Equal(t, "context has no property 'nmae' at 6-10", problem.Error())
+}
@@ -0,0 +1,79 @@
+package checker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// Shape describes the static type of a value in an evaluation context. Objects can describe their known
+// properties, or have nil properties if any property might exist, e.g. a webhook response.
+type Shape struct {
+	Type       functions.Type
+	Properties map[string]*Shape
+	Items      *Shape
+	Default    functions.Type
+
+	// the value if this is a literal
+	value types.XValue
+}
+
+// NewShape creates a new shape of the given type
+func NewShape(t functions.Type) *Shape {
+	return &Shape{Type: t}
+}
+
+// NewObjectShape creates a new object shape with the given properties and type of default value, which can be empty
+func NewObjectShape(properties map[string]*Shape, defaultType functions.Type) *Shape {
+	props := make(map[string]*Shape, len(properties))
+	for k, v := range properties {
+		props[strings.ToLower(k)] = v
+	}
+	return &Shape{Type: functions.TypeObject, Properties: props, Default: defaultType}
+}
+
+// NewDynamicObjectShape creates a new object shape where any property might exist
+func NewDynamicObjectShape(defaultType functions.Type) *Shape {
+	return &Shape{Type: functions.TypeObject, Default: defaultType}
+}
+
+// NewArrayShape creates a new array shape with the given item shape
+func NewArrayShape(items *Shape) *Shape {
+	return &Shape{Type: functions.TypeArray, Items: items}
+}
+
+// AnyShape is the shape of a value we know nothing about
+var AnyShape = NewShape(functions.TypeAny)
+
+// Property returns the shape of the given property of this object
+func (s *Shape) Property(name string) (*Shape, bool) {
+	if s.Properties == nil {
+		return AnyShape, true
+	}
+	p, exists := s.Properties[strings.ToLower(name)]
+	return p, exists
+}
+
+// PropertyNames returns the sorted names of the known properties of this object
+func (s *Shape) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Describe returns a description of this shape for use in messages
+func (s *Shape) Describe() string {
+	if s.value != nil {
+		return types.Describe(s.value)
+	}
+	return string(s.Type)
+}
+
+func newLiteral(t functions.Type, value types.XValue) *Shape {
+	return &Shape{Type: t, value: value}
+}
@@ -1,23 +1,35 @@
 package excellent
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/nyaruka/goflow/utils"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
-	"github.com/pkg/errors"
 )
 
-// TemplateError is an error which occurs during evaluation of an expression
+// TemplateError is an error which occurs during evaluation of an expression in a template. The underlying
+// error, e.g. a *SyntaxError, can be retrieved with errors.As.
 type TemplateError struct {
-	expression string
-	message    string
+	// Expression is the expression as it appears in the template, e.g. @(1 / 0)
+	Expression string
+
+	// Offset is the offset in runes of the expression in the template, or -1 if not known
+	Offset int
+
+	cause error
 }
 
 func (e TemplateError) Error() string {
-	return fmt.Sprintf("error evaluating %s: %s", e.expression, e.message)
+	return fmt.Sprintf("error evaluating %s: %s", e.Expression, e.cause.Error())
+}
+
+// Unwrap returns the underlying error
+func (e TemplateError) Unwrap() error {
+	return e.cause
 }
 
 // TemplateErrors represents the list of all errors encountered during evaluation of a template
@@ -29,14 +41,25 @@ func NewTemplateErrors() *TemplateErrors {
 	return &TemplateErrors{}
 }
 
+// Add adds an error with the given message for an expression whose position in the template isn't known
 func (e *TemplateErrors) Add(expression, message string) {
-	e.errors = append(e.errors, &TemplateError{expression: expression, message: message})
+	e.AddError(expression, -1, errors.New(message))
+}
+
+// AddError adds the given error for the expression at the given offset in the template
+func (e *TemplateErrors) AddError(expression string, offset int, err error) {
+	e.errors = append(e.errors, &TemplateError{Expression: expression, Offset: offset, cause: err})
 }
 
 func (e *TemplateErrors) HasErrors() bool {
 	return len(e.errors) > 0
 }
 
+// Errors returns the individual errors
+func (e *TemplateErrors) Errors() []*TemplateError {
+	return e.errors
+}
+
 // Error returns a single string describing all the errors encountered
 func (e *TemplateErrors) Error() string {
 	messages := make([]string, len(e.errors))
@@ -46,6 +69,54 @@ func (e *TemplateErrors) Error() string {
 	return strings.Join(messages, ", ")
 }
 
+// As finds the first of our errors which matches target, so that errors.As can be used on a template
+// evaluation error to find a specific error type
+func (e *TemplateErrors) As(target interface{}) bool {
+	for _, err := range e.errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SyntaxError is an error in the syntax of an expression
+type SyntaxError struct {
+	// Expression is the expression being parsed
+	Expression string
+
+	// Offset is the offset in runes of the offending token in the expression
+	Offset int
+
+	// Token is the text of the offending token, or empty if the end of the expression was reached
+	Token string
+
+	// Expected is the tokens which would have been valid at this position, e.g. ")" or NAME
+	Expected []string
+
+	context string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %s", e.context)
+}
+
+// UnknownNameError is an error when an expression refers to a function or property which doesn't exist
+type UnknownNameError struct {
+	// Container is a description of what was being looked up on, e.g. context
+	Container string
+
+	// Name is the name which doesn't exist
+	Name string
+
+	// Suggestion is the closest existing name if there is one which is likely a misspelling
+	Suggestion string
+}
+
+func (e *UnknownNameError) Error() string {
+	return fmt.Sprintf("%s has no property '%s'", e.Container, e.Name)
+}
+
 // ErrorListener records syntax errors
 type ErrorListener struct {
 	*antlr.DefaultErrorListener
@@ -71,5 +142,54 @@ func (l *ErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol
 	lineOfError := lines[line-1]
 	contextOfError := lineOfError[column:utils.MinInt(column+10, len(lineOfError))]
 
-	l.errors = append(l.errors, errors.Errorf("syntax error at %s", contextOfError))
+	// column is relative to the line so add the lengths of any preceding lines
+	offset := column
+	for _, previous := range lines[:line-1] {
+		offset += utf8.RuneCountInString(previous) + 1
+	}
+
+	token := ""
+	if t, isToken := offendingSymbol.(antlr.Token); isToken && t.GetTokenType() != antlr.TokenEOF {
+		token = t.GetText()
+	}
+
+	var expected []string
+	if parser, isParser := recognizer.(antlr.Parser); isParser {
+		expected = expectedTokens(parser)
+	}
+
+	l.errors = append(l.errors, &SyntaxError{
+		Expression: l.expression,
+		Offset:     offset,
+		Token:      token,
+		Expected:   expected,
+		context:    contextOfError,
+	})
+}
+
+// gets the names of the tokens the parser would have accepted in its current state
+func expectedTokens(parser antlr.Parser) (names []string) {
+	// antlr panics describing a set which has had all its items removed
+	defer func() {
+		if recover() != nil {
+			names = nil
+		}
+	}()
+
+	described := parser.GetExpectedTokens().StringVerbose(parser.GetLiteralNames(), parser.GetSymbolicNames(), false)
+	if described == "{}" {
+		return nil
+	}
+	if strings.HasPrefix(described, "{") && strings.HasSuffix(described, "}") {
+		described = described[1 : len(described)-1]
+	}
+
+	for _, name := range strings.Split(described, ", ") {
+		// literal names are quoted, e.g. ')'
+		if len(name) > 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+			name = name[1 : len(name)-1]
+		}
+		names = append(names, name)
+	}
+	return names
 }
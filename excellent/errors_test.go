@@ -0,0 +1,92 @@
+package excellent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		template   string
+		expression string
+		offset     int
+		token      string
+		expected   []string
+	}{
+		{`@('x')`, `@('x')`, 0, `'`, []string{"(", "-", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE", "NULL", "NAME"}},
+		{`Hi @(0 / )`, `@(0 / )`, 3, ``, []string{"(", "-", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE", "NULL", "NAME"}},
+		{`@(upper("x" "y"))`, `@(upper("x" "y"))`, 0, `"y"`, []string{")"}},
+		{`é @(1.1.0)`, `@(1.1.0)`, 2, `.`, []string{"<EOF>"}},
+	}
+
+	env := envs.NewBuilder().Build()
+
+	for _, tc := range tests {
+		_, err := excellent.EvaluateTemplate(env, types.NewXObject(nil), tc.template)
+
+		var templateErr *excellent.TemplateError
+		require.True(t, errors.As(err, &templateErr), "expected template error for %s", tc.template)
+		assert.Equal(t, tc.expression, templateErr.Expression)
+		assert.Equal(t, tc.offset, templateErr.Offset)
+
+		var syntaxErr *excellent.SyntaxError
+		require.True(t, errors.As(err, &syntaxErr), "expected syntax error for %s", tc.template)
+		assert.Equal(t, tc.token, syntaxErr.Token, "token mismatch for %s", tc.template)
+		assert.Equal(t, tc.expected, syntaxErr.Expected, "expected tokens mismatch for %s", tc.template)
+	}
+
+	// offsets within the expression account for preceding lines
+	_, err := excellent.EvaluateTemplate(env, types.NewXObject(nil), "@(1 +\n 2 +\n 'x')")
+
+	var syntaxErr *excellent.SyntaxError
+	require.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 10, syntaxErr.Offset)
+	assert.Equal(t, "'", syntaxErr.Token)
+}
+
+func TestUnknownNameErrors(t *testing.T) {
+	vars := types.NewXObject(map[string]types.XValue{
+		"contact": types.NewXObject(map[string]types.XValue{
+			"name":     types.NewXText("Bob"),
+			"language": types.NewXText("eng"),
+		}),
+	})
+	env := envs.NewBuilder().Build()
+
+	tests := []struct {
+		template   string
+		errorMsg   string
+		container  string
+		name       string
+		suggestion string
+	}{
+		{`@(contct.name)`, `error evaluating @(contct.name): context has no property 'contct'`, "context", "contct", "contact"},
+		{`@(uper("x"))`, `error evaluating @(uper("x")): context has no property 'uper'`, "context", "uper", "upper"},
+		{`@(contact.nmae)`, `error evaluating @(contact.nmae): object has no property 'nmae'`, "object", "nmae", "name"},
+		{`@(xyz)`, `error evaluating @(xyz): context has no property 'xyz'`, "context", "xyz", ""},
+	}
+
+	for _, tc := range tests {
+		_, err := excellent.EvaluateTemplate(env, vars, tc.template)
+		require.Error(t, err)
+		assert.Equal(t, tc.errorMsg, err.Error())
+
+		var nameErr *excellent.UnknownNameError
+		require.True(t, errors.As(err, &nameErr), "expected unknown name error for %s", tc.template)
+		assert.Equal(t, tc.container, nameErr.Container)
+		assert.Equal(t, tc.name, nameErr.Name)
+		assert.Equal(t, tc.suggestion, nameErr.Suggestion, "suggestion mismatch for %s", tc.template)
+	}
+
+	// not every error is a syntax error
+	_, err := excellent.EvaluateTemplate(env, vars, `@(xyz)`)
+	var syntaxErr *excellent.SyntaxError
+	assert.False(t, errors.As(err, &syntaxErr))
+}
@@ -16,28 +16,7 @@ import (
 
 // EvaluateTemplate evaluates the passed in template
 func EvaluateTemplate(env envs.Environment, context *types.XObject, template string) (string, error) {
-	var buf strings.Builder
-
-	err := VisitTemplate(template, context.Properties(), func(tokenType XTokenType, token string) error {
-		switch tokenType {
-		case BODY:
-			buf.WriteString(token)
-		case IDENTIFIER, EXPRESSION:
-			value := EvaluateExpression(env, context, token)
-
-			// if we got an error, return that
-			if types.IsXError(value) {
-				return value.(error)
-			}
-
-			// if not, stringify value and append to the output
-			strValue, _ := types.ToXText(env, value)
-			buf.WriteString(strValue.Native())
-		}
-		return nil
-	})
-
-	return buf.String(), err
+	return CompileTemplate(template, context.Properties()).Evaluate(env, context)
 }
 
 // EvaluateTemplateValue is equivalent to EvaluateTemplate except in the case where the template contains
@@ -45,25 +24,8 @@ func EvaluateTemplate(env envs.Environment, context *types.XObject, template str
 // the typed value from EvaluateExpression instead of stringifying the result.
 func EvaluateTemplateValue(env envs.Environment, context *types.XObject, template string) (types.XValue, error) {
 	template = strings.TrimSpace(template)
-	scanner := NewXScanner(strings.NewReader(template), context.Properties())
-
-	// parse our first token
-	tokenType, token := scanner.Scan()
-
-	// try to scan to our next token
-	nextTT, _ := scanner.Scan()
-
-	// if we only have an identifier or an expression, evaluate it on its own
-	if nextTT == EOF {
-		switch tokenType {
-		case IDENTIFIER, EXPRESSION:
-			return EvaluateExpression(env, context, token), nil
-		}
-	}
 
-	// otherwise fallback to full template evaluation
-	asStr, err := EvaluateTemplate(env, context, template)
-	return types.NewXText(asStr), err
+	return CompileTemplate(template, context.Properties()).EvaluateValue(env, context)
 }
 
 // EvaluateExpression evalutes the passed in Excellent expression, returning the typed value it evaluates to,
@@ -78,17 +40,26 @@ func EvaluateExpression(env envs.Environment, context *types.XObject, expression
 	return toXValue(output)
 }
 
+// the maximum depth of calls to anonymous functions, which stops functions like (f) => f(f) recursing forever
+const maxFunctionCallDepth = 20
+
 // visitor which evaluates each part of an expression as a value
 type visitor struct {
 	gen.BaseExcellent2Visitor
 
 	env     envs.Environment
 	context *types.XObject
+
+	// parameters of the anonymous functions we're inside of
+	locals map[string]types.XValue
+
+	// depth of anonymous function calls, shared by all the visitors of an evaluation
+	callDepth *int
 }
 
 // creates a new visitor for evaluation
 func newEvaluationVisitor(env envs.Environment, context *types.XObject) *visitor {
-	return &visitor{env: env, context: context}
+	return &visitor{env: env, context: context, callDepth: new(int)}
 }
 
 // Visit the top level parse tree
@@ -125,15 +96,23 @@ func (v *visitor) VisitNumberLiteral(ctx *gen.NumberLiteralContext) interface{}
 func (v *visitor) VisitContextReference(ctx *gen.ContextReferenceContext) interface{} {
 	name := strings.ToLower(ctx.GetText())
 
-	// first of all try to look this up as a function
-	function := functions.Lookup(name)
+	// parameters of anonymous functions take precedence over everything else
+	if value, isLocal := v.locals[name]; isLocal {
+		return value
+	}
+
+	// then try to look this up as a function
+	function := functions.LookupIn(v.env, name)
 	if function != nil {
 		return toXValue(function)
 	}
 
 	value, exists := v.context.Get(name)
 	if !exists {
-		return types.NewXErrorf("context has no property '%s'", name)
+		// names are looked up as functions before context properties so either could have been meant
+		candidates := append(functions.NamesIn(v.env), v.context.Properties()...)
+
+		return types.NewXError(&UnknownNameError{Container: "context", Name: name, Suggestion: utils.ClosestString(name, candidates)})
 	}
 
 	return value
@@ -289,6 +268,37 @@ func (v *visitor) VisitAtomReference(ctx *gen.AtomReferenceContext) interface{}
 	return v.Visit(ctx.Atom())
 }
 
+// VisitAnonymousFunction deals with anonymous functions like (x) => x * 2 which can be passed to other functions
+func (v *visitor) VisitAnonymousFunction(ctx *gen.AnonymousFunctionContext) interface{} {
+	nameNodes := ctx.AllNAME()
+	names := make([]string, len(nameNodes))
+	for i := range nameNodes {
+		names[i] = strings.ToLower(nameNodes[i].GetText())
+	}
+
+	body := ctx.Expression()
+
+	return types.XFunction(functions.MinAndMaxArgsCheck(len(names), len(names), func(env envs.Environment, args ...types.XValue) types.XValue {
+		if *v.callDepth >= maxFunctionCallDepth {
+			return types.NewXErrorf("maximum depth of %d function calls exceeded", maxFunctionCallDepth)
+		}
+		*v.callDepth++
+		defer func() { *v.callDepth-- }()
+
+		// parameters are added to the parameters of any enclosing functions, so they can also be referenced
+		locals := make(map[string]types.XValue, len(v.locals)+len(names))
+		for name, value := range v.locals {
+			locals[name] = value
+		}
+		for i, name := range names {
+			locals[name] = args[i]
+		}
+
+		inner := &visitor{env: v.env, context: v.context, locals: locals, callDepth: v.callDepth}
+		return toXValue(inner.Visit(body))
+	}))
+}
+
 // VisitFunctionParameters deals with the parameters to a function call
 func (v *visitor) VisitFunctionParameters(ctx *gen.FunctionParametersContext) interface{} {
 	expressions := ctx.AllExpression()
@@ -347,7 +357,8 @@ func resolveLookup(env envs.Environment, container types.XValue, lookup types.XV
 
 		// [] notation doesn't error for non-existent properties, . does
 		if !exists && notation == lookupNotationDot {
-			return types.NewXErrorf("%s has no property '%s'", types.Describe(container), property.Native())
+			name := property.Native()
+			return types.NewXError(&UnknownNameError{Container: types.Describe(container), Name: name, Suggestion: utils.ClosestString(name, object.Properties())})
 		}
 
 		return value
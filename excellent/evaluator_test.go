@@ -165,6 +165,19 @@ func TestEvaluateTemplateValue(t *testing.T) {
 		{"@(split(words, \" \")[1])", xs("two")},
 		{"@(split(words, \" \")[-1])", xs("three")},
 
+		// anonymous functions
+		{"@(((x) => x * 2)(3))", xi(6)},
+		{"@((() => string1)())", xs("foo")},
+		{"@(((a, b) => a & b)(string1, string2))", xs("foobar")},
+		{"@(((x) => (y) => x + y)(1)(2))", xi(3)},     // inner functions can see outer parameters
+		{"@(((string1) => string1)(\"x\"))", xs("x")}, // parameters shadow the context
+		{"@(((title) => title)(\"x\"))", xs("x")},     // and functions
+		{"@(((x) => x)(1, 2))", ERROR},                // wrong number of arguments
+		{"@(((x) => x.foo)(array1d))", ERROR},         // errors are returned as values
+		{"@(map(array1d, (x) => upper(x) & string1))", types.NewXArray(xs("Afoo"), xs("Bfoo"), xs("Cfoo"))},
+		{"@(((x) => x)(1) + x)", ERROR},          // parameters aren't visible outside the function
+		{"@(((f) => f(f))((f) => f(f)))", ERROR}, // calls can't recurse forever
+
 		{"@string1 @string2", xs("foo bar")}, // falls back to template evaluation if necessary
 	}
 
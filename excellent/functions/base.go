@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/nyaruka/goflow/envs"
@@ -20,6 +21,16 @@ func Lookup(name string) types.XFunction {
 	return XFUNCTIONS[strings.ToLower(name)]
 }
 
+// Names returns the sorted names of all registered functions
+func Names() []string {
+	names := make([]string, 0, len(XFUNCTIONS))
+	for name := range XFUNCTIONS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Call calls the given function with the given parameters
 func Call(env envs.Environment, name string, function types.XFunction, params []types.XValue) types.XValue {
 	val := function(env, params...)
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/nyaruka/gocommon/urns"
@@ -47,7 +51,7 @@ func init() {
 		"word_slice":        InitialTextFunction(1, 3, WordSlice),
 		"field":             InitialTextFunction(2, 2, Field),
 		"clean":             OneTextFunction(Clean),
-		"text_slice":        InitialTextFunction(1, 3, TextSlice),
+		"text_slice":        InitialTextFunction(1, 2, TextSlice),
 		"lower":             OneTextFunction(Lower),
 		"regex_match":       InitialTextFunction(1, 2, RegexMatch),
 		"text_length":       OneTextFunction(TextLength),
@@ -111,7 +115,12 @@ func init() {
 		"format_time":     MinAndMaxArgsCheck(1, 2, FormatTime),
 		"format_location": OneTextFunction(FormatLocation),
 		"format_number":   MinAndMaxArgsCheck(1, 3, FormatNumber),
+		"format_currency": TwoArgFunction(FormatCurrency),
 		"format_urn":      OneTextFunction(FormatURN),
+		"format_list":     MinAndMaxArgsCheck(1, 2, FormatList),
+		"format_table":    MinAndMaxArgsCheck(1, 2, FormatTable),
+		"format_text":     MinArgsCheck(1, FormatText),
+		"number_words":    OneNumberFunction(NumberWords),
 
 		// utility functions
 		"is_error":       OneArgFunction(IsError),
@@ -123,6 +132,15 @@ func init() {
 		"extract_object": MinArgsCheck(2, ExtractObject),
 		"foreach":        MinArgsCheck(2, ForEach),
 		"foreach_value":  MinArgsCheck(2, ForEachValue),
+
+		// array functions
+		"filter":  TwoArgFunction(Filter),
+		"map":     TwoArgFunction(Map),
+		"reduce":  ThreeArgFunction(Reduce),
+		"sort_by": TwoArgFunction(SortBy),
+		"sum":     MinAndMaxArgsCheck(1, 2, Sum),
+		"any":     TwoArgFunction(Any),
+		"all":     TwoArgFunction(All),
 	}
 
 	for name, fn := range builtin {
@@ -187,16 +205,25 @@ func Number(env envs.Environment, value types.XValue) types.XValue {
 
 // Date tries to convert `value` to a date.
 //
-// If it is text then it will be parsed into a date using the default date format.
+// If it is text then it will be parsed into a date using the default date format, or as a date written
+// in words in one of the environment's languages, e.g. "tomorrow" or "15 march".
 // An error is returned if the value can't be converted.
 //
 //   @(date("1979-07-18")) -> 1979-07-18
 //   @(date("1979-07-18T10:30:45.123456Z")) -> 1979-07-18
 //   @(date("10/05/2010")) -> 2010-05-10
+//   @(date("18 July 1979")) -> 1979-07-18
 //   @(date("NOT DATE")) -> ERROR
 //
 // @function date(value)
 func Date(env envs.Environment, value types.XValue) types.XValue {
+	// text can also be a date written in words
+	if text, isText := value.(types.XText); isText {
+		if parsed, err := envs.DateFromNaturalString(env, text.Native()); err == nil {
+			return types.NewXDate(parsed)
+		}
+	}
+
 	d, err := types.ToXDate(env, value)
 	if err != nil {
 		return types.NewXError(err)
@@ -1776,6 +1803,39 @@ func FormatNumber(env envs.Environment, args ...types.XValue) types.XValue {
 	return types.NewXText(num.FormatCustom(env.NumberFormat(), places, human.Native()))
 }
 
+// FormatCurrency formats `amount` as an amount of the currency with the given ISO 4217 `code`.
+//
+// The amount is rounded to the number of decimal places used by the currency, and the currency symbol is
+// placed according to the locale of the environment, which in a flow is the language and country of the contact.
+//
+//   @(format_currency(1234.5, "USD")) -> $1,234.50
+//   @(format_currency(-12.345, "USD")) -> -$12.35
+//   @(format_currency(1234.5, "RWF")) -> RWF 1,235
+//   @(format_currency(1234.5, "XYZ")) -> ERROR
+//
+// @function format_currency(amount, code)
+func FormatCurrency(env envs.Environment, amount types.XValue, code types.XValue) types.XValue {
+	num, xerr := types.ToXNumber(env, amount)
+	if xerr != nil {
+		return xerr
+	}
+	codeText, xerr := types.ToXText(env, code)
+	if xerr != nil {
+		return xerr
+	}
+
+	currency, err := envs.ParseCurrency(codeText.Native())
+	if err != nil {
+		return types.NewXError(err)
+	}
+
+	rounded := num.Native().Round(int32(currency.Digits))
+	formatted := types.NewXNumber(rounded.Abs()).FormatCustom(env.NumberFormat(), currency.Digits, true)
+	locale := envs.LocaleOf(env)
+
+	return types.NewXText(locale.FormatCurrency(formatted, rounded.Sign() < 0, currency))
+}
+
 // FormatLocation formats the given `location` as its name.
 //
 //   @(format_location("Rwanda")) -> Rwanda
@@ -1806,6 +1866,313 @@ func FormatURN(env envs.Environment, arg types.XText) types.XValue {
 	return types.NewXText(urn.Format())
 }
 
+// FormatList formats the items of `array` as a list in the given `style`.
+//
+// The style can be one of `and` (the default), `or`, `comma`, `numbered`, `bulleted` or `lines`. The words
+// used to join the last two items of `and` and `or` lists are in the language of the environment, which in a flow
+// is the language of the contact.
+//
+//   @(format_list(array("red", "green", "blue"))) -> red, green and blue
+//   @(format_list(array("red", "green"), "or")) -> red or green
+//   @(format_list(array(1, 2000, 3.5), "comma")) -> 1, 2,000, 3.5
+//   @(format_list(array("Yes", "No"), "numbered")) -> 1. Yes\n2. No
+//   @(format_list(array("Yes", "No"), "bulleted")) -> • Yes\n• No
+//   @(format_list(array("a"), "xxx")) -> ERROR
+//
+// @function format_list(array [,style])
+func FormatList(env envs.Environment, args ...types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	style := "and"
+	if len(args) > 1 {
+		arg, xerr := types.ToXText(env, args[1])
+		if xerr != nil {
+			return xerr
+		}
+		style = strings.ToLower(arg.Native())
+	}
+
+	items := make([]string, array.Count())
+	for i := range items {
+		items[i] = types.Format(env, array.Get(i))
+	}
+
+	switch style {
+	case "and", "or":
+		if len(items) < 2 {
+			return types.NewXText(strings.Join(items, ""))
+		}
+		conjunction := listConjunctions[envs.LocaleOf(env).Language][style]
+		if conjunction == "" {
+			conjunction = listConjunctions[envs.Language("eng")][style]
+		}
+		return types.NewXText(strings.Join(items[:len(items)-1], ", ") + " " + conjunction + " " + items[len(items)-1])
+	case "comma":
+		return types.NewXText(strings.Join(items, ", "))
+	case "numbered":
+		for i := range items {
+			items[i] = fmt.Sprintf("%d. %s", i+1, items[i])
+		}
+		return types.NewXText(strings.Join(items, "\n"))
+	case "bulleted":
+		for i := range items {
+			items[i] = "• " + items[i]
+		}
+		return types.NewXText(strings.Join(items, "\n"))
+	case "lines":
+		return types.NewXText(strings.Join(items, "\n"))
+	}
+
+	return types.NewXErrorf("unknown list style '%s'", style)
+}
+
+// words used to join the last two items of a list in different languages
+var listConjunctions = map[envs.Language]map[string]string{
+	"eng": {"and": "and", "or": "or"},
+	"fra": {"and": "et", "or": "ou"},
+	"spa": {"and": "y", "or": "o"},
+	"por": {"and": "e", "or": "ou"},
+}
+
+// FormatTable formats `array` of objects as a text table with a column for each of the given `columns`.
+//
+// If `columns` is not specified then the table has a column for every property of the objects. Properties
+// missing from an object are left blank.
+//
+//   @(format_table(array(object("name", "Bob", "age", 23), object("name", "Jo", "age", 1500)))) -> age   | name\n------+-----\n23    | Bob\n1,500 | Jo
+//   @(format_table(array(object("name", "Bob", "age", 23)), array("name"))) -> name\n----\nBob
+//   @(format_table(array("Bob"))) -> ERROR
+//
+// @function format_table(array [,columns])
+func FormatTable(env envs.Environment, args ...types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	rows := make([]*types.XObject, array.Count())
+	for i := range rows {
+		if rows[i], xerr = types.ToXObject(env, array.Get(i)); xerr != nil {
+			return xerr
+		}
+	}
+
+	var columns []string
+	if len(args) > 1 {
+		columnsArg, xerr := types.ToXArray(env, args[1])
+		if xerr != nil {
+			return xerr
+		}
+		for i := 0; i < columnsArg.Count(); i++ {
+			column, xerr := types.ToXText(env, columnsArg.Get(i))
+			if xerr != nil {
+				return xerr
+			}
+			columns = append(columns, column.Native())
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			for _, property := range row.Properties() {
+				if !seen[strings.ToLower(property)] {
+					seen[strings.ToLower(property)] = true
+					columns = append(columns, property)
+				}
+			}
+		}
+	}
+
+	cells := make([][]string, len(rows)+1)
+	cells[0] = columns
+	for r, row := range rows {
+		cells[r+1] = make([]string, len(columns))
+		for c, column := range columns {
+			value, _ := row.Get(column)
+			cells[r+1][c] = strings.Replace(types.Format(env, value), "\n", " ", -1)
+		}
+	}
+
+	widths := make([]int, len(columns))
+	for _, line := range cells {
+		for c, cell := range line {
+			widths[c] = utils.MaxInt(widths[c], utf8.RuneCountInString(cell))
+		}
+	}
+
+	lines := make([]string, 0, len(cells)+1)
+	for r, line := range cells {
+		// don't pad out blank cells at the end of a row
+		for len(line) > 1 && line[len(line)-1] == "" {
+			line = line[:len(line)-1]
+		}
+
+		padded := make([]string, len(line))
+		for c, cell := range line {
+			padded[c] = cell + strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(padded, " | "), " "))
+
+		// add a line under the header
+		if r == 0 {
+			rules := make([]string, len(widths))
+			for c, width := range widths {
+				rules[c] = strings.Repeat("-", width)
+			}
+			lines = append(lines, strings.Join(rules, "-+-"))
+		}
+	}
+
+	return types.NewXText(strings.Join(lines, "\n"))
+}
+
+// FormatText formats `pattern` by replacing each specifier with the next of the given `values`.
+//
+// The specifiers which can be used are:
+//
+// * `%s`        - the value formatted as text according to its type
+// * `%d`        - the value as a whole number with digit grouping
+// * `%f`        - the value as a number with digit grouping, e.g. `%.2f` for two decimal places
+// * `%D`        - the value as a date in the environment's date format
+// * `%T`        - the value as a time in the environment's time format
+// * `%%`        - a literal %
+//
+// Numbers use the decimal and digit grouping symbols of the environment.
+//
+//   @(format_text("%s has %d points", "Bob", 1234.6)) -> Bob has 1,235 points
+//   @(format_text("Total: %.2f", 1234.5)) -> Total: 1,234.50
+//   @(format_text("Due on %D at %T", datetime("2019-03-18T15:30:00Z"), datetime("2019-03-18T15:30:00Z"))) -> Due on 18-03-2019 at 10:30
+//   @(format_text("100%%")) -> 100%
+//   @(format_text("%d", "abc")) -> ERROR
+//   @(format_text("%s and %s", "a")) -> ERROR
+//   @(format_text("%x", "a")) -> ERROR
+//
+// @function format_text(pattern, values...)
+func FormatText(env envs.Environment, args ...types.XValue) types.XValue {
+	pattern, xerr := types.ToXText(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	values := args[1:]
+	used := 0
+	var buf strings.Builder
+
+	runes := []rune(pattern.Native())
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			buf.WriteRune(runes[i])
+			continue
+		}
+
+		// read the optional precision and the verb
+		j := i + 1
+		places := -1
+		if j < len(runes) && runes[j] == '.' {
+			k := j + 1
+			for k < len(runes) && unicode.IsDigit(runes[k]) {
+				k++
+			}
+			if k == j+1 {
+				return types.NewXErrorf("invalid precision in specifier '%s'", string(runes[i:utils.MinInt(k+1, len(runes))]))
+			}
+			places, _ = strconv.Atoi(string(runes[j+1 : k]))
+			j = k
+		}
+		if j >= len(runes) {
+			return types.NewXErrorf("incomplete specifier at end of pattern")
+		}
+		verb := runes[j]
+		specifier := string(runes[i : j+1])
+		i = j
+
+		if verb == '%' {
+			buf.WriteRune('%')
+			continue
+		}
+		if places >= 0 && verb != 'f' {
+			return types.NewXErrorf("specifier '%s' doesn't support a precision", specifier)
+		}
+		if used >= len(values) {
+			return types.NewXErrorf("not enough values for specifier '%s'", specifier)
+		}
+		value := values[used]
+		used++
+
+		switch verb {
+		case 's':
+			if types.IsXError(value) {
+				return value
+			}
+			buf.WriteString(types.Format(env, value))
+		case 'd', 'f':
+			num, xerr := types.ToXNumber(env, value)
+			if xerr != nil {
+				return xerr
+			}
+			if verb == 'd' {
+				places = 0
+			} else if places > 9 {
+				return types.NewXErrorf("specifier '%s' must have 0-9 decimal places", specifier)
+			}
+			buf.WriteString(num.FormatCustom(env.NumberFormat(), places, true))
+		case 'D':
+			date, xerr := types.ToXDate(env, value)
+			if xerr != nil {
+				return xerr
+			}
+			buf.WriteString(date.Format(env))
+		case 'T':
+			// like dates, times of datetimes are in the environment's timezone
+			if datetime, isDateTime := value.(types.XDateTime); isDateTime {
+				value = datetime.In(env.Timezone())
+			}
+			t, xerr := types.ToXTime(env, value)
+			if xerr != nil {
+				return xerr
+			}
+			buf.WriteString(t.Format(env))
+		default:
+			return types.NewXErrorf("unknown specifier '%s'", specifier)
+		}
+	}
+
+	if used < len(values) {
+		return types.NewXErrorf("too many values for pattern, expected %d but got %d", used, len(values))
+	}
+
+	return types.NewXText(buf.String())
+}
+
+// NumberWords spells out the whole number `number` in words.
+//
+// The words are in the language of the environment, which in a flow is the language of the contact, if that is
+// English, French, Spanish or Portuguese, and otherwise in English.
+//
+//   @(number_words(123)) -> one hundred twenty-three
+//   @(number_words(-2001)) -> minus two thousand one
+//   @(number_words(1.5)) -> ERROR
+//
+// @function number_words(number)
+func NumberWords(env envs.Environment, number types.XNumber) types.XValue {
+	if !number.Native().Equal(number.Native().Truncate(0)) {
+		return types.NewXErrorf("can only spell out whole numbers, got %s", number.Render())
+	}
+
+	if number.Native().Abs().GreaterThan(decimal.New(envs.MaxSpelledNumber, 0)) {
+		return types.NewXErrorf("can't spell out numbers larger than %d", int64(envs.MaxSpelledNumber))
+	}
+
+	spelled, err := envs.SpellNumber(envs.LocaleOf(env).Language, number.Native().IntPart())
+	if err != nil {
+		return types.NewXError(err)
+	}
+
+	return types.NewXText(spelled)
+}
+
 //------------------------------------------------------------------------------------------
 // Utility Functions
 //------------------------------------------------------------------------------------------
@@ -2088,3 +2455,290 @@ func ReadChars(env envs.Environment, val types.XText) types.XValue {
 
 	return types.NewXText(output.String())
 }
+
+//------------------------------------------------------------------------------------------
+// Array Functions
+//------------------------------------------------------------------------------------------
+
+// Filter returns a new array with the items of `array` for which `func` returns a truthy value.
+//
+//   @(filter(array(1, 2, 3, 4), (x) => x > 2)) -> [3, 4]
+//   @(filter(array("a", "", "b"), (x) => x)) -> [a, b]
+//   @(filter(array(1, 2, 3), (x) => 1 / 0)) -> ERROR
+//
+// @function filter(array, func)
+func Filter(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	result := make([]types.XValue, 0, array.Count())
+
+	for i := 0; i < array.Count(); i++ {
+		item := array.Get(i)
+
+		keep := Call(env, function.Describe(), function, []types.XValue{item})
+		if types.IsXError(keep) {
+			return keep
+		}
+		if types.Truthy(keep) {
+			result = append(result, item)
+		}
+	}
+
+	return types.NewXArray(result...)
+}
+
+// Map returns a new array by applying `func` to each item of `array`.
+//
+//   @(map(array(1, 2, 3), (x) => x * 2)) -> [2, 4, 6]
+//   @(map(array("a", "b"), upper)) -> [A, B]
+//   @(map(array(object("price", 5), object("price", 3)), (x) => x.price)) -> [5, 3]
+//   @(map("abc", upper)) -> ERROR
+//
+// @function map(array, func)
+func Map(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	result := make([]types.XValue, array.Count())
+
+	for i := 0; i < array.Count(); i++ {
+		newItem := Call(env, function.Describe(), function, []types.XValue{array.Get(i)})
+		if types.IsXError(newItem) {
+			return newItem
+		}
+		result[i] = newItem
+	}
+
+	return types.NewXArray(result...)
+}
+
+// Reduce combines the items of `array` into a single value by calling `func` with the result so far and each
+// item in turn, starting with `initial`.
+//
+//   @(reduce(array(1, 2, 3), (total, x) => total + x, 0)) -> 6
+//   @(reduce(array("a", "b", "c"), (s, x) => s & x, ">")) -> >abc
+//   @(reduce(array(), (total, x) => total + x, 10)) -> 10
+//   @(reduce(array(1, 2), (x) => x, 0)) -> ERROR
+//
+// @function reduce(array, func, initial)
+func Reduce(env envs.Environment, arg1 types.XValue, arg2 types.XValue, initial types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	result := initial
+
+	for i := 0; i < array.Count(); i++ {
+		result = Call(env, function.Describe(), function, []types.XValue{result, array.Get(i)})
+		if types.IsXError(result) {
+			return result
+		}
+	}
+
+	return result
+}
+
+// SortBy returns a new array with the items of `array` sorted by the key returned by `func` for each item.
+//
+// Keys are compared as numbers if they can all be converted to numbers, as dates, datetimes or times if they
+// are all of that type, and otherwise as text. Items with equal keys keep their original order.
+//
+//   @(sort_by(array(3, 1, 2), (x) => x)) -> [1, 2, 3]
+//   @(sort_by(array("10", "9", "100"), (x) => x)) -> [9, 10, 100]
+//   @(sort_by(array("bob", "Al", "cy"), (x) => lower(x))) -> [Al, bob, cy]
+//   @(sort_by(array(object("n", 2), object("n", 1)), (x) => -x.n)) -> [{n: 2}, {n: 1}]
+//
+// @function sort_by(array, func)
+func SortBy(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	items := make([]types.XValue, array.Count())
+	keys := make([]types.XValue, array.Count())
+
+	for i := 0; i < array.Count(); i++ {
+		items[i] = array.Get(i)
+		keys[i] = Call(env, function.Describe(), function, []types.XValue{items[i]})
+		if types.IsXError(keys[i]) {
+			return keys[i]
+		}
+	}
+
+	compare := sortKeyComparer(env, keys)
+
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return compare(indexes[i], indexes[j]) < 0 })
+
+	sorted := make([]types.XValue, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+
+	return types.NewXArray(sorted...)
+}
+
+// Sum returns the sum of the items in `array`, or of the results of applying `func` to each item if it is given.
+//
+//   @(sum(array(1, 2, 3))) -> 6
+//   @(sum(array(object("price", 5), object("price", 3)), (x) => x.price)) -> 8
+//   @(sum(array())) -> 0
+//   @(sum(array(1, "x"))) -> ERROR
+//
+// @function sum(array, [func])
+func Sum(env envs.Environment, args ...types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	var function types.XFunction
+	if len(args) > 1 {
+		var isFunction bool
+		if function, isFunction = args[1].(types.XFunction); !isFunction {
+			return types.NewXErrorf("requires a function as its second argument")
+		}
+	}
+
+	total := types.XNumberZero
+
+	for i := 0; i < array.Count(); i++ {
+		item := array.Get(i)
+
+		if function != nil {
+			item = Call(env, function.Describe(), function, []types.XValue{item})
+			if types.IsXError(item) {
+				return item
+			}
+		}
+
+		num, xerr := types.ToXNumber(env, item)
+		if xerr != nil {
+			return xerr
+		}
+		total = types.NewXNumber(total.Native().Add(num.Native()))
+	}
+
+	return total
+}
+
+// Any returns whether `func` returns a truthy value for any of the items in `array`.
+//
+//   @(any(array(1, 2, 3), (x) => x > 2)) -> true
+//   @(any(array(1, 2, 3), (x) => x > 3)) -> false
+//   @(any(array(), (x) => true)) -> false
+//
+// @function any(array, func)
+func Any(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	for i := 0; i < array.Count(); i++ {
+		result := Call(env, function.Describe(), function, []types.XValue{array.Get(i)})
+		if types.IsXError(result) {
+			return result
+		}
+		if types.Truthy(result) {
+			return types.XBooleanTrue
+		}
+	}
+
+	return types.XBooleanFalse
+}
+
+// All returns whether `func` returns a truthy value for all of the items in `array`.
+//
+//   @(all(array(1, 2, 3), (x) => x > 0)) -> true
+//   @(all(array(1, 2, 3), (x) => x > 1)) -> false
+//   @(all(array(), (x) => false)) -> true
+//
+// @function all(array, func)
+func All(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, function, xerr := arrayAndFunction(env, arg1, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	for i := 0; i < array.Count(); i++ {
+		result := Call(env, function.Describe(), function, []types.XValue{array.Get(i)})
+		if types.IsXError(result) {
+			return result
+		}
+		if !types.Truthy(result) {
+			return types.XBooleanFalse
+		}
+	}
+
+	return types.XBooleanTrue
+}
+
+// converts the arguments of a function which takes an array and a function to apply to its items
+func arrayAndFunction(env envs.Environment, arg1 types.XValue, arg2 types.XValue) (*types.XArray, types.XFunction, types.XError) {
+	array, xerr := types.ToXArray(env, arg1)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	function, isFunction := arg2.(types.XFunction)
+	if !isFunction {
+		return nil, nil, types.NewXErrorf("requires a function as its second argument")
+	}
+
+	return array, function, nil
+}
+
+// returns a function which compares the given sort keys by index, using the most specific type they all share
+func sortKeyComparer(env envs.Environment, keys []types.XValue) func(i, j int) int {
+	numbers := make([]types.XNumber, len(keys))
+	allNumbers := true
+	for i, key := range keys {
+		num, xerr := types.ToXNumber(env, key)
+		if xerr != nil {
+			allNumbers = false
+			break
+		}
+		numbers[i] = num
+	}
+	if allNumbers {
+		return func(i, j int) int { return numbers[i].Compare(numbers[j]) }
+	}
+
+	if len(keys) > 0 && sameTypes(keys) {
+		switch keys[0].(type) {
+		case types.XDateTime:
+			return func(i, j int) int { return keys[i].(types.XDateTime).Compare(keys[j].(types.XDateTime)) }
+		case types.XDate:
+			return func(i, j int) int { return keys[i].(types.XDate).Compare(keys[j].(types.XDate)) }
+		case types.XTime:
+			return func(i, j int) int { return keys[i].(types.XTime).Compare(keys[j].(types.XTime)) }
+		}
+	}
+
+	texts := make([]types.XText, len(keys))
+	for i, key := range keys {
+		texts[i], _ = types.ToXText(env, key)
+	}
+	return func(i, j int) int { return texts[i].Compare(texts[j]) }
+}
+
+// checks whether the given values are all of the same type
+func sameTypes(values []types.XValue) bool {
+	for _, value := range values[1:] {
+		if reflect.TypeOf(value) != reflect.TypeOf(values[0]) {
+			return false
+		}
+	}
+	return true
+}
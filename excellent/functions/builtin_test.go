@@ -37,6 +37,12 @@ func TestFunctions(t *testing.T) {
 		WithTimeFormat(envs.TimeFormatHourMinuteAmPm).
 		WithTimezone(la).
 		Build()
+	fra := envs.NewBuilder().
+		WithDefaultLanguage(envs.Language("fra")).
+		WithNumberFormat(&envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: "."}).
+		Build()
+	bob := types.NewXObject(map[string]types.XValue{"name": xs("Bob"), "age": xi(23)})
+	jo := types.NewXObject(map[string]types.XValue{"name": xs("Jo"), "city": xs("Kigali")})
 
 	var funcTests = []struct {
 		name     string
@@ -52,12 +58,24 @@ func TestFunctions(t *testing.T) {
 		{"abs", dmy, []types.XValue{ERROR}, ERROR},
 		{"abs", dmy, []types.XValue{}, ERROR},
 
+		{"all", dmy, []types.XValue{xa(xs("a"), xs("b")), xf("text")}, types.XBooleanTrue},
+		{"all", dmy, []types.XValue{xa(xs("a"), xs("")), xf("text")}, types.XBooleanFalse},
+		{"all", dmy, []types.XValue{xa(), xf("text")}, types.XBooleanTrue},
+		{"all", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"all", dmy, []types.XValue{ERROR, xf("text")}, ERROR},
+
 		{"and", dmy, []types.XValue{types.XBooleanTrue}, types.XBooleanTrue},
 		{"and", dmy, []types.XValue{types.XBooleanFalse}, types.XBooleanFalse},
 		{"and", dmy, []types.XValue{types.XBooleanTrue, types.XBooleanFalse}, types.XBooleanFalse},
 		{"and", dmy, []types.XValue{ERROR}, ERROR},
 		{"and", dmy, []types.XValue{}, ERROR},
 
+		{"any", dmy, []types.XValue{xa(xs(""), xs("b")), xf("text")}, types.XBooleanTrue},
+		{"any", dmy, []types.XValue{xa(xs(""), xs("")), xf("text")}, types.XBooleanFalse},
+		{"any", dmy, []types.XValue{xa(), xf("text")}, types.XBooleanFalse},
+		{"any", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"any", dmy, []types.XValue{xa(xs("a")), xs("text")}, ERROR},
+
 		{"array", dmy, []types.XValue{}, xa()},
 		{"array", dmy, []types.XValue{xi(123), xs("abc")}, xa(xi(123), xs("abc"))},
 		{"array", dmy, []types.XValue{xi(123), ERROR, xs("abc")}, ERROR},
@@ -115,8 +133,12 @@ func TestFunctions(t *testing.T) {
 		{"date", dmy, []types.XValue{xs("01-12-2017")}, xd(dates.NewDate(2017, 12, 1))},
 		{"date", mdy, []types.XValue{xs("12-01-2017")}, xd(dates.NewDate(2017, 12, 1))},
 		{"date", dmy, []types.XValue{xs("01-12-2017 10:15pm")}, xd(dates.NewDate(2017, 12, 1))},
-		{"date", dmy, []types.XValue{xs("01.15.2017")}, ERROR}, // month out of range
-		{"date", dmy, []types.XValue{xs("no date")}, ERROR},    // invalid date
+		{"date", dmy, []types.XValue{xs("tomorrow")}, xd(dates.NewDate(2018, 4, 12))},
+		{"date", dmy, []types.XValue{xs("March 15, 2017")}, xd(dates.NewDate(2017, 3, 15))},
+		{"date", fra, []types.XValue{xs("le 1er mai 2019")}, xd(dates.NewDate(2019, 5, 1))},
+		{"date", dmy, []types.XValue{xs("le 1er mai 2019")}, ERROR}, // French not enabled
+		{"date", dmy, []types.XValue{xs("01.15.2017")}, ERROR},      // month out of range
+		{"date", dmy, []types.XValue{xs("no date")}, ERROR},         // invalid date
 		{"date", dmy, []types.XValue{}, ERROR},
 
 		{"date_from_parts", dmy, []types.XValue{xi(2018), xi(11), xi(3)}, xd(dates.NewDate(2018, 11, 3))},
@@ -250,6 +272,12 @@ func TestFunctions(t *testing.T) {
 		{"field", dmy, []types.XValue{xs("hello"), xs("1"), ERROR}, ERROR},
 		{"field", dmy, []types.XValue{}, ERROR},
 
+		{"filter", dmy, []types.XValue{xa(xs("a"), xs(""), xs("b")), xf("text")}, xa(xs("a"), xs("b"))},
+		{"filter", dmy, []types.XValue{xa(), xf("text")}, xa()},
+		{"filter", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"filter", dmy, []types.XValue{ERROR, xf("text")}, ERROR},
+		{"filter", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+
 		{"foreach", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c")), xf("upper")}, xa(xs("A"), xs("B"), xs("C"))},
 		{"foreach", dmy, []types.XValue{xa(xs("the man"), xs("fox"), xs("jumped up")), xf("word"), xi(0)}, xa(xs("the"), xs("fox"), xs("jumped"))},
 		{"foreach", dmy, []types.XValue{ERROR, xf("upper")}, ERROR},
@@ -321,6 +349,59 @@ func TestFunctions(t *testing.T) {
 		{"format_number", dmy, []types.XValue{ERROR}, ERROR},
 		{"format_number", dmy, []types.XValue{}, ERROR},
 
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), xs("USD")}, xs("US$1,234.50")},
+		{"format_currency", dmy, []types.XValue{xn("-0.126"), xs("usd")}, xs("-US$0.13")},
+		{"format_currency", dmy, []types.XValue{xn("-0.001"), xs("USD")}, xs("US$0.00")},
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), xs("RWF")}, xs("RWF 1,235")},
+		{"format_currency", fra, []types.XValue{xn("1234.5"), xs("EUR")}, xs("1.234,50 €")},
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), xs("XYZ")}, ERROR},
+		{"format_currency", dmy, []types.XValue{xs("abc"), xs("USD")}, ERROR},
+		{"format_currency", dmy, []types.XValue{xn("1"), ERROR}, ERROR},
+		{"format_currency", dmy, []types.XValue{}, ERROR},
+
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"))}, xs("a, b and c")},
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xs("b")), xs("OR")}, xs("a or b")},
+		{"format_list", dmy, []types.XValue{xa(xs("a")), xs("and")}, xs("a")},
+		{"format_list", dmy, []types.XValue{xa(), xs("and")}, xs("")},
+		{"format_list", fra, []types.XValue{xa(xn("1.5"), xi(2000), xs("c"))}, xs("1,5, 2.000 et c")},
+		{"format_list", fra, []types.XValue{xa(xs("a"), xs("b")), xs("or")}, xs("a ou b")},
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xi(1234)), xs("comma")}, xs("a, 1,234")},
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xs("b")), xs("numbered")}, xs("1. a\n2. b")},
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xs("b")), xs("bulleted")}, xs("• a\n• b")},
+		{"format_list", dmy, []types.XValue{xa(xs("a"), xs("b")), xs("lines")}, xs("a\nb")},
+		{"format_list", dmy, []types.XValue{xa(xs("a")), xs("xxx")}, ERROR},
+		{"format_list", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+		{"format_list", dmy, []types.XValue{ERROR}, ERROR},
+		{"format_list", dmy, []types.XValue{}, ERROR},
+
+		{"format_table", dmy, []types.XValue{xa(bob, jo)}, xs("age | name | city\n----+------+-------\n23  | Bob\n    | Jo   | Kigali")},
+		{"format_table", dmy, []types.XValue{xa(bob, jo), xa(xs("NAME"), xs("age"))}, xs("NAME | age\n-----+----\nBob  | 23\nJo")},
+		{"format_table", dmy, []types.XValue{xa(), xa(xs("name"))}, xs("name\n----")},
+		{"format_table", dmy, []types.XValue{xa(bob, xs("Jo"))}, ERROR},
+		{"format_table", dmy, []types.XValue{xa(bob), ERROR}, ERROR},
+		{"format_table", dmy, []types.XValue{ERROR}, ERROR},
+		{"format_table", dmy, []types.XValue{}, ERROR},
+
+		{"format_text", dmy, []types.XValue{xs("%s is %d")}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%s is %d"), xs("Bob"), xn("1234.5")}, xs("Bob is 1,235")},
+		{"format_text", fra, []types.XValue{xs("%s is %d"), xs("Bob"), xn("1234.5")}, xs("Bob is 1.235")},
+		{"format_text", fra, []types.XValue{xs("%.2f|%f|%.0f"), xn("1234.5"), xn("1234.5"), xn("1.5")}, xs("1.234,50|1.234,5|2")},
+		{"format_text", dmy, []types.XValue{xs("%D %T"), xs("2019-03-18T15:30:00Z"), xdt(time.Date(2019, 3, 18, 15, 30, 0, 0, time.UTC))}, xs("18-03-2019 15:30")},
+		{"format_text", mdy, []types.XValue{xs("%D %T"), xd(dates.NewDate(2019, 3, 18)), xt(dates.NewTimeOfDay(15, 30, 0, 0))}, xs("03-18-2019 3:30 pm")},
+		{"format_text", dmy, []types.XValue{xs("100%% %s"), xs("done")}, xs("100% done")},
+		{"format_text", dmy, []types.XValue{xs("%s"), ERROR}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%d"), xs("abc")}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%D"), xs("abc")}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%T"), xs("abc")}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%.12f"), xi(1)}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%.2d"), xi(1)}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%.f"), xi(1)}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%x"), xi(1)}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("50%")}, ERROR},
+		{"format_text", dmy, []types.XValue{xs("%s"), xs("a"), xs("b")}, ERROR},
+		{"format_text", dmy, []types.XValue{ERROR}, ERROR},
+		{"format_text", dmy, []types.XValue{}, ERROR},
+
 		{"format_urn", dmy, []types.XValue{xs("tel:+14132378053")}, xs("(413) 237-8053")},
 		{"format_urn", dmy, []types.XValue{xs("tel:+250781234567")}, xs("0781 234 567")},
 		{"format_urn", dmy, []types.XValue{xs("twitter:134252511151#billy_bob")}, xs("billy_bob")},
@@ -377,6 +458,12 @@ func TestFunctions(t *testing.T) {
 		{"lower", dmy, []types.XValue{xs("😁")}, xs("😁")},
 		{"lower", dmy, []types.XValue{}, ERROR},
 
+		{"map", dmy, []types.XValue{xa(xs("a"), xs("b")), xf("upper")}, xa(xs("A"), xs("B"))},
+		{"map", dmy, []types.XValue{xa(), xf("upper")}, xa()},
+		{"map", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"map", dmy, []types.XValue{ERROR, xf("upper")}, ERROR},
+		{"map", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+
 		{"max", dmy, []types.XValue{xs("10.5"), xs("11")}, xi(11)},
 		{"max", dmy, []types.XValue{xs("10.2"), xs("9")}, xn("10.2")},
 		{"max", dmy, []types.XValue{xs("not_num"), xs("9")}, ERROR},
@@ -408,6 +495,14 @@ func TestFunctions(t *testing.T) {
 		{"number", dmy, []types.XValue{xs("123.45000")}, xn("123.45")},
 		{"number", dmy, []types.XValue{xs("what?")}, ERROR},
 
+		{"number_words", dmy, []types.XValue{xi(21)}, xs("twenty-one")},
+		{"number_words", dmy, []types.XValue{xn("-1000.00")}, xs("minus one thousand")},
+		{"number_words", fra, []types.XValue{xi(21)}, xs("vingt et un")},
+		{"number_words", dmy, []types.XValue{xn("1.5")}, ERROR},
+		{"number_words", dmy, []types.XValue{xn("1000000000000000")}, ERROR},
+		{"number_words", dmy, []types.XValue{xs("abc")}, ERROR},
+		{"number_words", dmy, []types.XValue{}, ERROR},
+
 		{"object", dmy, []types.XValue{xs("foo"), xs("hello"), xs("bar"), xi(123)}, types.NewXObject(map[string]types.XValue{"foo": xs("hello"), "bar": xi(123)})},
 		{"object", dmy, []types.XValue{xi(0), xs("hello")}, types.NewXObject(map[string]types.XValue{"0": xs("hello")})},
 		{"object", dmy, []types.XValue{ERROR, xs("hello")}, ERROR},
@@ -462,6 +557,11 @@ func TestFunctions(t *testing.T) {
 		{"read_chars", dmy, []types.XValue{xs("12")}, xs("1 , 2")},
 		{"read_chars", dmy, []types.XValue{}, ERROR},
 
+		{"reduce", dmy, []types.XValue{xa(xi(1), xi(5), xi(3)), xf("max"), xi(0)}, xi(5)},
+		{"reduce", dmy, []types.XValue{xa(), xf("max"), xi(7)}, xi(7)},
+		{"reduce", dmy, []types.XValue{xa(xs("a")), xf("max"), xi(0)}, ERROR},
+		{"reduce", dmy, []types.XValue{ERROR, xf("max"), xi(0)}, ERROR},
+
 		{"regex_match", dmy, []types.XValue{xs("zAbc"), xs(`a\w`)}, xs(`Ab`)},
 		{"regex_match", dmy, []types.XValue{xs("<html>"), xs(`<(\w+)>`), xn("1")}, xs(`html`)},
 		{"regex_match", dmy, []types.XValue{xs("<html>"), xs(`<(\w+)>`), xn("2")}, ERROR}, // invalid group
@@ -543,6 +643,14 @@ func TestFunctions(t *testing.T) {
 		{"round_up", dmy, []types.XValue{xs("not_num")}, ERROR},
 		{"round_up", dmy, []types.XValue{}, ERROR},
 
+		{"sort_by", dmy, []types.XValue{xa(xi(-3), xi(1), xi(-2)), xf("abs")}, xa(xi(1), xi(-2), xi(-3))},
+		{"sort_by", dmy, []types.XValue{xa(xs("10"), xs("9"), xs("100")), xf("text")}, xa(xs("9"), xs("10"), xs("100"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("10"), xs("a")), xf("text")}, xa(xs("10"), xs("a"), xs("b"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("2019-02-01"), xs("2018-12-31")), xf("date")}, xa(xs("2018-12-31"), xs("2019-02-01"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("B"), xs("a"), xs("b")), xf("lower")}, xa(xs("a"), xs("B"), xs("b"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"sort_by", dmy, []types.XValue{ERROR, xf("abs")}, ERROR},
+
 		{"split", dmy, []types.XValue{xs("1,2,3"), xs(",")}, xa(xs("1"), xs("2"), xs("3"))},
 		{"split", dmy, []types.XValue{xs("1,2,3"), xs(".")}, xa(xs("1,2,3"))},
 		{"split", dmy, []types.XValue{xs("1,2,3"), nil}, xa(xs("1,2,3"))},
@@ -550,6 +658,13 @@ func TestFunctions(t *testing.T) {
 		{"split", dmy, []types.XValue{xs("1,2,3"), ERROR}, ERROR},
 		{"split", dmy, []types.XValue{}, ERROR},
 
+		{"sum", dmy, []types.XValue{xa(xi(1), xn("2.5"))}, xn("3.5")},
+		{"sum", dmy, []types.XValue{xa(xi(-1), xi(-2)), xf("abs")}, xi(3)},
+		{"sum", dmy, []types.XValue{xa()}, xi(0)},
+		{"sum", dmy, []types.XValue{xa(xi(1), xs("x"))}, ERROR},
+		{"sum", dmy, []types.XValue{xa(xi(1)), xs("x")}, ERROR},
+		{"sum", dmy, []types.XValue{ERROR}, ERROR},
+
 		{"text", dmy, []types.XValue{xs("abc")}, xs("abc")},
 		{"text", dmy, []types.XValue{xi(123)}, xs("123")},
 		{"text", dmy, []types.XValue{ERROR}, ERROR},
@@ -0,0 +1,105 @@
+package functions
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// Registry is a set of functions which are available in addition to the builtin functions, e.g. functions
+// registered on a particular engine. Functions in a registry take precedence over builtins with the same name.
+type Registry struct {
+	functions map[string]types.XFunction
+}
+
+// NewRegistry creates a new empty registry
+func NewRegistry() *Registry {
+	return &Registry{functions: make(map[string]types.XFunction)}
+}
+
+// Register registers a new function in this registry
+func (r *Registry) Register(name string, function types.XFunction) {
+	r.functions[strings.ToLower(name)] = function
+}
+
+// Lookup returns the function in this registry with the given name (case-insensitive) or nil
+func (r *Registry) Lookup(name string) types.XFunction {
+	return r.functions[strings.ToLower(name)]
+}
+
+// Names returns the sorted names of the functions in this registry
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.functions))
+	for name := range r.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ScopedEnvironment is implemented by environments which carry their own registry of functions
+type ScopedEnvironment interface {
+	envs.Environment
+
+	XFunctions() *Registry
+}
+
+// NewScopedEnvironment wraps the given environment so that it carries the given registry of functions
+func NewScopedEnvironment(env envs.Environment, registry *Registry) ScopedEnvironment {
+	return &scopedEnvironment{Environment: env, registry: registry}
+}
+
+type scopedEnvironment struct {
+	envs.Environment
+
+	registry *Registry
+}
+
+func (e *scopedEnvironment) XFunctions() *Registry { return e.registry }
+
+// registryOf returns the registry carried by the given environment, or nil
+func registryOf(env envs.Environment) *Registry {
+	if scoped, isScoped := env.(ScopedEnvironment); isScoped {
+		return scoped.XFunctions()
+	}
+	return nil
+}
+
+// LookupIn returns the function with the given name (case-insensitive) from the registry carried by the
+// environment if it has one, falling back to the builtin functions
+func LookupIn(env envs.Environment, name string) types.XFunction {
+	if registry := registryOf(env); registry != nil {
+		if function := registry.Lookup(name); function != nil {
+			return function
+		}
+	}
+	return Lookup(name)
+}
+
+// LookupSignatureIn returns the signature of the function with the given name (case-insensitive) as it's available
+// in the given environment, or nil if it has no signature or a function in the environment's registry replaces it
+func LookupSignatureIn(env envs.Environment, name string) *Signature {
+	if registry := registryOf(env); registry != nil && registry.Lookup(name) != nil {
+		return nil
+	}
+	return LookupSignature(name)
+}
+
+// NamesIn returns the sorted names of all functions available in the given environment
+func NamesIn(env envs.Environment) []string {
+	registry := registryOf(env)
+	if registry == nil {
+		return Names()
+	}
+
+	names := Names()
+	for _, name := range registry.Names() {
+		if Lookup(name) == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	double := func(env envs.Environment, args ...types.XValue) types.XValue { return args[0] }
+	upper := func(env envs.Environment, args ...types.XValue) types.XValue { return types.NewXText("UPPER") }
+
+	registry := functions.NewRegistry()
+	registry.Register("DOUBLE", double)
+	registry.Register("upper", upper)
+
+	assert.NotNil(t, registry.Lookup("double"))
+	assert.NotNil(t, registry.Lookup("Upper"))
+	assert.Nil(t, registry.Lookup("lower"))
+	assert.Equal(t, []string{"double", "upper"}, registry.Names())
+
+	env := envs.NewBuilder().Build()
+	scoped := functions.NewScopedEnvironment(env, registry)
+
+	// environments without a registry only have builtins
+	assert.Nil(t, functions.LookupIn(env, "double"))
+	assert.NotNil(t, functions.LookupIn(env, "lower"))
+	assert.Equal(t, functions.Names(), functions.NamesIn(env))
+
+	// environments with a registry look there first
+	assert.NotNil(t, functions.LookupIn(scoped, "double"))
+	assert.Equal(t, types.NewXText("UPPER"), functions.LookupIn(scoped, "upper")(scoped, types.NewXText("x")))
+	assert.NotNil(t, functions.LookupIn(scoped, "lower"))
+	assert.Equal(t, len(functions.Names())+1, len(functions.NamesIn(scoped)))
+	assert.Contains(t, functions.NamesIn(scoped), "double")
+
+	// scoped environments are still environments
+	assert.Equal(t, env.DateFormat(), scoped.DateFormat())
+}
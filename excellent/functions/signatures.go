@@ -0,0 +1,268 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Type is the static type of a value as described in a function signature
+type Type string
+
+// possible static types
+const (
+	TypeAny      Type = "any"
+	TypeText     Type = "text"
+	TypeNumber   Type = "number"
+	TypeBoolean  Type = "boolean"
+	TypeDate     Type = "date"
+	TypeDateTime Type = "datetime"
+	TypeTime     Type = "time"
+	TypeArray    Type = "array"
+	TypeObject   Type = "object"
+	TypeFunction Type = "function"
+)
+
+var validTypes = map[Type]bool{
+	TypeAny: true, TypeText: true, TypeNumber: true, TypeBoolean: true, TypeDate: true,
+	TypeDateTime: true, TypeTime: true, TypeArray: true, TypeObject: true, TypeFunction: true,
+}
+
+// Signature describes the parameters and return type of a function
+type Signature struct {
+	Params   []Type
+	Optional []Type
+	Variadic Type
+	Returns  Type
+}
+
+// MinArgs returns the minimum number of arguments the function takes
+func (s *Signature) MinArgs() int { return len(s.Params) }
+
+// MaxArgs returns the maximum number of arguments the function takes, or -1 if there is no maximum
+func (s *Signature) MaxArgs() int {
+	if s.Variadic != "" {
+		return -1
+	}
+	return len(s.Params) + len(s.Optional)
+}
+
+// ParamType returns the type of the parameter at the given index
+func (s *Signature) ParamType(index int) Type {
+	if index < len(s.Params) {
+		return s.Params[index]
+	}
+	if index < len(s.Params)+len(s.Optional) {
+		return s.Optional[index-len(s.Params)]
+	}
+	return s.Variadic
+}
+
+// String returns the signature in the same format it's parsed from, e.g. (text, number?) text
+func (s *Signature) String() string {
+	params := make([]string, 0, len(s.Params)+len(s.Optional)+1)
+	for _, p := range s.Params {
+		params = append(params, string(p))
+	}
+	for _, p := range s.Optional {
+		params = append(params, string(p)+"?")
+	}
+	if s.Variadic != "" {
+		params = append(params, string(s.Variadic)+"...")
+	}
+	return fmt.Sprintf("(%s) %s", strings.Join(params, ", "), s.Returns)
+}
+
+// ParseSignature parses a signature like (text, number, text?) text where a ? suffix marks a parameter as
+// optional and a ... suffix allows any number of arguments of that type
+func ParseSignature(s string) (*Signature, error) {
+	s = strings.TrimSpace(s)
+	closing := strings.Index(s, ")")
+	if !strings.HasPrefix(s, "(") || closing < 0 {
+		return nil, errors.Errorf("signature '%s' must start with a parameter list", s)
+	}
+
+	sig := &Signature{Returns: Type(strings.TrimSpace(s[closing+1:]))}
+	if !validTypes[sig.Returns] {
+		return nil, errors.Errorf("signature '%s' has invalid return type '%s'", s, sig.Returns)
+	}
+
+	params := strings.TrimSpace(s[1:closing])
+	if params == "" {
+		return sig, nil
+	}
+
+	for _, param := range strings.Split(params, ",") {
+		param = strings.TrimSpace(param)
+
+		if sig.Variadic != "" {
+			return nil, errors.Errorf("signature '%s' has parameters after a variadic parameter", s)
+		}
+
+		var t Type
+		switch {
+		case strings.HasSuffix(param, "..."):
+			t = Type(strings.TrimSuffix(param, "..."))
+			sig.Variadic = t
+		case strings.HasSuffix(param, "?"):
+			t = Type(strings.TrimSuffix(param, "?"))
+			sig.Optional = append(sig.Optional, t)
+		default:
+			t = Type(param)
+			if len(sig.Optional) > 0 {
+				return nil, errors.Errorf("signature '%s' has required parameters after optional parameters", s)
+			}
+			sig.Params = append(sig.Params, t)
+		}
+
+		if !validTypes[t] {
+			return nil, errors.Errorf("signature '%s' has invalid parameter type '%s'", s, t)
+		}
+	}
+
+	return sig, nil
+}
+
+// MustParseSignature parses the given signature, panicking if it's invalid
+func MustParseSignature(s string) *Signature {
+	sig, err := ParseSignature(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return sig
+}
+
+// XSIGNATURES is our map of signatures of functions available in Excellent
+var XSIGNATURES = map[string]*Signature{}
+
+// RegisterXSignature registers the signature of a function in Excellent
+func RegisterXSignature(name string, signature *Signature) {
+	XSIGNATURES[name] = signature
+}
+
+// LookupSignature returns the signature of the function with the given name (case-insensitive) or nil
+func LookupSignature(name string) *Signature {
+	return XSIGNATURES[strings.ToLower(name)]
+}
+
+func init() {
+	builtin := map[string]string{
+		// type conversion
+		"text":     "(any) text",
+		"boolean":  "(any) boolean",
+		"number":   "(any) number",
+		"date":     "(any) date",
+		"datetime": "(any) datetime",
+		"time":     "(any) time",
+		"array":    "(any...) array",
+		"object":   "(any...) object",
+
+		// text functions
+		"char":              "(number) text",
+		"code":              "(text) number",
+		"split":             "(text, text) array",
+		"join":              "(array, text) text",
+		"title":             "(text) text",
+		"word":              "(text, number, text?) text",
+		"remove_first_word": "(text) text",
+		"word_count":        "(text, text?) number",
+		"word_slice":        "(text, number, number?, text?) text",
+		"field":             "(text, number, text) text",
+		"clean":             "(text) text",
+		"text_slice":        "(text, number, number?) text",
+		"lower":             "(text) text",
+		"regex_match":       "(text, text, number?) text",
+		"text_length":       "(text) number",
+		"text_compare":      "(text, text) number",
+		"repeat":            "(text, number) text",
+		"replace":           "(text, text, text, number?) text",
+		"upper":             "(text) text",
+		"percent":           "(number) text",
+		"url_encode":        "(text) text",
+
+		// bool functions
+		"and": "(any, any...) boolean",
+		"if":  "(any, any, any) any",
+		"or":  "(any, any...) boolean",
+
+		// number functions
+		"round":        "(number, number?) number",
+		"round_up":     "(number, number?) number",
+		"round_down":   "(number, number?) number",
+		"max":          "(number, number...) number",
+		"min":          "(number, number...) number",
+		"mean":         "(number, number...) number",
+		"mod":          "(number, number) number",
+		"rand":         "() number",
+		"rand_between": "(number, number) number",
+		"abs":          "(number) number",
+
+		// datetime functions
+		"parse_datetime":      "(text, text, text?) datetime",
+		"datetime_from_epoch": "(number) datetime",
+		"datetime_diff":       "(datetime, datetime, text) number",
+		"datetime_add":        "(datetime, number, text) datetime",
+		"replace_time":        "(datetime, time) datetime",
+		"tz":                  "(datetime) text",
+		"tz_offset":           "(datetime) text",
+		"now":                 "() datetime",
+		"epoch":               "(datetime) number",
+
+		// date functions
+		"date_from_parts": "(number, number, number) date",
+		"weekday":         "(date) number",
+		"week_number":     "(date) number",
+		"today":           "() date",
+
+		// time functions
+		"parse_time":      "(text, text) time",
+		"time_from_parts": "(number, number, number) time",
+
+		// encoded text functions
+		"urn_parts":        "(text) object",
+		"attachment_parts": "(text) object",
+
+		// json functions
+		"json":       "(any) text",
+		"parse_json": "(text) any",
+
+		// formatting functions
+		"format":          "(any) text",
+		"format_date":     "(date, text?) text",
+		"format_datetime": "(datetime, text?, text?) text",
+		"format_time":     "(time, text?) text",
+		"format_location": "(text) text",
+		"format_number":   "(number, number?, boolean?) text",
+		"format_currency": "(number, text) text",
+		"format_urn":      "(text) text",
+		"format_list":     "(array, text?) text",
+		"format_table":    "(array, array?) text",
+		"format_text":     "(text, any...) text",
+		"number_words":    "(number) text",
+
+		// utility functions
+		"is_error":       "(any) boolean",
+		"count":          "(any) number",
+		"default":        "(any, any) any",
+		"legacy_add":     "(any, any) any",
+		"read_chars":     "(text) text",
+		"extract":        "(object, text) any",
+		"extract_object": "(object, text, text...) object",
+		"foreach":        "(array, function, any...) array",
+		"foreach_value":  "(object, function, any...) object",
+
+		// array functions
+		"filter":  "(array, function) array",
+		"map":     "(array, function) array",
+		"reduce":  "(array, function, any) any",
+		"sort_by": "(array, function) array",
+		"sum":     "(array, function?) number",
+		"any":     "(array, function) boolean",
+		"all":     "(array, function) boolean",
+	}
+
+	for name, sig := range builtin {
+		RegisterXSignature(name, MustParseSignature(sig))
+	}
+}
@@ -0,0 +1,111 @@
+package functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/utils/dates"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignature(t *testing.T) {
+	tcs := []struct {
+		signature string
+		min       int
+		max       int
+		params    []functions.Type
+		err       string
+	}{
+		{"() number", 0, 0, []functions.Type{"", ""}, ""},
+		{"(text) text", 1, 1, []functions.Type{"text", ""}, ""},
+		{"(text, number, text?) text", 2, 3, []functions.Type{"text", "number", "text", ""}, ""},
+		{"(number, number...) number", 1, -1, []functions.Type{"number", "number", "number"}, ""},
+		{"(object, text?, any...) object", 1, -1, []functions.Type{"object", "text", "any", "any"}, ""},
+		{"text", 0, 0, nil, "signature 'text' must start with a parameter list"},
+		{"(text) foo", 0, 0, nil, "signature '(text) foo' has invalid return type 'foo'"},
+		{"(foo) text", 0, 0, nil, "signature '(foo) text' has invalid parameter type 'foo'"},
+		{"(text?, text) text", 0, 0, nil, "signature '(text?, text) text' has required parameters after optional parameters"},
+		{"(text..., text) text", 0, 0, nil, "signature '(text..., text) text' has parameters after a variadic parameter"},
+	}
+
+	for _, tc := range tcs {
+		sig, err := functions.ParseSignature(tc.signature)
+
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.min, sig.MinArgs(), "min args mismatch for %s", tc.signature)
+		assert.Equal(t, tc.max, sig.MaxArgs(), "max args mismatch for %s", tc.signature)
+		assert.Equal(t, tc.signature, sig.String())
+
+		for i, expected := range tc.params {
+			assert.Equal(t, expected, sig.ParamType(i), "param %d type mismatch for %s", i, tc.signature)
+		}
+	}
+
+	assert.Panics(t, func() { functions.MustParseSignature("(foo) text") })
+}
+
+func TestBuiltinSignatures(t *testing.T) {
+	// every builtin function should have a signature
+	for name := range functions.XFUNCTIONS {
+		assert.NotNil(t, functions.LookupSignature(name), "missing signature for function %s", name)
+	}
+
+	assert.Equal(t, "(datetime, number, text) datetime", functions.LookupSignature("DATETIME_ADD").String())
+	assert.Nil(t, functions.LookupSignature("xxx"))
+}
+
+func TestBuiltinSignatureArities(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	// calling a function with fewer or more arguments than its signature allows should be an error
+	for name, fn := range functions.XFUNCTIONS {
+		sig := functions.LookupSignature(name)
+		if sig == nil {
+			continue
+		}
+
+		if sig.MinArgs() > 0 {
+			result := fn(env, signatureArgs(sig, sig.MinArgs()-1)...)
+			assert.True(t, types.IsXError(result), "expected error calling %s with %d args, got %s", name, sig.MinArgs()-1, result)
+		}
+		if sig.MaxArgs() >= 0 {
+			result := fn(env, signatureArgs(sig, sig.MaxArgs()+1)...)
+			assert.True(t, types.IsXError(result), "expected error calling %s with %d args, got %s", name, sig.MaxArgs()+1, result)
+		}
+	}
+}
+
+// generates the given number of arguments with the types of the given signature
+func signatureArgs(sig *functions.Signature, count int) []types.XValue {
+	samples := map[functions.Type]types.XValue{
+		functions.TypeAny:      types.NewXText("1"),
+		functions.TypeText:     types.NewXText("1"),
+		functions.TypeNumber:   types.NewXNumberFromInt(1),
+		functions.TypeBoolean:  types.XBooleanTrue,
+		functions.TypeDate:     types.NewXDate(dates.NewDate(2020, 1, 2)),
+		functions.TypeDateTime: types.NewXDateTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		functions.TypeTime:     types.NewXTime(dates.NewTimeOfDay(3, 4, 5, 0)),
+		functions.TypeArray:    types.NewXArray(types.NewXText("1")),
+		functions.TypeObject:   types.NewXObject(map[string]types.XValue{"a": types.NewXText("1")}),
+		functions.TypeFunction: functions.Lookup("upper"),
+	}
+
+	args := make([]types.XValue, count)
+	for i := range args {
+		t := sig.ParamType(i)
+		if t == "" {
+			t = functions.TypeAny
+		}
+		args[i] = samples[t]
+	}
+	return args
+}
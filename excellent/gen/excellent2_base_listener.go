@@ -104,6 +104,12 @@ func (s *BaseExcellent2Listener) EnterExponent(ctx *ExponentContext) {}
 // ExitExponent is called when production exponent is exited.
 func (s *BaseExcellent2Listener) ExitExponent(ctx *ExponentContext) {}
 
+// EnterAnonymousFunction is called when production anonymousFunction is entered.
+func (s *BaseExcellent2Listener) EnterAnonymousFunction(ctx *AnonymousFunctionContext) {}
+
+// ExitAnonymousFunction is called when production anonymousFunction is exited.
+func (s *BaseExcellent2Listener) ExitAnonymousFunction(ctx *AnonymousFunctionContext) {}
+
 // EnterParentheses is called when production parentheses is entered.
 func (s *BaseExcellent2Listener) EnterParentheses(ctx *ParenthesesContext) {}
 
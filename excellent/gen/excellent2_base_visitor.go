@@ -63,6 +63,10 @@ func (v *BaseExcellent2Visitor) VisitExponent(ctx *ExponentContext) interface{}
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent2Visitor) VisitAnonymousFunction(ctx *AnonymousFunctionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent2Visitor) VisitParentheses(ctx *ParenthesesContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -49,6 +49,9 @@ type Excellent2Listener interface {
 	// EnterExponent is called when entering the exponent production.
 	EnterExponent(c *ExponentContext)
 
+	// EnterAnonymousFunction is called when entering the anonymousFunction production.
+	EnterAnonymousFunction(c *AnonymousFunctionContext)
+
 	// EnterParentheses is called when entering the parentheses production.
 	EnterParentheses(c *ParenthesesContext)
 
@@ -109,6 +112,9 @@ type Excellent2Listener interface {
 	// ExitExponent is called when exiting the exponent production.
 	ExitExponent(c *ExponentContext)
 
+	// ExitAnonymousFunction is called when exiting the anonymousFunction production.
+	ExitAnonymousFunction(c *AnonymousFunctionContext)
+
 	// ExitParentheses is called when exiting the parentheses production.
 	ExitParentheses(c *ParenthesesContext)
 
@@ -15,55 +15,63 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 29, 83, 4,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 30, 97, 4,
 	2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 3, 2, 3, 2, 3, 2, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 5, 3, 23, 10, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 43, 10, 3, 12, 3, 14, 3, 46, 11, 3, 3,
-	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 5, 4, 54, 10, 4, 3, 4, 3, 4, 3, 4, 5,
-	4, 59, 10, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 7,
-	4, 70, 10, 4, 12, 4, 14, 4, 73, 11, 4, 3, 5, 3, 5, 3, 5, 7, 5, 78, 10,
-	5, 12, 5, 14, 5, 81, 11, 5, 3, 5, 2, 4, 4, 6, 6, 2, 4, 6, 8, 2, 8, 3, 2,
-	22, 23, 3, 2, 11, 12, 3, 2, 9, 10, 3, 2, 16, 19, 3, 2, 14, 15, 4, 2, 22,
-	22, 27, 27, 2, 96, 2, 10, 3, 2, 2, 2, 4, 22, 3, 2, 2, 2, 6, 53, 3, 2, 2,
-	2, 8, 74, 3, 2, 2, 2, 10, 11, 5, 4, 3, 2, 11, 12, 7, 2, 2, 3, 12, 3, 3,
-	2, 2, 2, 13, 14, 8, 3, 1, 2, 14, 23, 5, 6, 4, 2, 15, 16, 7, 10, 2, 2, 16,
-	23, 5, 4, 3, 14, 17, 23, 7, 21, 2, 2, 18, 23, 9, 2, 2, 2, 19, 23, 7, 24,
-	2, 2, 20, 23, 7, 25, 2, 2, 21, 23, 7, 26, 2, 2, 22, 13, 3, 2, 2, 2, 22,
-	15, 3, 2, 2, 2, 22, 17, 3, 2, 2, 2, 22, 18, 3, 2, 2, 2, 22, 19, 3, 2, 2,
-	2, 22, 20, 3, 2, 2, 2, 22, 21, 3, 2, 2, 2, 23, 44, 3, 2, 2, 2, 24, 25,
-	12, 13, 2, 2, 25, 26, 7, 13, 2, 2, 26, 43, 5, 4, 3, 14, 27, 28, 12, 12,
-	2, 2, 28, 29, 9, 3, 2, 2, 29, 43, 5, 4, 3, 13, 30, 31, 12, 11, 2, 2, 31,
-	32, 9, 4, 2, 2, 32, 43, 5, 4, 3, 12, 33, 34, 12, 10, 2, 2, 34, 35, 9, 5,
-	2, 2, 35, 43, 5, 4, 3, 11, 36, 37, 12, 9, 2, 2, 37, 38, 9, 6, 2, 2, 38,
-	43, 5, 4, 3, 10, 39, 40, 12, 8, 2, 2, 40, 41, 7, 20, 2, 2, 41, 43, 5, 4,
-	3, 9, 42, 24, 3, 2, 2, 2, 42, 27, 3, 2, 2, 2, 42, 30, 3, 2, 2, 2, 42, 33,
-	3, 2, 2, 2, 42, 36, 3, 2, 2, 2, 42, 39, 3, 2, 2, 2, 43, 46, 3, 2, 2, 2,
-	44, 42, 3, 2, 2, 2, 44, 45, 3, 2, 2, 2, 45, 5, 3, 2, 2, 2, 46, 44, 3, 2,
-	2, 2, 47, 48, 8, 4, 1, 2, 48, 49, 7, 4, 2, 2, 49, 50, 5, 4, 3, 2, 50, 51,
-	7, 5, 2, 2, 51, 54, 3, 2, 2, 2, 52, 54, 7, 27, 2, 2, 53, 47, 3, 2, 2, 2,
-	53, 52, 3, 2, 2, 2, 54, 71, 3, 2, 2, 2, 55, 56, 12, 7, 2, 2, 56, 58, 7,
-	4, 2, 2, 57, 59, 5, 8, 5, 2, 58, 57, 3, 2, 2, 2, 58, 59, 3, 2, 2, 2, 59,
-	60, 3, 2, 2, 2, 60, 70, 7, 5, 2, 2, 61, 62, 12, 6, 2, 2, 62, 63, 7, 8,
-	2, 2, 63, 70, 9, 7, 2, 2, 64, 65, 12, 5, 2, 2, 65, 66, 7, 6, 2, 2, 66,
-	67, 5, 4, 3, 2, 67, 68, 7, 7, 2, 2, 68, 70, 3, 2, 2, 2, 69, 55, 3, 2, 2,
-	2, 69, 61, 3, 2, 2, 2, 69, 64, 3, 2, 2, 2, 70, 73, 3, 2, 2, 2, 71, 69,
-	3, 2, 2, 2, 71, 72, 3, 2, 2, 2, 72, 7, 3, 2, 2, 2, 73, 71, 3, 2, 2, 2,
-	74, 79, 5, 4, 3, 2, 75, 76, 7, 3, 2, 2, 76, 78, 5, 4, 3, 2, 77, 75, 3,
-	2, 2, 2, 78, 81, 3, 2, 2, 2, 79, 77, 3, 2, 2, 2, 79, 80, 3, 2, 2, 2, 80,
-	9, 3, 2, 2, 2, 81, 79, 3, 2, 2, 2, 10, 22, 42, 44, 53, 58, 69, 71, 79,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 7,
+	3, 27, 10, 3, 12, 3, 14, 3, 30, 11, 3, 5, 3, 32, 10, 3, 3, 3, 3, 3, 3,
+	3, 5, 3, 37, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 57, 10,
+	3, 12, 3, 14, 3, 60, 11, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 5, 4,
+	68, 10, 4, 3, 4, 3, 4, 3, 4, 5, 4, 73, 10, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
+	4, 3, 4, 3, 4, 3, 4, 3, 4, 7, 4, 84, 10, 4, 12, 4, 14, 4, 87, 11, 4, 3,
+	5, 3, 5, 3, 5, 7, 5, 92, 10, 5, 12, 5, 14, 5, 95, 11, 5, 3, 5, 2, 4, 4,
+	6, 6, 2, 4, 6, 8, 2, 8, 3, 2, 23, 24, 3, 2, 11, 12, 3, 2, 9, 10, 3, 2,
+	16, 19, 3, 2, 14, 15, 4, 2, 23, 23, 28, 28, 2, 113, 2, 10, 3, 2, 2, 2,
+	4, 36, 3, 2, 2, 2, 6, 67, 3, 2, 2, 2, 8, 88, 3, 2, 2, 2, 10, 11, 5, 4,
+	3, 2, 11, 12, 7, 2, 2, 3, 12, 3, 3, 2, 2, 2, 13, 14, 8, 3, 1, 2, 14, 37,
+	5, 6, 4, 2, 15, 16, 7, 10, 2, 2, 16, 37, 5, 4, 3, 15, 17, 37, 7, 22, 2,
+	2, 18, 37, 9, 2, 2, 2, 19, 37, 7, 25, 2, 2, 20, 37, 7, 26, 2, 2, 21, 37,
+	7, 27, 2, 2, 22, 31, 7, 4, 2, 2, 23, 28, 7, 28, 2, 2, 24, 25, 7, 3, 2,
+	2, 25, 27, 7, 28, 2, 2, 26, 24, 3, 2, 2, 2, 27, 30, 3, 2, 2, 2, 28, 26,
+	3, 2, 2, 2, 28, 29, 3, 2, 2, 2, 29, 32, 3, 2, 2, 2, 30, 28, 3, 2, 2, 2,
+	31, 23, 3, 2, 2, 2, 31, 32, 3, 2, 2, 2, 32, 33, 3, 2, 2, 2, 33, 34, 7,
+	5, 2, 2, 34, 35, 7, 21, 2, 2, 35, 37, 5, 4, 3, 3, 36, 13, 3, 2, 2, 2,
+	36, 15, 3, 2, 2, 2, 36, 17, 3, 2, 2, 2, 36, 18, 3, 2, 2, 2, 36, 19, 3,
+	2, 2, 2, 36, 20, 3, 2, 2, 2, 36, 21, 3, 2, 2, 2, 36, 22, 3, 2, 2, 2, 37,
+	58, 3, 2, 2, 2, 38, 39, 12, 14, 2, 2, 39, 40, 7, 13, 2, 2, 40, 57, 5, 4,
+	3, 15, 41, 42, 12, 13, 2, 2, 42, 43, 9, 3, 2, 2, 43, 57, 5, 4, 3, 14,
+	44, 45, 12, 12, 2, 2, 45, 46, 9, 4, 2, 2, 46, 57, 5, 4, 3, 13, 47, 48,
+	12, 11, 2, 2, 48, 49, 9, 5, 2, 2, 49, 57, 5, 4, 3, 12, 50, 51, 12, 10,
+	2, 2, 51, 52, 9, 6, 2, 2, 52, 57, 5, 4, 3, 11, 53, 54, 12, 9, 2, 2, 54,
+	55, 7, 20, 2, 2, 55, 57, 5, 4, 3, 10, 56, 38, 3, 2, 2, 2, 56, 41, 3, 2,
+	2, 2, 56, 44, 3, 2, 2, 2, 56, 47, 3, 2, 2, 2, 56, 50, 3, 2, 2, 2, 56,
+	53, 3, 2, 2, 2, 57, 60, 3, 2, 2, 2, 58, 56, 3, 2, 2, 2, 58, 59, 3, 2, 2,
+	2, 59, 5, 3, 2, 2, 2, 60, 58, 3, 2, 2, 2, 61, 62, 8, 4, 1, 2, 62, 63, 7,
+	4, 2, 2, 63, 64, 5, 4, 3, 2, 64, 65, 7, 5, 2, 2, 65, 68, 3, 2, 2, 2, 66,
+	68, 7, 28, 2, 2, 67, 61, 3, 2, 2, 2, 67, 66, 3, 2, 2, 2, 68, 85, 3, 2,
+	2, 2, 69, 70, 12, 7, 2, 2, 70, 72, 7, 4, 2, 2, 71, 73, 5, 8, 5, 2, 72,
+	71, 3, 2, 2, 2, 72, 73, 3, 2, 2, 2, 73, 74, 3, 2, 2, 2, 74, 84, 7, 5, 2,
+	2, 75, 76, 12, 6, 2, 2, 76, 77, 7, 8, 2, 2, 77, 84, 9, 7, 2, 2, 78, 79,
+	12, 5, 2, 2, 79, 80, 7, 6, 2, 2, 80, 81, 5, 4, 3, 2, 81, 82, 7, 7, 2, 2,
+	82, 84, 3, 2, 2, 2, 83, 69, 3, 2, 2, 2, 83, 75, 3, 2, 2, 2, 83, 78, 3,
+	2, 2, 2, 84, 87, 3, 2, 2, 2, 85, 83, 3, 2, 2, 2, 85, 86, 3, 2, 2, 2, 86,
+	7, 3, 2, 2, 2, 87, 85, 3, 2, 2, 2, 88, 93, 5, 4, 3, 2, 89, 90, 7, 3, 2,
+	2, 90, 92, 5, 4, 3, 2, 91, 89, 3, 2, 2, 2, 92, 95, 3, 2, 2, 2, 93, 91,
+	3, 2, 2, 2, 93, 94, 3, 2, 2, 2, 94, 9, 3, 2, 2, 2, 95, 93, 3, 2, 2, 2,
+	12, 28, 31, 36, 56, 58, 67, 72, 83, 85, 93,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
 
 var literalNames = []string{
 	"", "','", "'('", "')'", "'['", "']'", "'.'", "'+'", "'-'", "'*'", "'/'",
-	"'^'", "'='", "'!='", "'<='", "'<'", "'>='", "'>'", "'&'",
+	"'^'", "'='", "'!='", "'<='", "'<'", "'>='", "'>'", "'&'", "'=>'",
 }
 var symbolicNames = []string{
 	"", "COMMA", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "DOT", "PLUS", "MINUS",
 	"TIMES", "DIVIDE", "EXPONENT", "EQ", "NEQ", "LTE", "LT", "GTE", "GT", "AMPERSAND",
-	"TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE", "NULL", "NAME", "WS", "ERROR",
+	"ARROW", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE", "NULL", "NAME", "WS",
+	"ERROR",
 }
 
 var ruleNames = []string{
@@ -116,15 +124,16 @@ const (
 	Excellent2ParserGTE       = 16
 	Excellent2ParserGT        = 17
 	Excellent2ParserAMPERSAND = 18
-	Excellent2ParserTEXT      = 19
-	Excellent2ParserINTEGER   = 20
-	Excellent2ParserDECIMAL   = 21
-	Excellent2ParserTRUE      = 22
-	Excellent2ParserFALSE     = 23
-	Excellent2ParserNULL      = 24
-	Excellent2ParserNAME      = 25
-	Excellent2ParserWS        = 26
-	Excellent2ParserERROR     = 27
+	Excellent2ParserARROW     = 19
+	Excellent2ParserTEXT      = 20
+	Excellent2ParserINTEGER   = 21
+	Excellent2ParserDECIMAL   = 22
+	Excellent2ParserTRUE      = 23
+	Excellent2ParserFALSE     = 24
+	Excellent2ParserNULL      = 25
+	Excellent2ParserNAME      = 26
+	Excellent2ParserWS        = 27
+	Excellent2ParserERROR     = 28
 )
 
 // Excellent2Parser rules.
@@ -1074,6 +1083,84 @@ func (s *ExponentContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type AnonymousFunctionContext struct {
+	*ExpressionContext
+}
+
+func NewAnonymousFunctionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AnonymousFunctionContext {
+	var p = new(AnonymousFunctionContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *AnonymousFunctionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AnonymousFunctionContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(Excellent2ParserLPAREN, 0)
+}
+
+func (s *AnonymousFunctionContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(Excellent2ParserRPAREN, 0)
+}
+
+func (s *AnonymousFunctionContext) ARROW() antlr.TerminalNode {
+	return s.GetToken(Excellent2ParserARROW, 0)
+}
+
+func (s *AnonymousFunctionContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *AnonymousFunctionContext) AllNAME() []antlr.TerminalNode {
+	return s.GetTokens(Excellent2ParserNAME)
+}
+
+func (s *AnonymousFunctionContext) NAME(i int) antlr.TerminalNode {
+	return s.GetToken(Excellent2ParserNAME, i)
+}
+
+func (s *AnonymousFunctionContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(Excellent2ParserCOMMA)
+}
+
+func (s *AnonymousFunctionContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(Excellent2ParserCOMMA, i)
+}
+
+func (s *AnonymousFunctionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent2Listener); ok {
+		listenerT.EnterAnonymousFunction(s)
+	}
+}
+
+func (s *AnonymousFunctionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent2Listener); ok {
+		listenerT.ExitAnonymousFunction(s)
+	}
+}
+
+func (s *AnonymousFunctionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent2Visitor:
+		return t.VisitAnonymousFunction(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 func (p *Excellent2Parser) Expression() (localctx IExpressionContext) {
 	return p.expression(0)
 }
@@ -1107,21 +1194,20 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(20)
+	p.SetState(34)
 	p.GetErrorHandler().Sync(p)
 
-	switch p.GetTokenStream().LA(1) {
-	case Excellent2ParserLPAREN, Excellent2ParserNAME:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext()) {
+	case 1:
 		localctx = NewAtomReferenceContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
-
 		{
 			p.SetState(12)
 			p.atom(0)
 		}
 
-	case Excellent2ParserMINUS:
+	case 2:
 		localctx = NewNegationContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1131,10 +1217,10 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 		}
 		{
 			p.SetState(14)
-			p.expression(12)
+			p.expression(13)
 		}
 
-	case Excellent2ParserTEXT:
+	case 3:
 		localctx = NewTextLiteralContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1143,7 +1229,7 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 			p.Match(Excellent2ParserTEXT)
 		}
 
-	case Excellent2ParserINTEGER, Excellent2ParserDECIMAL:
+	case 4:
 		localctx = NewNumberLiteralContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1159,7 +1245,7 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 
-	case Excellent2ParserTRUE:
+	case 5:
 		localctx = NewTrueContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1168,7 +1254,7 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 			p.Match(Excellent2ParserTRUE)
 		}
 
-	case Excellent2ParserFALSE:
+	case 6:
 		localctx = NewFalseContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1177,7 +1263,7 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 			p.Match(Excellent2ParserFALSE)
 		}
 
-	case Excellent2ParserNULL:
+	case 7:
 		localctx = NewNullContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1186,13 +1272,61 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 			p.Match(Excellent2ParserNULL)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	case 8:
+		localctx = NewAnonymousFunctionContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(20)
+			p.Match(Excellent2ParserLPAREN)
+		}
+		p.SetState(29)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == Excellent2ParserNAME {
+			{
+				p.SetState(21)
+				p.Match(Excellent2ParserNAME)
+			}
+			p.SetState(26)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+
+			for _la == Excellent2ParserCOMMA {
+				{
+					p.SetState(22)
+					p.Match(Excellent2ParserCOMMA)
+				}
+				{
+					p.SetState(23)
+					p.Match(Excellent2ParserNAME)
+				}
+
+				p.SetState(28)
+				p.GetErrorHandler().Sync(p)
+				_la = p.GetTokenStream().LA(1)
+			}
+
+		}
+		{
+			p.SetState(31)
+			p.Match(Excellent2ParserRPAREN)
+		}
+		{
+			p.SetState(32)
+			p.Match(Excellent2ParserARROW)
+		}
+		{
+			p.SetState(33)
+			p.expression(1)
+		}
+
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(42)
+	p.SetState(56)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -1200,36 +1334,36 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(40)
+			p.SetState(54)
 			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 1, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewExponentContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(22)
+				p.SetState(36)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
 				}
 				{
-					p.SetState(23)
+					p.SetState(37)
 					p.Match(Excellent2ParserEXPONENT)
 				}
 				{
-					p.SetState(24)
-					p.expression(12)
+					p.SetState(38)
+					p.expression(13)
 				}
 
 			case 2:
 				localctx = NewMultiplicationOrDivisionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(25)
+				p.SetState(39)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
 				}
 				{
-					p.SetState(26)
+					p.SetState(40)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1247,20 +1381,20 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(27)
-					p.expression(11)
+					p.SetState(41)
+					p.expression(12)
 				}
 
 			case 3:
 				localctx = NewAdditionOrSubtractionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(28)
+				p.SetState(42)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
 				}
 				{
-					p.SetState(29)
+					p.SetState(43)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1278,20 +1412,20 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(30)
-					p.expression(10)
+					p.SetState(44)
+					p.expression(11)
 				}
 
 			case 4:
 				localctx = NewComparisonContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(31)
+				p.SetState(45)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
 				}
 				{
-					p.SetState(32)
+					p.SetState(46)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1309,20 +1443,20 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(33)
-					p.expression(9)
+					p.SetState(47)
+					p.expression(10)
 				}
 
 			case 5:
 				localctx = NewEqualityContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(34)
+				p.SetState(48)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 				}
 				{
-					p.SetState(35)
+					p.SetState(49)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1340,33 +1474,33 @@ func (p *Excellent2Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(36)
-					p.expression(8)
+					p.SetState(50)
+					p.expression(9)
 				}
 
 			case 6:
 				localctx = NewConcatenationContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_expression)
-				p.SetState(37)
+				p.SetState(51)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 				}
 				{
-					p.SetState(38)
+					p.SetState(52)
 					p.Match(Excellent2ParserAMPERSAND)
 				}
 				{
-					p.SetState(39)
-					p.expression(7)
+					p.SetState(53)
+					p.expression(8)
 				}
 
 			}
 
 		}
-		p.SetState(44)
+		p.SetState(58)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -1755,7 +1889,7 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(51)
+	p.SetState(65)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -1765,15 +1899,15 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 		_prevctx = localctx
 
 		{
-			p.SetState(46)
+			p.SetState(60)
 			p.Match(Excellent2ParserLPAREN)
 		}
 		{
-			p.SetState(47)
+			p.SetState(61)
 			p.expression(0)
 		}
 		{
-			p.SetState(48)
+			p.SetState(62)
 			p.Match(Excellent2ParserRPAREN)
 		}
 
@@ -1782,7 +1916,7 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(50)
+			p.SetState(64)
 			p.Match(Excellent2ParserNAME)
 		}
 
@@ -1790,9 +1924,9 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(69)
+	p.SetState(83)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 6, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 8, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -1800,51 +1934,51 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(67)
+			p.SetState(81)
 			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 7, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewFunctionCallContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_atom)
-				p.SetState(53)
+				p.SetState(67)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 				}
 				{
-					p.SetState(54)
+					p.SetState(68)
 					p.Match(Excellent2ParserLPAREN)
 				}
-				p.SetState(56)
+				p.SetState(70)
 				p.GetErrorHandler().Sync(p)
 				_la = p.GetTokenStream().LA(1)
 
 				if ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<Excellent2ParserLPAREN)|(1<<Excellent2ParserMINUS)|(1<<Excellent2ParserTEXT)|(1<<Excellent2ParserINTEGER)|(1<<Excellent2ParserDECIMAL)|(1<<Excellent2ParserTRUE)|(1<<Excellent2ParserFALSE)|(1<<Excellent2ParserNULL)|(1<<Excellent2ParserNAME))) != 0 {
 					{
-						p.SetState(55)
+						p.SetState(69)
 						p.Parameters()
 					}
 
 				}
 				{
-					p.SetState(58)
+					p.SetState(72)
 					p.Match(Excellent2ParserRPAREN)
 				}
 
 			case 2:
 				localctx = NewDotLookupContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_atom)
-				p.SetState(59)
+				p.SetState(73)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
 				}
 				{
-					p.SetState(60)
+					p.SetState(74)
 					p.Match(Excellent2ParserDOT)
 				}
 				{
-					p.SetState(61)
+					p.SetState(75)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(_la == Excellent2ParserINTEGER || _la == Excellent2ParserNAME) {
@@ -1858,30 +1992,30 @@ func (p *Excellent2Parser) atom(_p int) (localctx IAtomContext) {
 			case 3:
 				localctx = NewArrayLookupContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent2ParserRULE_atom)
-				p.SetState(62)
+				p.SetState(76)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 				}
 				{
-					p.SetState(63)
+					p.SetState(77)
 					p.Match(Excellent2ParserLBRACK)
 				}
 				{
-					p.SetState(64)
+					p.SetState(78)
 					p.expression(0)
 				}
 				{
-					p.SetState(65)
+					p.SetState(79)
 					p.Match(Excellent2ParserRBRACK)
 				}
 
 			}
 
 		}
-		p.SetState(71)
+		p.SetState(85)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 6, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 8, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -2032,24 +2166,24 @@ func (p *Excellent2Parser) Parameters() (localctx IParametersContext) {
 	localctx = NewFunctionParametersContext(p, localctx)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(72)
+		p.SetState(86)
 		p.expression(0)
 	}
-	p.SetState(77)
+	p.SetState(91)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == Excellent2ParserCOMMA {
 		{
-			p.SetState(73)
+			p.SetState(87)
 			p.Match(Excellent2ParserCOMMA)
 		}
 		{
-			p.SetState(74)
+			p.SetState(88)
 			p.expression(0)
 		}
 
-		p.SetState(79)
+		p.SetState(93)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -2081,22 +2215,22 @@ func (p *Excellent2Parser) Sempred(localctx antlr.RuleContext, ruleIndex, predIn
 func (p *Excellent2Parser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 11)
+		return p.Precpred(p.GetParserRuleContext(), 12)
 
 	case 1:
-		return p.Precpred(p.GetParserRuleContext(), 10)
+		return p.Precpred(p.GetParserRuleContext(), 11)
 
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 9)
+		return p.Precpred(p.GetParserRuleContext(), 10)
 
 	case 3:
-		return p.Precpred(p.GetParserRuleContext(), 8)
+		return p.Precpred(p.GetParserRuleContext(), 9)
 
 	case 4:
-		return p.Precpred(p.GetParserRuleContext(), 7)
+		return p.Precpred(p.GetParserRuleContext(), 8)
 
 	case 5:
-		return p.Precpred(p.GetParserRuleContext(), 6)
+		return p.Precpred(p.GetParserRuleContext(), 7)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
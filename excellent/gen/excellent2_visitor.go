@@ -49,6 +49,9 @@ type Excellent2Visitor interface {
 	// Visit a parse tree produced by Excellent2Parser#exponent.
 	VisitExponent(ctx *ExponentContext) interface{}
 
+	// Visit a parse tree produced by Excellent2Parser#anonymousFunction.
+	VisitAnonymousFunction(ctx *AnonymousFunctionContext) interface{}
+
 	// Visit a parse tree produced by Excellent2Parser#parentheses.
 	VisitParentheses(ctx *ParenthesesContext) interface{}
 
@@ -11,6 +11,7 @@ type xinput struct {
 	base        *bufio.Reader
 	unreadRunes []rune
 	unreadCount int
+	position    int
 }
 
 func newInput(base *bufio.Reader) *xinput {
@@ -26,6 +27,9 @@ func (r *xinput) read() rune {
 	if r.unreadCount > 0 {
 		ch := r.unreadRunes[r.unreadCount-1]
 		r.unreadCount--
+		if ch != eof {
+			r.position++
+		}
 		return ch
 	}
 
@@ -34,6 +38,7 @@ func (r *xinput) read() rune {
 	if err != nil {
 		return eof
 	}
+	r.position++
 	return ch
 }
 
@@ -41,4 +46,7 @@ func (r *xinput) read() rune {
 func (r *xinput) unread(ch rune) {
 	r.unreadRunes[r.unreadCount] = ch
 	r.unreadCount++
+	if ch != eof {
+		r.position--
+	}
 }
@@ -34,6 +34,7 @@ func isNameChar(ch rune) bool {
 type Scanner interface {
 	Scan() (XTokenType, string)
 	SetUnescapeBody(bool)
+	Position() int
 }
 
 // xscanner represents a lexical scanner.
@@ -56,6 +57,11 @@ func (s *xscanner) SetUnescapeBody(unescape bool) {
 	s.unescapeBody = unescape
 }
 
+// Position returns the offset in runes of the next token to be scanned
+func (s *xscanner) Position() int {
+	return s.input.position
+}
+
 // scanExpression consumes the current rune and all contiguous pieces until the end of the expression
 // our read should be after the '('
 func (s *xscanner) scanExpression() (XTokenType, string) {
@@ -42,3 +42,14 @@ func TestScanner(t *testing.T) {
 		assert.Equal(t, test.tokens, tokens, "scan failed for input %s", test.input)
 	}
 }
+
+func TestScannerPosition(t *testing.T) {
+	scanner := excellent.NewXScanner(strings.NewReader(`Hi @contact... @@ @(upper("é")) @bob`), []string{"contact"})
+
+	positions := make([]int, 0)
+	for tokenType, _ := scanner.Scan(); tokenType != excellent.EOF; tokenType, _ = scanner.Scan() {
+		positions = append(positions, scanner.Position())
+	}
+
+	assert.Equal(t, []int{3, 11, 18, 31, 32, 36}, positions)
+}
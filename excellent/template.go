@@ -0,0 +1,124 @@
+package excellent
+
+import (
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Template is a template which has been scanned and had its expressions parsed, so that it can be evaluated
+// many times without the cost of parsing it again. Templates are safe to evaluate concurrently.
+type Template struct {
+	source           string
+	allowedTopLevels []string
+	parts            []*templatePart
+}
+
+// a single token of a compiled template
+type templatePart struct {
+	tokenType XTokenType
+	token     string
+	offset    int
+
+	// the parsed expression, or the syntax error from parsing it
+	tree antlr.ParseTree
+	err  error
+}
+
+// CompileTemplate scans the given template and parses each of its expressions. Syntax errors aren't returned
+// here but when the template is evaluated, so that they're reported in the same way as evaluation errors.
+func CompileTemplate(template string, allowedTopLevels []string) *Template {
+	t := &Template{source: template, allowedTopLevels: allowedTopLevels}
+
+	// nothing todo for an empty template
+	if template == "" {
+		return t
+	}
+
+	scanner := NewXScanner(strings.NewReader(template), allowedTopLevels)
+
+	for {
+		offset := scanner.Position()
+		tokenType, token := scanner.Scan()
+		if tokenType == EOF {
+			break
+		}
+
+		part := &templatePart{tokenType: tokenType, token: token, offset: offset}
+		if tokenType == IDENTIFIER || tokenType == EXPRESSION {
+			part.tree, part.err = parseExpression(token)
+		}
+
+		t.parts = append(t.parts, part)
+	}
+
+	return t
+}
+
+// Source returns the text this template was compiled from
+func (t *Template) Source() string { return t.source }
+
+// Evaluate evaluates this template, returning the result as a string
+func (t *Template) Evaluate(env envs.Environment, context *types.XObject) (string, error) {
+	var buf strings.Builder
+	errors := NewTemplateErrors()
+
+	for _, part := range t.parts {
+		switch part.tokenType {
+		case BODY:
+			buf.WriteString(part.token)
+		case IDENTIFIER, EXPRESSION:
+			value := part.evaluate(env, context)
+
+			// if we got an error, record that
+			if types.IsXError(value) {
+				errors.AddError(part.repr(), part.offset, value.(error))
+				continue
+			}
+
+			// if not, stringify value and append to the output
+			strValue, _ := types.ToXText(env, value)
+			buf.WriteString(strValue.Native())
+		}
+	}
+
+	if errors.HasErrors() {
+		return buf.String(), errors
+	}
+	return buf.String(), nil
+}
+
+// EvaluateValue is equivalent to Evaluate except in the case where the template contains a single identifier
+// or expression, ie: "@contact" or "@(first(contact.urns))". In these cases we return the typed value of
+// the expression instead of stringifying the result.
+func (t *Template) EvaluateValue(env envs.Environment, context *types.XObject) (types.XValue, error) {
+	if len(t.parts) == 1 {
+		switch part := t.parts[0]; part.tokenType {
+		case IDENTIFIER, EXPRESSION:
+			return part.evaluate(env, context), nil
+		}
+	}
+
+	asStr, err := t.Evaluate(env, context)
+	return types.NewXText(asStr), err
+}
+
+// evaluates the expression of this part
+func (p *templatePart) evaluate(env envs.Environment, context *types.XObject) types.XValue {
+	if p.err != nil {
+		return types.NewXError(p.err)
+	}
+
+	return toXValue(newEvaluationVisitor(env, context).Visit(p.tree))
+}
+
+// gets how the expression of this part appears in the template
+func (p *templatePart) repr() string {
+	if p.tokenType == IDENTIFIER {
+		return "@" + p.token
+	}
+	return "@(" + p.token + ")"
+}
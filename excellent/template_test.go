@@ -0,0 +1,104 @@
+package excellent_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileTemplate(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	context := types.NewXObject(map[string]types.XValue{
+		"foo": types.NewXText("bar"),
+		"num": types.NewXNumberFromInt(3),
+	})
+
+	compiled := excellent.CompileTemplate(`Hi @foo, @(num * 2) or @(1 /)`, context.Properties())
+	assert.Equal(t, `Hi @foo, @(num * 2) or @(1 /)`, compiled.Source())
+
+	// can be evaluated repeatedly and against different contexts
+	for _, val := range []string{"bar", "zed"} {
+		context := types.NewXObject(map[string]types.XValue{
+			"foo": types.NewXText(val),
+			"num": types.NewXNumberFromInt(3),
+		})
+
+		result, err := compiled.Evaluate(env, context)
+		assert.Equal(t, "Hi "+val+", 6 or ", result)
+		assert.EqualError(t, err, `error evaluating @(1 /): syntax error at `)
+	}
+
+	// a single expression evaluates to a typed value
+	value, err := excellent.CompileTemplate(`@(num * 2)`, context.Properties()).EvaluateValue(env, context)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewXNumberFromInt(6), value)
+
+	value, err = excellent.CompileTemplate(`@num!`, context.Properties()).EvaluateValue(env, context)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewXText("3!"), value)
+
+	value, err = excellent.CompileTemplate(``, context.Properties()).EvaluateValue(env, context)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewXText(""), value)
+}
+
+func TestTemplateCache(t *testing.T) {
+	cache := excellent.NewTemplateCache(2)
+
+	t1 := cache.Get(`@foo`, []string{"foo"})
+	t2 := cache.Get(`@bar`, []string{"bar"})
+	assert.Equal(t, 2, cache.Len())
+
+	// same text and top levels gives us the cached template
+	assert.True(t, t1 == cache.Get(`@foo`, []string{"foo"}))
+
+	// different top levels means we can't use the cached template
+	t1b := cache.Get(`@foo`, []string{"bar"})
+	assert.True(t, t1 != t1b)
+	assert.True(t, t1b == cache.Get(`@foo`, []string{"bar"}))
+	assert.Equal(t, 2, cache.Len())
+
+	// adding a third template evicts the least recently used
+	cache.Get(`@baz`, []string{"baz"})
+	assert.Equal(t, 2, cache.Len())
+	assert.True(t, t1b == cache.Get(`@foo`, []string{"bar"}))
+	assert.True(t, t2 != cache.Get(`@bar`, []string{"bar"}))
+
+	// a cache with no capacity compiles every time
+	disabled := excellent.NewTemplateCache(0)
+	assert.True(t, disabled.Get(`@foo`, nil) != disabled.Get(`@foo`, nil))
+	assert.Equal(t, 0, disabled.Len())
+}
+
+var benchmarkTemplates = []string{
+	`Hi @foo, you have @(num * 2) points`,
+	`@(upper(foo)) @(if(num > 2, "many", "few")) @(format_number(num + 1.5))`,
+	`@(foo & " " & num & " " & (num - 1))`,
+}
+
+func BenchmarkEvaluateTemplateUncompiled(b *testing.B) {
+	env := envs.NewBuilder().Build()
+	context := types.NewXObject(map[string]types.XValue{"foo": types.NewXText("bar"), "num": types.NewXNumberFromInt(3)})
+
+	for n := 0; n < b.N; n++ {
+		for _, template := range benchmarkTemplates {
+			excellent.EvaluateTemplate(env, context, template)
+		}
+	}
+}
+
+func BenchmarkEvaluateTemplateCompiled(b *testing.B) {
+	env := envs.NewBuilder().Build()
+	context := types.NewXObject(map[string]types.XValue{"foo": types.NewXText("bar"), "num": types.NewXNumberFromInt(3)})
+	cache := excellent.NewTemplateCache(10)
+
+	for n := 0; n < b.N; n++ {
+		for _, template := range benchmarkTemplates {
+			cache.Get(template, context.Properties()).Evaluate(env, context)
+		}
+	}
+}
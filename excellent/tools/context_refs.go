@@ -2,7 +2,9 @@ package tools
 
 import (
 	"strconv"
+	"strings"
 
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/gen"
@@ -10,19 +12,21 @@ import (
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 )
 
-// FindContextRefsInTemplate audits context references in the given template
-func FindContextRefsInTemplate(template string, allowedTopLevels []string, callback func([]string)) error {
+// FindContextRefsInTemplate audits context references in the given template. Names of functions available in
+// the given environment, which may carry an engine's registry of functions, aren't reported as references. If
+// the environment is nil, only builtin functions are considered.
+func FindContextRefsInTemplate(env envs.Environment, template string, allowedTopLevels []string, callback func([]string)) error {
 	return excellent.VisitTemplate(template, allowedTopLevels, func(tokenType excellent.XTokenType, token string) error {
 		switch tokenType {
 		case excellent.IDENTIFIER, excellent.EXPRESSION:
-			return findContextRefsInTemplate(token, callback)
+			return findContextRefsInTemplate(env, token, callback)
 		}
 		return nil
 	})
 }
 
-func findContextRefsInTemplate(expression string, callback func([]string)) error {
-	visitor := &auditContextVisitor{callback: callback}
+func findContextRefsInTemplate(env envs.Environment, expression string, callback func([]string)) error {
+	visitor := &auditContextVisitor{env: env, callback: callback}
 
 	_, err := excellent.VisitExpression(expression, visitor)
 
@@ -33,7 +37,11 @@ func findContextRefsInTemplate(expression string, callback func([]string)) error
 type auditContextVisitor struct {
 	gen.BaseExcellent2Visitor
 
+	env      envs.Environment
 	callback func([]string)
+
+	// parameters of the anonymous functions we're inside of, which aren't context references
+	locals map[string]int
 }
 
 // Visit the top level parse tree
@@ -57,7 +65,11 @@ func (v *auditContextVisitor) VisitParse(ctx *gen.ParseContext) interface{} {
 func (v *auditContextVisitor) VisitContextReference(ctx *gen.ContextReferenceContext) interface{} {
 	name := ctx.NAME().GetText()
 
-	function := functions.Lookup(name)
+	if v.locals[strings.ToLower(name)] > 0 {
+		return nil
+	}
+
+	function := functions.LookupIn(v.env, name)
 	if function == nil {
 		path := []string{name}
 		v.callback(path)
@@ -160,3 +172,21 @@ func (v *auditContextVisitor) VisitEquality(ctx *gen.EqualityContext) interface{
 func (v *auditContextVisitor) VisitComparison(ctx *gen.ComparisonContext) interface{} {
 	return v.VisitChildren(ctx)
 }
+
+// VisitAnonymousFunction deals with anonymous functions like (x) => x * 2
+func (v *auditContextVisitor) VisitAnonymousFunction(ctx *gen.AnonymousFunctionContext) interface{} {
+	if v.locals == nil {
+		v.locals = make(map[string]int)
+	}
+
+	for _, name := range ctx.AllNAME() {
+		v.locals[strings.ToLower(name.GetText())]++
+	}
+
+	v.Visit(ctx.Expression())
+
+	for _, name := range ctx.AllNAME() {
+		v.locals[strings.ToLower(name.GetText())]--
+	}
+	return nil
+}
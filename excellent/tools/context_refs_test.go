@@ -3,9 +3,13 @@ package tools_test
 import (
 	"testing"
 
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/tools"
+	"github.com/nyaruka/goflow/excellent/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFindContextRefsInTemplate(t *testing.T) {
@@ -24,12 +28,16 @@ func TestFindContextRefsInTemplate(t *testing.T) {
 		{`@(3 * (foo.bar + 1) / 2)`, [][]string{{`foo`}, {`foo`, `bar`}}, false},
 		{`@("foo.bar")`, [][]string{}, false},
 		{`@(webhook.0.kd_prov)`, [][]string{[]string{"webhook"}, []string{"webhook", "0"}, []string{"webhook", "0", "kd_prov"}}, false},
+		{`@(map(foo.items, (x) => x.price * foo.rate))`, [][]string{{`foo`}, {`foo`, `items`}, {`foo`}, {`foo`, `rate`}}, false},
+		{`@(foreach(foo, (x, y) => x & y) & x)`, [][]string{{`foo`}, {`x`}}, false},
 	}
 
+	env := envs.NewBuilder().Build()
+
 	for _, tc := range testCases {
 		actual := make([][]string, 0)
 
-		err := tools.FindContextRefsInTemplate(tc.template, []string{"foo"}, func(path []string) {
+		err := tools.FindContextRefsInTemplate(env, tc.template, []string{"foo"}, func(path []string) {
 			actual = append(actual, path)
 		})
 
@@ -42,3 +50,24 @@ func TestFindContextRefsInTemplate(t *testing.T) {
 		assert.Equal(t, tc.paths, actual, "audit context mismatch for input: %s", tc.template)
 	}
 }
+
+func TestFindContextRefsWithRegisteredFunctions(t *testing.T) {
+	registry := functions.NewRegistry()
+	registry.Register("shout", functions.OneTextFunction(func(env envs.Environment, s types.XText) types.XValue { return s }))
+
+	findRefs := func(env envs.Environment) [][]string {
+		actual := make([][]string, 0)
+		err := tools.FindContextRefsInTemplate(env, `@(shout(foo.bar))`, []string{"foo"}, func(path []string) {
+			actual = append(actual, path)
+		})
+		require.NoError(t, err)
+		return actual
+	}
+
+	// without the registry, the function name looks like a reference to the context
+	assert.Equal(t, [][]string{{`shout`}, {`foo`}, {`foo`, `bar`}}, findRefs(envs.NewBuilder().Build()))
+
+	// but with an environment which carries it, it's recognized as a function
+	env := functions.NewScopedEnvironment(envs.NewBuilder().Build(), registry)
+	assert.Equal(t, [][]string{{`foo`}, {`foo`, `bar`}}, findRefs(env))
+}
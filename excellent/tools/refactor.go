@@ -179,3 +179,13 @@ func (v *refactorVisitor) VisitEquality(ctx *gen.EqualityContext) interface{} {
 func (v *refactorVisitor) VisitComparison(ctx *gen.ComparisonContext) interface{} {
 	return fmt.Sprintf("%s %s %s", v.Visit(ctx.Expression(0)), ctx.GetOp().GetText(), v.Visit(ctx.Expression(1)))
 }
+
+// VisitAnonymousFunction deals with anonymous functions like (x) => x * 2
+func (v *refactorVisitor) VisitAnonymousFunction(ctx *gen.AnonymousFunctionContext) interface{} {
+	params := make([]string, len(ctx.AllNAME()))
+	for i, name := range ctx.AllNAME() {
+		params[i] = strings.ToLower(name.GetText())
+	}
+
+	return fmt.Sprintf("(%s) => %s", strings.Join(params, ", "), v.Visit(ctx.Expression()))
+}
@@ -30,6 +30,8 @@ func TestRefactorTemplate(t *testing.T) {
 		{`@(AND("x"="y", "x"!="y"))`, `@(and("x" = "y", "x" != "y"))`, false},
 		{`@(AND(1>2, 3<4, 5>=6, 7<=8))`, `@(and(1 > 2, 3 < 4, 5 >= 6, 7 <= 8))`, false},
 		{`@(FOO_Func(x, y))`, `@(foo_func(x, y))`, false},
+		{`@(( X,Y )=>X+Y)`, `@((x, y) => x + y)`, false},
+		{`@(( )=>1)`, `@(() => 1)`, false},
 		{`@(1 / ) @(1+2)`, `@(1 / ) @(1 + 2)`, true},
 	}
 
@@ -53,6 +53,7 @@ func TestToXDate(t *testing.T) {
 		{types.NewXError(errors.Errorf("Error")), types.XDateZero, true},
 		{types.NewXNumberFromInt(123), types.XDateZero, true},
 		{types.NewXText("2018-01-20"), types.NewXDate(dates.NewDate(2018, 1, 20)), false},
+		{types.NewXText("15 march"), types.XDateZero, true}, // dates written in words aren't converted
 		{types.NewXDate(dates.NewDate(2018, 4, 19)), types.NewXDate(dates.NewDate(2018, 4, 19)), false},
 		{types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXDate(dates.NewDate(2018, 4, 9)), false},
 		{types.NewXObject(map[string]types.XValue{
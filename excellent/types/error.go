@@ -51,6 +51,9 @@ func (x xerror) Native() error { return x.native }
 
 func (x xerror) Error() string { return x.Native().Error() }
 
+// Unwrap returns the underlying error
+func (x xerror) Unwrap() error { return x.native }
+
 // Equals determines equality for this type
 func (x xerror) Equals(other XError) bool {
 	return x.String() == other.String()
@@ -51,7 +51,7 @@ func (a *AddContactURNAction) Execute(run flows.FlowRun, step flows.Step, logMod
 	// only generate event if run has a contact
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -59,19 +59,19 @@ func (a *AddContactURNAction) Execute(run flows.FlowRun, step flows.Step, logMod
 
 	// if we received an error, log it although it might just be a non-expression like foo@bar.com
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 
 	evaluatedPath = strings.TrimSpace(evaluatedPath)
 	if evaluatedPath == "" {
-		logEvent(events.NewErrorf("can't add URN with empty path"))
+		logEvent(events.NewErrorf(run.Now(), "can't add URN with empty path"))
 		return nil
 	}
 
 	// if we don't have a valid URN, log error
 	urn, err := urns.NewURNFromParts(a.Scheme, evaluatedPath, "", "")
 	if err != nil {
-		logEvent(events.NewError(errors.Wrapf(err, "unable to add URN '%s:%s'", a.Scheme, evaluatedPath)))
+		logEvent(events.NewError(run.Now(), errors.Wrapf(err, "unable to add URN '%s:%s'", a.Scheme, evaluatedPath)))
 		return nil
 	}
 
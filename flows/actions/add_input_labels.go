@@ -47,7 +47,7 @@ func (a *AddInputLabelsAction) Execute(run flows.FlowRun, step flows.Step, logMo
 	// log error if we don't have any input that could be labeled
 	input := run.Session().Input()
 	if input == nil {
-		logEvent(events.NewErrorf("can't execute action in session without input"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without input"))
 		return nil
 	}
 
@@ -57,7 +57,7 @@ func (a *AddInputLabelsAction) Execute(run flows.FlowRun, step flows.Step, logMo
 	}
 
 	if len(labels) > 0 {
-		logEvent(events.NewInputLabelsAdded(input.UUID(), labels))
+		logEvent(events.NewInputLabelsAdded(run.Now(), input.UUID(), labels))
 	}
 
 	return nil
@@ -12,7 +12,6 @@ import (
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/utils"
-	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/pkg/errors"
@@ -30,6 +29,7 @@ var webhookStatusCategories = map[flows.CallStatus]string{
 	flows.CallStatusSuccess:         CategorySuccess,
 	flows.CallStatusResponseError:   CategoryFailure,
 	flows.CallStatusConnectionError: CategoryFailure,
+	flows.CallStatusAccessDenied:    CategoryFailure,
 	flows.CallStatusSubscriberGone:  CategoryFailure,
 }
 
@@ -76,7 +76,7 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 	localizedText := run.GetTranslatedTextArray(uuids.UUID(a.UUID()), "text", []string{actionText}, languages)[0]
 	evaluatedText, err := run.EvaluateTemplate(localizedText)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 
 	// localize and evaluate the message attachments
@@ -85,10 +85,10 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 	for _, a := range translatedAttachments {
 		evaluatedAttachment, err := run.EvaluateTemplate(a)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 		if evaluatedAttachment == "" {
-			logEvent(events.NewErrorf("attachment text evaluated to empty string, skipping"))
+			logEvent(events.NewErrorf(run.Now(), "attachment text evaluated to empty string, skipping"))
 			continue
 		}
 		evaluatedAttachments = append(evaluatedAttachments, utils.Attachment(evaluatedAttachment))
@@ -100,10 +100,10 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 	for _, qr := range translatedQuickReplies {
 		evaluatedQuickReply, err := run.EvaluateTemplate(qr)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 		if evaluatedQuickReply == "" {
-			logEvent(events.NewErrorf("quick reply text evaluated to empty string, skipping"))
+			logEvent(events.NewErrorf(run.Now(), "quick reply text evaluated to empty string, skipping"))
 			continue
 		}
 		evaluatedQuickReplies = append(evaluatedQuickReplies, evaluatedQuickReply)
@@ -114,9 +114,9 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 
 // helper to save a run result and log it as an event
 func (a *baseAction) saveResult(run flows.FlowRun, step flows.Step, name, value, category, categoryLocalized string, input string, extra json.RawMessage, logEvent flows.EventCallback) {
-	result := flows.NewResult(name, value, category, categoryLocalized, step.NodeUUID(), input, extra, dates.Now())
+	result := flows.NewResult(name, value, category, categoryLocalized, step.NodeUUID(), input, extra, run.Session().Engine().NowSource().Now())
 	run.SaveResult(result)
-	logEvent(events.NewRunResultChanged(result))
+	logEvent(events.NewRunResultChanged(run.Now(), result))
 }
 
 // helper to save a run result based on a webhook call and log it as an event
@@ -138,7 +138,7 @@ func (a *baseAction) applyModifier(run flows.FlowRun, mod flows.Modifier, logMod
 // helper to log a failure
 func (a *baseAction) fail(run flows.FlowRun, err error, logEvent flows.EventCallback) {
 	run.Exit(flows.RunStatusFailed)
-	logEvent(events.NewFailure(err))
+	logEvent(events.NewFailure(run.Now(), err))
 }
 
 // utility struct which sets the allowed flow types to any
@@ -203,7 +203,7 @@ func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flow
 	for _, legacyVar := range a.LegacyVars {
 		evaluatedLegacyVar, err := run.EvaluateTemplate(legacyVar)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 
 		if uuidRegex.MatchString(evaluatedLegacyVar) {
@@ -223,7 +223,7 @@ func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flow
 				// if that fails, assume this is a phone number, and let the caller worry about validation
 				urn, err := urns.NewURNFromParts(urns.TelScheme, evaluatedLegacyVar, "", "")
 				if err != nil {
-					logEvent(events.NewError(err))
+					logEvent(events.NewError(run.Now(), err))
 				} else {
 					urn = urn.Normalize(string(run.Environment().DefaultCountry()))
 					urnList = append(urnList, urn)
@@ -260,19 +260,19 @@ func resolveGroups(run flows.FlowRun, references []*assets.GroupReference, stati
 			// group is an expression that evaluates to an existing group's name
 			evaluatedGroupName, err := run.EvaluateTemplate(ref.NameMatch)
 			if err != nil {
-				logEvent(events.NewError(err))
+				logEvent(events.NewError(run.Now(), err))
 			} else {
 				// look up the set of all groups to see if such a group exists
 				group = groupSet.FindByName(evaluatedGroupName)
 				if group == nil {
-					logEvent(events.NewErrorf("no such group with name '%s'", evaluatedGroupName))
+					logEvent(events.NewErrorf(run.Now(), "no such group with name '%s'", evaluatedGroupName))
 				}
 			}
 		}
 
 		if group != nil {
 			if staticOnly && group.IsDynamic() {
-				logEvent(events.NewErrorf("can't add or remove contacts from a dynamic group '%s'", group.Name()))
+				logEvent(events.NewErrorf(run.Now(), "can't add or remove contacts from a dynamic group '%s'", group.Name()))
 			} else {
 				groups = append(groups, group)
 			}
@@ -297,12 +297,12 @@ func resolveLabels(run flows.FlowRun, references []*assets.LabelReference, logEv
 			// label is an expression that evaluates to an existing label's name
 			evaluatedLabelName, err := run.EvaluateTemplate(ref.NameMatch)
 			if err != nil {
-				logEvent(events.NewError(err))
+				logEvent(events.NewError(run.Now(), err))
 			} else {
 				// look up the set of all labels to see if such a label exists
 				label = labelSet.FindByName(evaluatedLabelName)
 				if label == nil {
-					logEvent(events.NewErrorf("no such label with name '%s'", evaluatedLabelName))
+					logEvent(events.NewErrorf(run.Now(), "no such label with name '%s'", evaluatedLabelName))
 				}
 			}
 		}
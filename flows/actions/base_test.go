@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"sort"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/services/airtime/dtone"
 	"github.com/nyaruka/goflow/services/classification/wit"
+	"github.com/nyaruka/goflow/services/credentials"
 	"github.com/nyaruka/goflow/services/webhooks"
 	"github.com/nyaruka/goflow/test"
 	"github.com/nyaruka/goflow/utils"
@@ -210,7 +212,7 @@ func testActionType(t *testing.T, assetsJSON json.RawMessage, typeName string) {
 
 		// create an engine instance
 		eng := engine.NewBuilder().
-			WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000)).
+			WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000, nil, nil, nil)).
 			WithClassificationServiceFactory(func(s flows.Session, c *flows.Classifier) (flows.ClassificationService, error) {
 				if c.Type() == "wit" {
 					return wit.NewService(c, "123456789"), nil
@@ -220,6 +222,13 @@ func testActionType(t *testing.T, assetsJSON json.RawMessage, typeName string) {
 			WithAirtimeServiceFactory(func(flows.Session) (flows.AirtimeService, error) {
 				return dtone.NewService("nyaruka", "123456789", "RWF"), nil
 			}).
+			WithLLMServiceFactory(func(flows.Session) (flows.LLMService, error) {
+				return test.NewLLMService(), nil
+			}).
+			WithCredentialServiceFactory(credentials.NewServiceFactory(map[string]flows.Credential{
+				"acme_oauth": credentials.NewOAuth2ClientCredentials(http.DefaultClient, "http://oauth.temba.io/token", "goflow", "sesame", []string{"webhooks"}),
+				"acme_hmac":  credentials.NewHMACSigner("sesame", "X-Signature"),
+			})).
 			Build()
 
 		// create session
@@ -346,6 +355,21 @@ func TestConstructors(t *testing.T) {
 			"result_name": "Intent"
 		}`,
 		},
+		{
+			actions.NewCallLLM(
+				actionUUID,
+				"Translate to French",
+				"@input.text",
+				"Translation",
+			),
+			`{
+			"type": "call_llm",
+			"uuid": "ad154980-7bf7-4ab8-8728-545fd6378912",
+			"instructions": "Translate to French",
+			"input": "@input.text",
+			"result_name": "Translation"
+		}`,
+		},
 		{
 			actions.NewCallResthook(
 				actionUUID,
@@ -369,6 +393,10 @@ func TestConstructors(t *testing.T) {
 				},
 				`{"contact_id": 234}`, // body
 				"Webhook Response",
+				"acme_oauth",
+				[]*actions.WebhookMapping{
+					actions.NewWebhookMapping("Name", "response.name"),
+				},
 			),
 			`{
 			"type": "call_webhook",
@@ -379,7 +407,11 @@ func TestConstructors(t *testing.T) {
 				"Authentication": "Token @fields.token"
 			},
 			"body": "{\"contact_id\": 234}",
-			"result_name": "Webhook Response"
+			"result_name": "Webhook Response",
+			"credential": "acme_oauth",
+			"mappings": [
+				{"result_name": "Name", "expression": "response.name"}
+			]
 		}`,
 		},
 		{
@@ -61,12 +61,12 @@ func (a *CallClassifierAction) Execute(run flows.FlowRun, step flows.Step, logMo
 	// substitute any variables in our input
 	input, err := run.EvaluateTemplate(a.Input)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 
 	classification, skipped, err := a.classify(run, step, input, classifier, logEvent)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 
 		if skipped {
 			a.saveSkipped(run, step, input, logEvent)
@@ -98,7 +98,7 @@ func (a *CallClassifierAction) classify(run flows.FlowRun, step flows.Step, inpu
 	classification, err := svc.Classify(run.Session(), input, httpLogger.Log)
 
 	if len(httpLogger.Logs) > 0 {
-		logEvent(events.NewClassifierCalled(classifier.Reference(), httpLogger.Logs))
+		logEvent(events.NewClassifierCalled(run.Now(), classifier.Reference(), httpLogger.Logs))
 	}
 
 	return classification, false, err
@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registerType(TypeCallLLM, func() flows.Action { return &CallLLMAction{} })
+}
+
+var llmCategories = []string{CategorySuccess, CategoryFailure}
+
+// TypeCallLLM is the type for the call LLM action
+const TypeCallLLM string = "call_llm"
+
+// CallLLMAction can be used to call an LLM service to generate or transform text. The instructions and input are
+// evaluated and sent to the service, and the output is saved as a result with a category of success or failure.
+//
+//   {
+//     "uuid": "3cd8f2db-8429-462e-ab93-8041dd23abf1",
+//     "type": "call_llm",
+//     "instructions": "Translate to French",
+//     "input": "@input.text",
+//     "result_name": "Translation"
+//   }
+//
+// @action call_llm
+type CallLLMAction struct {
+	baseAction
+	onlineAction
+
+	Instructions string `json:"instructions" validate:"required" engine:"evaluated"`
+	Input        string `json:"input" engine:"evaluated"`
+	ResultName   string `json:"result_name" validate:"required"`
+}
+
+// NewCallLLM creates a new call LLM action
+func NewCallLLM(uuid flows.ActionUUID, instructions, input, resultName string) *CallLLMAction {
+	return &CallLLMAction{
+		baseAction:   newBaseAction(TypeCallLLM, uuid),
+		Instructions: instructions,
+		Input:        input,
+		ResultName:   resultName,
+	}
+}
+
+// Execute runs this action
+func (a *CallLLMAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+	// substitute any variables in our instructions and input
+	instructions, err := run.EvaluateTemplate(a.Instructions)
+	if err != nil {
+		logEvent(events.NewError(run.Now(), err))
+	}
+	input, err := run.EvaluateTemplate(a.Input)
+	if err != nil {
+		logEvent(events.NewError(run.Now(), err))
+	}
+
+	response, err := a.call(run, instructions, input, logEvent)
+	if err != nil {
+		logEvent(events.NewError(run.Now(), err))
+
+		a.saveResult(run, step, a.ResultName, "", CategoryFailure, "", input, nil, logEvent)
+	} else {
+		a.saveResult(run, step, a.ResultName, response.Output, CategorySuccess, "", input, nil, logEvent)
+	}
+
+	return nil
+}
+
+func (a *CallLLMAction) call(run flows.FlowRun, instructions, input string, logEvent flows.EventCallback) (*flows.LLMResponse, error) {
+	if instructions == "" {
+		return nil, errors.New("can't call LLM with empty instructions")
+	}
+
+	svc, err := run.Session().Engine().Services().LLM(run.Session())
+	if err != nil {
+		return nil, err
+	}
+
+	httpLogger := &flows.HTTPLogger{}
+
+	response, err := svc.Response(run.Session(), instructions, input, httpLogger.Log)
+
+	output := ""
+	if response != nil {
+		output = response.Output
+	}
+
+	logEvent(events.NewLLMCalled(run.Now(), instructions, input, output, httpLogger.Logs))
+
+	return response, err
+}
+
+// Results enumerates any results generated by this flow object
+func (a *CallLLMAction) Results(node flows.Node, include func(*flows.ResultInfo)) {
+	include(flows.NewResultInfo(a.ResultName, llmCategories, node))
+}
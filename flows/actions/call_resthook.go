@@ -101,7 +101,7 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 	}
 
 	// regardless of what subscriber calls we make, we need to record the payload that would be sent
-	logEvent(events.NewResthookCalled(a.Resthook, json.RawMessage(payload)))
+	logEvent(events.NewResthookCalled(run.Now(), a.Resthook, json.RawMessage(payload)))
 
 	// make a call to each subscriber URL
 	calls := make([]*flows.WebhookCall, 0, len(resthook.Subscribers()))
@@ -109,7 +109,7 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 	for _, url := range resthook.Subscribers() {
 		req, err := http.NewRequest("POST", url, strings.NewReader(payload))
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 			return nil
 		}
 
@@ -117,18 +117,18 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 		svc, err := run.Session().Engine().Services().Webhook(run.Session())
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 			return nil
 		}
 
 		call, err := svc.Call(run.Session(), req, a.Resthook)
 
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 		if call != nil {
 			calls = append(calls, call)
-			logEvent(events.NewWebhookCalled(call))
+			logEvent(events.NewWebhookCalled(run.Now(), call))
 		}
 	}
 
@@ -1,11 +1,16 @@
 package actions
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/tools"
+	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/utils"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/http/httpguts"
@@ -22,7 +27,11 @@ const TypeCallWebhook string = "call_webhook"
 // templates and will be evaluated at runtime. A [event:webhook_called] event will be created based on
 // the results of the HTTP call. If this action has a `result_name`, then addtionally it will create
 // a new result with that name. If the webhook returned valid JSON, that will be accessible
-// through `extra` on the result.
+// through `extra` on the result. If this action has a `credential`, then the named credential provided by the
+// engine will be used to authorize the request, e.g. by adding an OAuth2 bearer token or signing the body, and any
+// secrets it adds will be redacted from the event. If this action has `mappings`, then each of those will create
+// a result whose value is the evaluation of an expression against the JSON response, e.g. `response.data.name`,
+// and whose category is success if that gave a value and failure otherwise.
 //
 //   {
 //     "uuid": "8eebd020-1af5-431c-b943-aa670fc74da9",
@@ -32,7 +41,10 @@ const TypeCallWebhook string = "call_webhook"
 //     "headers": {
 //       "Authorization": "Token AAFFZZHH"
 //     },
-//     "result_name": "webhook"
+//     "result_name": "webhook",
+//     "mappings": [
+//       {"result_name": "Ok", "expression": "response.ok"}
+//     ]
 //   }
 //
 // @action call_webhook
@@ -45,10 +57,23 @@ type CallWebhookAction struct {
 	Headers    map[string]string `json:"headers,omitempty" engine:"evaluated"`
 	Body       string            `json:"body,omitempty" engine:"evaluated"`
 	ResultName string            `json:"result_name,omitempty"`
+	Credential string            `json:"credential,omitempty"`
+	Mappings   []*WebhookMapping `json:"mappings,omitempty" validate:"dive"`
+}
+
+// WebhookMapping maps a value in a webhook's JSON response to a result
+type WebhookMapping struct {
+	ResultName string `json:"result_name" validate:"required"`
+	Expression string `json:"expression" validate:"required"`
+}
+
+// NewWebhookMapping creates a new webhook mapping
+func NewWebhookMapping(resultName, expression string) *WebhookMapping {
+	return &WebhookMapping{ResultName: resultName, Expression: expression}
 }
 
 // NewCallWebhook creates a new call webhook action
-func NewCallWebhook(uuid flows.ActionUUID, method string, url string, headers map[string]string, body string, resultName string) *CallWebhookAction {
+func NewCallWebhook(uuid flows.ActionUUID, method string, url string, headers map[string]string, body string, resultName string, credential string, mappings []*WebhookMapping) *CallWebhookAction {
 	return &CallWebhookAction{
 		baseAction: newBaseAction(TypeCallWebhook, uuid),
 		Method:     method,
@@ -56,6 +81,8 @@ func NewCallWebhook(uuid flows.ActionUUID, method string, url string, headers ma
 		Headers:    headers,
 		Body:       body,
 		ResultName: resultName,
+		Credential: credential,
+		Mappings:   mappings,
 	}
 }
 
@@ -71,6 +98,13 @@ func (a *CallWebhookAction) Validate() error {
 		}
 	}
 
+	for _, m := range a.Mappings {
+		err := tools.FindContextRefsInTemplate(nil, "@("+m.Expression+")", []string{"response"}, func([]string) {})
+		if err != nil {
+			return errors.Wrapf(err, "invalid expression for mapping to result '%s'", m.ResultName)
+		}
+	}
+
 	return nil
 }
 
@@ -80,10 +114,10 @@ func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModif
 	// substitute any variables in our url
 	url, err := run.EvaluateTemplate(a.URL)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 	if url == "" {
-		logEvent(events.NewErrorf("call_webhook URL evaluated to empty string, skipping"))
+		logEvent(events.NewErrorf(run.Now(), "call_webhook URL evaluated to empty string, skipping"))
 		return nil
 	}
 
@@ -94,7 +128,7 @@ func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModif
 	if body != "" {
 		body, err = run.EvaluateTemplate(body)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 	}
 
@@ -108,36 +142,97 @@ func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModif
 	for key, value := range a.Headers {
 		headerValue, err := run.EvaluateTemplate(value)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 
 		req.Header.Add(key, headerValue)
 	}
 
+	// if we have a credential, use it to authorize the request
+	var secrets []string
+	if a.Credential != "" {
+		secrets, err = a.authorize(run, req)
+		if err != nil {
+			logEvent(events.NewError(run.Now(), err))
+			return nil
+		}
+	}
+
 	svc, err := run.Session().Engine().Services().Webhook(run.Session())
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 		return nil
 	}
 
 	call, err := svc.Call(run.Session(), req, "")
 
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 	if call != nil {
-		logEvent(events.NewWebhookCalled(call))
+		call.RedactSecrets(secrets)
+
+		logEvent(events.NewWebhookCalled(run.Now(), call))
 		if a.ResultName != "" {
 			a.saveWebhookResult(run, step, a.ResultName, call, logEvent)
 		}
+		if len(a.Mappings) > 0 {
+			a.saveMappedResults(run, step, call, logEvent)
+		}
 	}
 
 	return nil
 }
 
+// saves a result for each of our mappings by evaluating its expression against the response
+func (a *CallWebhookAction) saveMappedResults(run flows.FlowRun, step flows.Step, call *flows.WebhookCall, logEvent flows.EventCallback) {
+	input := fmt.Sprintf("%s %s", call.Method, call.URL)
+
+	var response types.XValue
+	if call.Status == flows.CallStatusSuccess {
+		response = types.JSONToXValue(utils.ExtractResponseJSON(call.Response))
+	}
+	context := types.NewXObject(map[string]types.XValue{"response": response})
+
+	for _, m := range a.Mappings {
+		value, category := "", CategoryFailure
+
+		if response != nil {
+			result := excellent.EvaluateExpression(run.Environment(), context, m.Expression)
+			if result != nil && !types.IsXError(result) {
+				value, category = types.Render(result), CategorySuccess
+			}
+		}
+
+		a.saveResult(run, step, m.ResultName, value, category, "", input, nil, logEvent)
+	}
+}
+
+// authorizes the given request using our credential
+func (a *CallWebhookAction) authorize(run flows.FlowRun, req *http.Request) ([]string, error) {
+	svc, err := run.Session().Engine().Services().Credentials(run.Session())
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := svc.Credential(run.Session(), a.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := credential.Authorize(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to authorize request with credential '%s'", a.Credential)
+	}
+	return secrets, nil
+}
+
 // Results enumerates any results generated by this flow object
 func (a *CallWebhookAction) Results(node flows.Node, include func(*flows.ResultInfo)) {
 	if a.ResultName != "" {
 		include(flows.NewResultInfo(a.ResultName, webhookCategories, node))
 	}
+	for _, m := range a.Mappings {
+		include(flows.NewResultInfo(m.ResultName, webhookCategories, node))
+	}
 }
@@ -60,6 +60,6 @@ func (a *EnterFlowAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	}
 
 	run.Session().PushFlow(flow, run, a.Terminal)
-	logEvent(events.NewFlowEntered(a.Flow, run.UUID(), a.Terminal))
+	logEvent(events.NewFlowEntered(run.Now(), a.Flow, run.UUID(), a.Terminal))
 	return nil
 }
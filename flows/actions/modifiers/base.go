@@ -44,12 +44,12 @@ func (m *baseModifier) reevaluateDynamicGroups(env envs.Environment, assets flow
 
 	// add error event for each group we couldn't re-evaluate
 	for _, err := range errors {
-		log(events.NewError(err))
+		log(events.NewError(env.Now().UTC(), err))
 	}
 
 	// add groups changed event for the groups we were added/removed to/from
 	if len(added) > 0 || len(removed) > 0 {
-		log(events.NewContactGroupsChanged(added, removed))
+		log(events.NewContactGroupsChanged(env.Now().UTC(), added, removed))
 	}
 }
 
@@ -36,7 +36,7 @@ func NewChannel(channel *flows.Channel) *ChannelModifier {
 func (m *ChannelModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
 	// if URNs change in anyway, generate a URNs changed event
 	if contact.UpdatePreferredChannel(m.channel) {
-		log(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+		log(events.NewContactURNsChanged(env.Now().UTC(), contact.URNs().RawURNs()))
 	}
 }
 
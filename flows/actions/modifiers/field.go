@@ -45,7 +45,7 @@ func (m *FieldModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 		}
 
 		contact.Fields().Set(m.field, m.value)
-		log(events.NewContactFieldChanged(m.field, m.value))
+		log(events.NewContactFieldChanged(env.Now().UTC(), m.field, m.value))
 		m.reevaluateDynamicGroups(env, assets, contact, log)
 	}
 }
@@ -60,7 +60,7 @@ func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 
 		// only generate event if contact's groups change
 		if len(diff) > 0 {
-			log(events.NewContactGroupsChanged(diff, nil))
+			log(events.NewContactGroupsChanged(env.Now().UTC(), diff, nil))
 		}
 	} else if m.modification == GroupsRemove {
 		for _, group := range m.groups {
@@ -75,7 +75,7 @@ func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 
 		// only generate event if contact's groups change
 		if len(diff) > 0 {
-			log(events.NewContactGroupsChanged(nil, diff))
+			log(events.NewContactGroupsChanged(env.Now().UTC(), nil, diff))
 		}
 	}
 }
@@ -36,7 +36,7 @@ func NewLanguage(language envs.Language) *LanguageModifier {
 func (m *LanguageModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
 	if contact.Language() != m.Language {
 		contact.SetLanguage(m.Language)
-		log(events.NewContactLanguageChanged(m.Language))
+		log(events.NewContactLanguageChanged(env.Now().UTC(), m.Language))
 		m.reevaluateDynamicGroups(env, assets, contact, log)
 	}
 }
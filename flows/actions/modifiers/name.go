@@ -41,7 +41,7 @@ func (m *NameModifier) Apply(env envs.Environment, assets flows.SessionAssets, c
 		}
 
 		contact.SetName(m.Name)
-		log(events.NewContactNameChanged(m.Name))
+		log(events.NewContactNameChanged(env.Now().UTC(), m.Name))
 		m.reevaluateDynamicGroups(env, assets, contact, log)
 	}
 }
@@ -37,7 +37,7 @@ func NewTimezone(timezone *time.Location) *TimezoneModifier {
 func (m *TimezoneModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
 	if !timezonesEqual(contact.Timezone(), m.timezone) {
 		contact.SetTimezone(m.timezone)
-		log(events.NewContactTimezoneChanged(m.timezone))
+		log(events.NewContactTimezoneChanged(env.Now().UTC(), m.timezone))
 		m.reevaluateDynamicGroups(env, assets, contact, log)
 	}
 }
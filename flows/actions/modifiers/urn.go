@@ -47,7 +47,7 @@ func NewURN(urn urns.URN, modification URNModification) *URNModifier {
 func (m *URNModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
 	contactURN := flows.NewContactURN(m.URN.Normalize(string(env.DefaultCountry())), nil)
 	if contact.AddURN(contactURN) {
-		log(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+		log(events.NewContactURNsChanged(env.Now().UTC(), contact.URNs().RawURNs()))
 		m.reevaluateDynamicGroups(env, assets, contact, log)
 	}
 }
@@ -49,13 +49,13 @@ func (a *PlayAudioAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	localizedAudioURL := run.GetText(uuids.UUID(a.UUID()), "audio_url", a.AudioURL)
 	evaluatedAudioURL, err := run.EvaluateTemplate(localizedAudioURL)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 		return nil
 	}
 
 	evaluatedAudioURL = strings.TrimSpace(evaluatedAudioURL)
 	if evaluatedAudioURL == "" {
-		logEvent(events.NewErrorf("audio URL evaluated to empty, skipping"))
+		logEvent(events.NewErrorf(run.Now(), "audio URL evaluated to empty, skipping"))
 		return nil
 	}
 
@@ -65,7 +65,7 @@ func (a *PlayAudioAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	// if we have an audio URL, turn it into a message
 	attachments := []utils.Attachment{utils.Attachment(fmt.Sprintf("audio:%s", evaluatedAudioURL))}
 	msg := flows.NewMsgOut(connection.URN(), connection.Channel(), "", attachments, nil, nil)
-	logEvent(events.NewIVRCreated(msg))
+	logEvent(events.NewIVRCreated(run.Now(), msg))
 
 	return nil
 }
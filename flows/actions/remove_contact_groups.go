@@ -59,7 +59,7 @@ func (a *RemoveContactGroupsAction) Validate() error {
 func (a *RemoveContactGroupsAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
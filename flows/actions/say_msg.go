@@ -54,7 +54,7 @@ func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier f
 	localizedText := run.GetText(uuids.UUID(a.UUID()), "text", a.Text)
 	evaluatedText, err := run.EvaluateTemplate(localizedText)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 	evaluatedText = strings.TrimSpace(evaluatedText)
 
@@ -63,7 +63,7 @@ func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier f
 
 	// if we have neither an audio URL or backdown text, skip
 	if evaluatedText == "" && localizedAudioURL == "" {
-		logEvent(events.NewErrorf("need either audio URL or backdown text, skipping"))
+		logEvent(events.NewErrorf(run.Now(), "need either audio URL or backdown text, skipping"))
 		return nil
 	}
 
@@ -76,7 +76,7 @@ func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier f
 	connection := run.Session().Trigger().Connection()
 
 	msg := flows.NewMsgOut(connection.URN(), connection.Channel(), evaluatedText, attachments, nil, nil)
-	logEvent(events.NewIVRCreated(msg))
+	logEvent(events.NewIVRCreated(run.Now(), msg))
 
 	return nil
 }
@@ -78,7 +78,7 @@ func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logMod
 
 	// if we have any recipients, log an event
 	if len(urnList) > 0 || len(contactRefs) > 0 || len(groupRefs) > 0 {
-		logEvent(events.NewBroadcastCreated(translations, run.Flow().Language(), groupRefs, contactRefs, urnList))
+		logEvent(events.NewBroadcastCreated(run.Now(), translations, run.Flow().Language(), groupRefs, contactRefs, urnList))
 	}
 
 	return nil
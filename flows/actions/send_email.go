@@ -54,7 +54,7 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	localizedSubject := run.GetText(uuids.UUID(a.UUID()), "subject", a.Subject)
 	evaluatedSubject, err := run.EvaluateTemplate(localizedSubject)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 
 	// make sure the subject is single line - replace '\t\n\r\f\v' to ' '
@@ -62,17 +62,17 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	evaluatedSubject = strings.TrimSpace(evaluatedSubject)
 
 	if evaluatedSubject == "" {
-		logEvent(events.NewErrorf("email subject evaluated to empty string, skipping"))
+		logEvent(events.NewErrorf(run.Now(), "email subject evaluated to empty string, skipping"))
 		return nil
 	}
 
 	localizedBody := run.GetText(uuids.UUID(a.UUID()), "body", a.Body)
 	evaluatedBody, err := run.EvaluateTemplate(localizedBody)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 	}
 	if evaluatedBody == "" {
-		logEvent(events.NewErrorf("email body evaluated to empty string, skipping"))
+		logEvent(events.NewErrorf(run.Now(), "email body evaluated to empty string, skipping"))
 		return nil
 	}
 
@@ -81,10 +81,10 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	for _, address := range a.Addresses {
 		evaluatedAddress, err := run.EvaluateTemplate(address)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 		}
 		if evaluatedAddress == "" {
-			logEvent(events.NewErrorf("email address evaluated to empty string, skipping"))
+			logEvent(events.NewErrorf(run.Now(), "email address evaluated to empty string, skipping"))
 			continue
 		}
 
@@ -97,7 +97,7 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	}
 
 	if len(evaluatedAddresses) > 0 {
-		logEvent(events.NewEmailCreated(evaluatedAddresses, evaluatedSubject, evaluatedBody))
+		logEvent(events.NewEmailCreated(run.Now(), evaluatedAddresses, evaluatedSubject, evaluatedBody))
 	}
 
 	return nil
@@ -68,7 +68,7 @@ func NewSendMsg(uuid flows.ActionUUID, text string, attachments []string, quickR
 // Execute runs this action
 func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	if run.Contact() == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -96,7 +96,7 @@ func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier
 				for i, t := range a.Templating.Variables {
 					sub, err := run.EvaluateTemplate(t)
 					if err != nil {
-						logEvent(events.NewError(err))
+						logEvent(events.NewError(run.Now(), err))
 					}
 					templateVariables[i] = sub
 				}
@@ -107,14 +107,14 @@ func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier
 		}
 
 		msg := flows.NewMsgOut(dest.URN.URN(), channelRef, evaluatedText, evaluatedAttachments, evaluatedQuickReplies, templating)
-		logEvent(events.NewMsgCreated(msg))
+		logEvent(events.NewMsgCreated(run.Now(), msg))
 	}
 
 	// if we couldn't find a destination, create a msg without a URN or channel and it's up to the caller
 	// to handle that as they want
 	if len(destinations) == 0 {
 		msg := flows.NewMsgOut(urns.NilURN, nil, evaluatedText, evaluatedAttachments, evaluatedQuickReplies, nil)
-		logEvent(events.NewMsgCreated(msg))
+		logEvent(events.NewMsgCreated(run.Now(), msg))
 	}
 
 	return nil
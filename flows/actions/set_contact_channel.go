@@ -45,7 +45,7 @@ func NewSetContactChannel(uuid flows.ActionUUID, channel *assets.ChannelReferenc
 func (a *SetContactChannelAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -45,7 +45,7 @@ func NewSetContactLanguage(uuid flows.ActionUUID, language string) *SetContactLa
 // Execute runs this action
 func (a *SetContactLanguageAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	if run.Contact() == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -54,7 +54,7 @@ func (a *SetContactLanguageAction) Execute(run flows.FlowRun, step flows.Step, l
 
 	// if we received an error, log it
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 		return nil
 	}
 
@@ -63,7 +63,7 @@ func (a *SetContactLanguageAction) Execute(run flows.FlowRun, step flows.Step, l
 	if language != "" {
 		lang, err = envs.ParseLanguage(language)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent(events.NewError(run.Now(), err))
 			return nil
 		}
 	}
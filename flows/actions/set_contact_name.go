@@ -44,7 +44,7 @@ func NewSetContactName(uuid flows.ActionUUID, name string) *SetContactNameAction
 // Execute runs this action
 func (a *SetContactNameAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	if run.Contact() == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent(events.NewErrorf(run.Now(), "can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -53,7 +53,7 @@ func (a *SetContactNameAction) Execute(run flows.FlowRun, step flows.Step, logMo
 
 	// if we received an error, log it
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 		return nil
 	}
 
@@ -55,7 +55,7 @@ func (a *SetRunResultAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 	// log any error received
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 		return nil
 	}
 
@@ -67,7 +67,7 @@ func (a *StartSessionAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 	// if we have any recipients, log an event
 	if len(urnList) > 0 || len(groupRefs) > 0 || len(contactRefs) > 0 || a.ContactQuery != "" || a.CreateContact {
-		logEvent(events.NewSessionTriggered(a.Flow, groupRefs, contactRefs, contactQuery, a.CreateContact, urnList, runSnapshot))
+		logEvent(events.NewSessionTriggered(run.Now(), a.Flow, groupRefs, contactRefs, contactQuery, a.CreateContact, urnList, runSnapshot))
 	}
 	return nil
 }
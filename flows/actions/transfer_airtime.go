@@ -51,7 +51,7 @@ func NewTransferAirtime(uuid flows.ActionUUID, amounts map[string]decimal.Decima
 func (a *TransferAirtimeAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	transfer, err := a.transfer(run, step, logEvent)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(run.Now(), err))
 
 		a.saveFailure(run, step, logEvent)
 	} else {
@@ -90,7 +90,7 @@ func (a *TransferAirtimeAction) transfer(run flows.FlowRun, step flows.Step, log
 
 	transfer, err := svc.Transfer(run.Session(), sender, telURNs[0].URN(), a.Amounts, httpLogger.Log)
 	if transfer != nil {
-		logEvent(events.NewAirtimeTransferred(transfer, httpLogger.Logs))
+		logEvent(events.NewAirtimeTransferred(run.Now(), transfer, httpLogger.Logs))
 	}
 
 	return transfer, err
@@ -12,7 +12,6 @@ import (
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
-	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/pkg/errors"
@@ -76,14 +75,15 @@ func NewContact(
 	}, nil
 }
 
-// NewEmptyContact creates a new empy contact with the passed in name, language and location
-func NewEmptyContact(sa SessionAssets, name string, language envs.Language, timezone *time.Location) *Contact {
+// NewEmptyContact creates a new empy contact with the passed in name, language and location, created at the given
+// time, e.g. the now of an engine's clock
+func NewEmptyContact(sa SessionAssets, name string, language envs.Language, timezone *time.Location, createdOn time.Time) *Contact {
 	return &Contact{
 		uuid:      ContactUUID(uuids.New()),
 		name:      name,
 		language:  language,
 		timezone:  timezone,
-		createdOn: dates.Now(),
+		createdOn: createdOn,
 		urns:      URNList{},
 		groups:    NewGroupList([]*Group{}),
 		fields:    make(FieldValues),
@@ -13,6 +13,7 @@ import (
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/stretchr/testify/assert"
@@ -114,7 +115,7 @@ func TestContactFormat(t *testing.T) {
 	sa, _ := engine.NewSessionAssets(static.NewEmptySource())
 
 	// name takes precedence if set
-	contact := flows.NewEmptyContact(sa, "Joe", envs.NilLanguage, nil)
+	contact := flows.NewEmptyContact(sa, "Joe", envs.NilLanguage, nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("twitter:joey"), nil))
 	assert.Equal(t, "Joe", contact.Format(env))
 
@@ -132,7 +133,7 @@ func TestContactFormat(t *testing.T) {
 	assert.Equal(t, "1234", contact.Format(anonEnv))
 
 	// if we don't have name or URNs, then empty string
-	contact = flows.NewEmptyContact(sa, "", envs.NilLanguage, nil)
+	contact = flows.NewEmptyContact(sa, "", envs.NilLanguage, nil, dates.Now())
 	assert.Equal(t, "", contact.Format(env))
 }
 
@@ -144,7 +145,7 @@ func TestContactSetPreferredChannel(t *testing.T) {
 	twitter1 := test.NewChannel("Twitter", "nyaruka", []string{"twitter", "twitterid"}, roles, nil)
 	twitter2 := test.NewChannel("Twitter", "nyaruka", []string{"twitter", "twitterid"}, roles, nil)
 
-	contact := flows.NewEmptyContact(sa, "Joe", envs.NilLanguage, nil)
+	contact := flows.NewEmptyContact(sa, "Joe", envs.NilLanguage, nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("twitter:joey"), nil))
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+12345678999"), nil))
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+18005555777"), nil))
@@ -194,7 +195,7 @@ func TestReevaluateDynamicGroups(t *testing.T) {
 	twitterCrazies := test.NewGroup("Twitter Crazies", `twitter ~ crazy`)
 	groups := []*flows.Group{males, old, english, spanish, lastYear, tel1800, twitterCrazies}
 
-	contact := flows.NewEmptyContact(session.Assets(), "Joe", "eng", nil)
+	contact := flows.NewEmptyContact(session.Assets(), "Joe", "eng", nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+12345678999"), nil))
 
 	memberships, errors := evaluateGroups(t, env, contact, groups, fieldSet)
@@ -234,7 +235,7 @@ func TestReevaluateDynamicGroupsWithURNRedaction(t *testing.T) {
 	twitterCrazies := test.NewGroup("Twitter Crazies", `twitter ~ crazy`)
 	groups := []*flows.Group{males, tel1800, twitterCrazies}
 
-	contact := flows.NewEmptyContact(session.Assets(), "Joe", "eng", nil)
+	contact := flows.NewEmptyContact(session.Assets(), "Joe", "eng", nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+12345678999"), nil))
 
 	memberships, errors := evaluateGroups(t, env, contact, groups, fieldSet)
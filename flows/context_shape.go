@@ -0,0 +1,94 @@
+package flows
+
+import (
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/excellent/checker"
+	"github.com/nyaruka/goflow/excellent/functions"
+)
+
+var fieldTypeShapes = map[assets.FieldType]functions.Type{
+	assets.FieldTypeText:     functions.TypeText,
+	assets.FieldTypeNumber:   functions.TypeNumber,
+	assets.FieldTypeDatetime: functions.TypeDateTime,
+	assets.FieldTypeState:    functions.TypeText,
+	assets.FieldTypeDistrict: functions.TypeText,
+	assets.FieldTypeWard:     functions.TypeText,
+}
+
+// NewRunContextShape describes the shape of the context available to expressions in a run of a flow, which can
+// be used to statically check expressions. Contact fields are typed by their field type and results are limited
+// to those which can be set in the flow.
+func NewRunContextShape(fields []assets.Field, results []*ResultInfo) *checker.Shape {
+	text := checker.NewShape(functions.TypeText)
+	datetime := checker.NewShape(functions.TypeDateTime)
+
+	fieldShapes := make(map[string]*checker.Shape, len(fields))
+	for _, field := range fields {
+		fieldShapes[field.Key()] = checker.NewShape(fieldTypeShapes[field.Type()])
+	}
+	fieldsShape := checker.NewObjectShape(fieldShapes, functions.TypeText)
+
+	resultShape := checker.NewObjectShape(map[string]*checker.Shape{
+		"name":                 text,
+		"value":                text,
+		"values":               checker.NewArrayShape(text),
+		"category":             text,
+		"categories":           checker.NewArrayShape(text),
+		"category_localized":   text,
+		"categories_localized": checker.NewArrayShape(text),
+		"input":                text,
+		"extra":                checker.AnyShape,
+		"node_uuid":            text,
+		"created_on":           datetime,
+	}, functions.TypeText)
+
+	resultShapes := make(map[string]*checker.Shape, len(results))
+	for _, result := range results {
+		resultShapes[result.Key] = resultShape
+	}
+	resultsShape := checker.NewObjectShape(resultShapes, functions.TypeText)
+
+	contactShape := checker.NewObjectShape(map[string]*checker.Shape{
+		"uuid":       text,
+		"id":         text,
+		"name":       text,
+		"first_name": text,
+		"language":   text,
+		"timezone":   text,
+		"created_on": datetime,
+		"urns":       checker.NewArrayShape(text),
+		"urn":        text,
+		"groups": checker.NewArrayShape(checker.NewObjectShape(map[string]*checker.Shape{
+			"uuid": text,
+			"name": text,
+		}, "")),
+		"fields":  fieldsShape,
+		"channel": checker.NewDynamicObjectShape(functions.TypeText),
+	}, functions.TypeText)
+
+	runShape := checker.NewObjectShape(map[string]*checker.Shape{
+		"uuid":       text,
+		"contact":    contactShape,
+		"flow":       checker.NewDynamicObjectShape(functions.TypeText),
+		"status":     text,
+		"results":    resultsShape,
+		"path":       checker.NewArrayShape(checker.NewDynamicObjectShape("")),
+		"created_on": datetime,
+		"exited_on":  datetime,
+	}, functions.TypeText)
+
+	return checker.NewObjectShape(map[string]*checker.Shape{
+		"run":          runShape,
+		"child":        checker.NewDynamicObjectShape(functions.TypeText),
+		"parent":       checker.NewDynamicObjectShape(functions.TypeText),
+		"contact":      contactShape,
+		"results":      resultsShape,
+		"urns":         checker.NewDynamicObjectShape(functions.TypeText),
+		"fields":       fieldsShape,
+		"webhook":      checker.AnyShape,
+		"trigger":      checker.NewDynamicObjectShape(""),
+		"resume":       checker.NewDynamicObjectShape(""),
+		"input":        checker.NewDynamicObjectShape(functions.TypeText),
+		"legacy_extra": checker.NewDynamicObjectShape(""),
+	}, "")
+}
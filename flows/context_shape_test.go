@@ -0,0 +1,59 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/checker"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunContextShape(t *testing.T) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	fields := make([]assets.Field, 0)
+	for _, f := range session.Assets().Fields().All() {
+		fields = append(fields, f)
+	}
+
+	run := session.Runs()[0]
+	shape := flows.NewRunContextShape(fields, run.Flow().ExtractResults())
+
+	// all the templates in the flow should be valid
+	for _, template := range run.Flow().ExtractTemplates() {
+		assert.Equal(t, []*checker.Problem{}, checker.CheckTemplate(session.Environment(), shape, template), "unexpected problems in template: %s", template)
+	}
+
+	tcs := []struct {
+		template string
+		problems []*checker.Problem
+	}{
+		{`@(contact.fields.age + 1) @(datetime_add(fields.join_date, 1, "D")) @results.favorite_color.category`, []*checker.Problem{}},
+		{`@webhook.results.0.state @parent.results.foo @(upper(run.results.favorite_color))`, []*checker.Problem{}},
+		{
+			`@(datetime_add(contact.fields.age, 1, "D"))`,
+			[]*checker.Problem{
+				{Code: checker.ProblemArgumentMismatch, Message: `argument 1 of datetime_add must be datetime, got number`, Start: 15, End: 33},
+			},
+		},
+		{
+			`@contact.fields.favorite_food @results.age`,
+			[]*checker.Problem{
+				{Code: checker.ProblemUnknownPath, Message: `contact.fields has no property 'favorite_food'`, Start: 1, End: 29},
+				{Code: checker.ProblemUnknownPath, Message: `results has no property 'age'`, Start: 31, End: 42},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		problems := checker.CheckTemplate(session.Environment(), shape, tc.template)
+
+		assert.Equal(t, tc.problems, problems, "problems mismatch for template: %s", tc.template)
+	}
+}
@@ -0,0 +1,203 @@
+package coverage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+)
+
+// Coverage aggregates the paths of runs through a flow so we can report which nodes, exits and
+// categories were reached, e.g. by a set of flow tests
+type Coverage struct {
+	flow         flows.Flow
+	runs         int
+	nodeHits     map[flows.NodeUUID]int
+	exitHits     map[flows.ExitUUID]int
+	categoryHits map[flows.CategoryUUID]int
+}
+
+// New creates a new empty coverage for the given flow
+func New(flow flows.Flow) *Coverage {
+	return &Coverage{
+		flow:         flow,
+		nodeHits:     make(map[flows.NodeUUID]int),
+		exitHits:     make(map[flows.ExitUUID]int),
+		categoryHits: make(map[flows.CategoryUUID]int),
+	}
+}
+
+// AddSession adds the paths of all runs in the given session which are in our flow
+func (c *Coverage) AddSession(session flows.Session) {
+	for _, run := range session.Runs() {
+		c.AddRun(run)
+	}
+}
+
+// AddRun adds the path of the given run if it's in our flow
+func (c *Coverage) AddRun(run flows.FlowRun) {
+	if run.FlowReference().UUID != c.flow.UUID() {
+		return
+	}
+
+	c.runs++
+
+	// results saved by routers tell us which category was used when several share an exit
+	resultCategories := make(map[flows.StepUUID]map[string]string)
+	for _, event := range run.Events() {
+		if e, isResult := event.(*events.RunResultChangedEvent); isResult {
+			if resultCategories[e.StepUUID()] == nil {
+				resultCategories[e.StepUUID()] = make(map[string]string)
+			}
+			resultCategories[e.StepUUID()][e.Name] = e.Category
+		}
+	}
+
+	for _, step := range run.Path() {
+		node := c.flow.GetNode(step.NodeUUID())
+		if node == nil {
+			continue
+		}
+
+		c.nodeHits[node.UUID()]++
+
+		if step.ExitUUID() == "" {
+			continue
+		}
+
+		c.exitHits[step.ExitUUID()]++
+
+		if node.Router() != nil {
+			category := categoryForStep(node.Router(), step, resultCategories[step.UUID()])
+			if category != nil {
+				c.categoryHits[category.UUID()]++
+			}
+		}
+	}
+}
+
+// works out which category of a router a step left by, returning nil if that can't be determined
+func categoryForStep(router flows.Router, step flows.Step, results map[string]string) flows.Category {
+	candidates := make([]flows.Category, 0, 1)
+	for _, category := range router.Categories() {
+		if category.ExitUUID() == step.ExitUUID() {
+			candidates = append(candidates, category)
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	if categoryName, hasResult := results[router.ResultName()]; hasResult && router.ResultName() != "" {
+		for _, category := range candidates {
+			if category.Name() == categoryName {
+				return category
+			}
+		}
+	}
+	return nil
+}
+
+// Report builds a report of the current coverage
+func (c *Coverage) Report() *Report {
+	report := &Report{
+		Flow:           c.flow.Reference(),
+		Runs:           c.runs,
+		Nodes:          make([]*NodeReport, len(c.flow.Nodes())),
+		UnreachedNodes: make([]flows.NodeUUID, 0),
+	}
+
+	for i, node := range c.flow.Nodes() {
+		nodeReport := &NodeReport{
+			UUID:  node.UUID(),
+			Hits:  c.nodeHits[node.UUID()],
+			Exits: make([]*ExitReport, len(node.Exits())),
+		}
+
+		for j, exit := range node.Exits() {
+			nodeReport.Exits[j] = &ExitReport{UUID: exit.UUID(), Hits: c.exitHits[exit.UUID()]}
+		}
+
+		if node.Router() != nil {
+			for _, category := range node.Router().Categories() {
+				nodeReport.Categories = append(nodeReport.Categories, &CategoryReport{
+					UUID: category.UUID(),
+					Name: category.Name(),
+					Hits: c.categoryHits[category.UUID()],
+				})
+			}
+		}
+
+		if nodeReport.Hits == 0 {
+			report.UnreachedNodes = append(report.UnreachedNodes, node.UUID())
+		} else {
+			report.NodesReached++
+		}
+
+		report.Nodes[i] = nodeReport
+	}
+
+	return report
+}
+
+// Report is a summary of the coverage of a flow
+type Report struct {
+	Flow           *assets.FlowReference `json:"flow"`
+	Runs           int                   `json:"runs"`
+	NodesReached   int                   `json:"nodes_reached"`
+	Nodes          []*NodeReport         `json:"nodes"`
+	UnreachedNodes []flows.NodeUUID      `json:"unreached_nodes"`
+}
+
+// NodeReport is the coverage of a single node
+type NodeReport struct {
+	UUID       flows.NodeUUID    `json:"uuid"`
+	Hits       int               `json:"hits"`
+	Exits      []*ExitReport     `json:"exits"`
+	Categories []*CategoryReport `json:"categories,omitempty"`
+}
+
+// ExitReport is the coverage of a single exit
+type ExitReport struct {
+	UUID flows.ExitUUID `json:"uuid"`
+	Hits int            `json:"hits"`
+}
+
+// CategoryReport is the coverage of a single router category
+type CategoryReport struct {
+	UUID flows.CategoryUUID `json:"uuid"`
+	Name string             `json:"name"`
+	Hits int                `json:"hits"`
+}
+
+// Percent returns the percentage of nodes which were reached
+func (r *Report) Percent() float64 {
+	if len(r.Nodes) == 0 {
+		return 0
+	}
+	return float64(r.NodesReached) * 100 / float64(len(r.Nodes))
+}
+
+// WriteText writes this report as readable text
+func (r *Report) WriteText(out io.Writer) {
+	fmt.Fprintf(out, "%s (%s)\n", r.Flow.Name, r.Flow.UUID)
+	fmt.Fprintf(out, "runs: %d, nodes reached: %d/%d (%.1f%%)\n", r.Runs, r.NodesReached, len(r.Nodes), r.Percent())
+
+	for _, node := range r.Nodes {
+		fmt.Fprintf(out, "  node %s: %d\n", node.UUID, node.Hits)
+
+		for _, category := range node.Categories {
+			fmt.Fprintf(out, "    category %s: %d\n", category.Name, category.Hits)
+		}
+	}
+
+	if len(r.UnreachedNodes) > 0 {
+		fmt.Fprintln(out, "unreached nodes:")
+		for _, uuid := range r.UnreachedNodes {
+			fmt.Fprintf(out, "  %s\n", uuid)
+		}
+	}
+}
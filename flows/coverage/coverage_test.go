@@ -0,0 +1,138 @@
+package coverage_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/coverage"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reads the final session of a runner test
+func readFinalSession(t *testing.T, sa flows.SessionAssets, path string) flows.Session {
+	testJSON, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	runnerTest := &struct {
+		Outputs []struct {
+			Session json.RawMessage `json:"session"`
+		} `json:"outputs"`
+	}{}
+	require.NoError(t, json.Unmarshal(testJSON, runnerTest))
+
+	session, err := test.NewEngine().ReadSession(sa, runnerTest.Outputs[len(runnerTest.Outputs)-1].Session, assets.PanicOnMissing)
+	require.NoError(t, err)
+	return session
+}
+
+func TestCoverage(t *testing.T) {
+	source, err := static.LoadSource("../../test/testdata/runner/two_questions.json")
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(source)
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get(assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"))
+	require.NoError(t, err)
+
+	cov := coverage.New(flow)
+
+	report := cov.Report()
+	assert.Equal(t, 0, report.Runs)
+	assert.Equal(t, 0, report.NodesReached)
+	assert.Equal(t, 0.0, report.Percent())
+	assert.Equal(t, 3, len(report.UnreachedNodes))
+
+	// this session expired waiting at the first node
+	cov.AddSession(readFinalSession(t, sa, "../../test/testdata/runner/two_questions.test_resume_with_expiration.json"))
+
+	report = cov.Report()
+	assert.Equal(t, 1, report.Runs)
+	assert.Equal(t, 1, report.NodesReached)
+	assert.Equal(t, []flows.NodeUUID{"11a772f3-3ca2-4429-8b33-20fdcfc2b69e", "cefd2817-38a8-4ddb-af97-34fffac7e6db"}, report.UnreachedNodes)
+
+	// this session went through all nodes
+	cov.AddSession(readFinalSession(t, sa, "../../test/testdata/runner/two_questions.test.json"))
+
+	report = cov.Report()
+	assert.Equal(t, 2, report.Runs)
+	assert.Equal(t, 3, report.NodesReached)
+	assert.Equal(t, 100.0, report.Percent())
+	assert.Equal(t, []flows.NodeUUID{}, report.UnreachedNodes)
+
+	assert.Equal(t, 2, report.Nodes[0].Hits)
+	assert.Equal(t, []*coverage.ExitReport{
+		{UUID: "2f42b942-bf32-4e81-8ff3-f946b5e68dd8", Hits: 0},
+		{UUID: "dcdc29b6-4671-4c10-a614-5b1507f3df97", Hits: 1},
+		{UUID: "17ec8700-cada-4cff-b3b1-351cac4d85c6", Hits: 0},
+		{UUID: "f0649239-6ab2-4903-b5c5-f813beb5539d", Hits: 0},
+	}, report.Nodes[0].Exits)
+	assert.Equal(t, []*coverage.CategoryReport{
+		{UUID: "598ae7a5-2f81-48f1-afac-595262514aa1", Name: "Red", Hits: 0},
+		{UUID: "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e", Name: "Blue", Hits: 1},
+		{UUID: "78ae8f05-f92e-43b2-a886-406eaea1b8e0", Name: "Other", Hits: 0},
+		{UUID: "1024833c-91aa-4873-a3b5-3bac1ef55812", Name: "No Response", Hits: 0},
+	}, report.Nodes[0].Categories)
+	assert.Equal(t, 1, report.Nodes[1].Hits)
+	assert.Equal(t, 1, report.Nodes[2].Hits)
+	assert.Nil(t, report.Nodes[2].Categories)
+
+	reportJSON, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(reportJSON), `"flow":{"uuid":"615b8a0f-588c-4d20-a05f-363b0b4ce6f4","name":"Two Questions"},"runs":2,"nodes_reached":3`)
+	assert.Contains(t, string(reportJSON), `"unreached_nodes":[]`)
+
+	text := &strings.Builder{}
+	report.WriteText(text)
+	assert.Equal(t, `Two Questions (615b8a0f-588c-4d20-a05f-363b0b4ce6f4)
+runs: 2, nodes reached: 3/3 (100.0%)
+  node 46d51f50-58de-49da-8d13-dadbf322685d: 2
+    category Red: 0
+    category Blue: 1
+    category Other: 0
+    category No Response: 0
+  node 11a772f3-3ca2-4429-8b33-20fdcfc2b69e: 1
+    category Pepsi: 0
+    category Coke: 1
+    category Other: 0
+  node cefd2817-38a8-4ddb-af97-34fffac7e6db: 1
+`, text.String())
+}
+
+func TestCoverageWithSharedExits(t *testing.T) {
+	source, err := static.LoadSource("../../test/testdata/runner/dial.json")
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(source)
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get(assets.FlowUUID("b8c7f1a2-6d6f-4d7f-9a1e-0d6b1c9f4a2e"))
+	require.NoError(t, err)
+
+	cov := coverage.New(flow)
+	cov.AddSession(readFinalSession(t, sa, "../../test/testdata/runner/dial.test.json"))
+	cov.AddSession(readFinalSession(t, sa, "../../test/testdata/runner/dial.test_busy.json"))
+
+	report := cov.Report()
+	assert.Equal(t, 2, report.Runs)
+
+	// no answer, busy and failed share an exit so the saved result is used to tell them apart
+	categoryHits := make(map[string]int)
+	for _, node := range report.Nodes {
+		if node.UUID == "6da04a32-6c84-40d9-b614-3782fde7af80" {
+			for _, category := range node.Categories {
+				categoryHits[category.Name] = category.Hits
+			}
+		}
+	}
+	assert.Equal(t, map[string]int{"Answered": 1, "No Answer": 0, "Busy": 1, "Failed": 0}, categoryHits)
+}
@@ -235,7 +235,7 @@ func (f *flow) ExtractDependencies() []assets.Reference {
 	}
 
 	include := func(template string) {
-		fieldRefs := inspect.ExtractFieldReferences(template)
+		fieldRefs := inspect.ExtractFieldReferences(nil, template)
 		for _, f := range fieldRefs {
 			addDependency(f)
 		}
@@ -58,6 +58,11 @@ func TestBrokenFlows(t *testing.T) {
 			"invalid node[uuid=a58be63b-907d-4a1a-856b-0bb5579d7507]: invalid router: timeout category 13fea3d4-b925-495b-b593-1c9e905e700d is not a valid category",
 			"",
 		},
+		{
+			"invalid_wait_by_flow_type.json",
+			"invalid node[uuid=a58be63b-907d-4a1a-856b-0bb5579d7507]: wait type 'dial' is not allowed in a flow of type 'messaging'",
+			"",
+		},
 		{
 			"invalid_default_exit.json",
 			"invalid node[uuid=a58be63b-907d-4a1a-856b-0bb5579d7507]: invalid router: default category 37d8813f-1402-4ad2-9cc2-e9054a96525b is not a valid category",
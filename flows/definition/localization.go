@@ -47,6 +47,15 @@ func (t languageTranslations) SetTextArray(uuid uuids.UUID, property string, tra
 	t[uuid][property] = translated
 }
 
+// Items returns the UUIDs of all items which have translations
+func (t languageTranslations) Items() []uuids.UUID {
+	items := make([]uuids.UUID, 0, len(t))
+	for uuid := range t {
+		items = append(items, uuid)
+	}
+	return items
+}
+
 // our top level container for all the translations for all languages
 type localization map[envs.Language]languageTranslations
 
@@ -65,6 +65,20 @@ func (n *node) Validate(flow flows.Flow, seenUUIDs map[uuids.UUID]bool) error {
 
 	// check the router if there is one
 	if n.Router() != nil {
+		// check that any wait is valid for this flow type
+		if wait := n.Router().Wait(); wait != nil {
+			isValidInType := false
+			for _, allowedType := range wait.AllowedFlowTypes() {
+				if flow.Type() == allowedType {
+					isValidInType = true
+					break
+				}
+			}
+			if !isValidInType {
+				return errors.Errorf("wait type '%s' is not allowed in a flow of type '%s'", wait.Type(), flow.Type())
+			}
+		}
+
 		if err := n.Router().Validate(n.Exits()); err != nil {
 			return errors.Wrap(err, "invalid router")
 		}
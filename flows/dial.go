@@ -0,0 +1,43 @@
+package flows
+
+import (
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// DialStatus is the type for different dial statuses
+type DialStatus string
+
+// possible dial status values
+const (
+	DialStatusAnswered DialStatus = "answered"
+	DialStatusNoAnswer DialStatus = "no_answer"
+	DialStatusBusy     DialStatus = "busy"
+	DialStatusFailed   DialStatus = "failed"
+)
+
+// Dial is the result of a forwarded call made from a dial wait
+type Dial struct {
+	Status   DialStatus `json:"status" validate:"required,eq=answered|eq=no_answer|eq=busy|eq=failed"`
+	Duration int        `json:"duration" validate:"min=0"`
+}
+
+// NewDial creates a new dial result
+func NewDial(status DialStatus, duration int) *Dial {
+	return &Dial{Status: status, Duration: duration}
+}
+
+// Context returns the properties available in expressions
+//
+//   __default__:text -> the status
+//   status:text -> the status of the dial
+//   duration:number -> the duration of the dial in seconds
+//
+// @context dial
+func (d *Dial) Context(env envs.Environment) map[string]types.XValue {
+	return map[string]types.XValue{
+		"__default__": types.NewXText(string(d.Status)),
+		"status":      types.NewXText(string(d.Status)),
+		"duration":    types.NewXNumberFromInt(d.Duration),
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/pkg/errors"
+)
+
+// the byte which prefixes compact session encodings. This can never be the first byte of a JSON document so it
+// lets us tell the two encodings apart.
+const compactSessionHeader byte = 0x01
+
+// MarshalCompactSession marshals the given session into a compact encoding which is JSON compressed with gzip and
+// prefixed with a header byte. Sessions in this encoding can be read by ReadSession in the same way as JSON.
+func MarshalCompactSession(session flows.Session) ([]byte, error) {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteByte(compactSessionHeader)
+
+	w := gzip.NewWriter(b)
+	if _, err := w.Write(sessionJSON); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// IsCompactSession returns whether the given data is a session in the compact encoding
+func IsCompactSession(data []byte) bool {
+	return len(data) > 0 && data[0] == compactSessionHeader
+}
+
+// decodes a session in the compact encoding back to JSON
+func decodeCompactSession(data []byte) (json.RawMessage, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decompress session")
+	}
+	defer r.Close()
+
+	sessionJSON, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decompress session")
+	}
+	return sessionJSON, nil
+}
@@ -0,0 +1,76 @@
+package engine_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactSession(t *testing.T) {
+	server := test.NewTestHTTPServer(0)
+	defer server.Close()
+
+	session, _, err := test.CreateTestSession(server.URL, envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	sessionJSON, err := json.Marshal(session)
+	require.NoError(t, err)
+
+	compact, err := engine.MarshalCompactSession(session)
+	require.NoError(t, err)
+
+	assert.True(t, engine.IsCompactSession(compact))
+	assert.False(t, engine.IsCompactSession(sessionJSON))
+	assert.False(t, engine.IsCompactSession(nil))
+	assert.True(t, len(compact) < len(sessionJSON))
+
+	// read back the compact session and check it's the same as the JSON form
+	session2, err := session.Engine().ReadSession(session.Assets(), compact, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, session.Status(), session2.Status())
+
+	sessionJSON2, err := json.Marshal(session2)
+	require.NoError(t, err)
+
+	test.AssertEqualJSON(t, sessionJSON, sessionJSON2, "compact session round trip mismatch")
+
+	// check we error if it's not valid gzip
+	_, err = session.Engine().ReadSession(session.Assets(), []byte{0x01, 0x02, 0x03}, assets.PanicOnMissing)
+	assert.EqualError(t, err, "unable to decompress session: unexpected EOF")
+}
+
+func TestCompactSessionWithMigration(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	testJSON, err := ioutil.ReadFile("testdata/session_migrations/v0_failed.json")
+	require.NoError(t, err)
+
+	tc := &struct {
+		Original json.RawMessage `json:"original"`
+	}{}
+	require.NoError(t, json.Unmarshal(testJSON, tc))
+
+	eng := engine.NewBuilder().Build()
+	session, err := eng.ReadSession(sa, tc.Original, assets.PanicOnMissing)
+	require.NoError(t, err)
+
+	compact, err := engine.MarshalCompactSession(session)
+	require.NoError(t, err)
+
+	session, err = eng.ReadSession(sa, compact, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, flows.SessionStatusFailed, session.Status())
+}
@@ -5,7 +5,11 @@ import (
 	"net/http"
 
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 )
 
@@ -14,6 +18,9 @@ type engine struct {
 	httpClient        *http.Client
 	services          *services
 	maxStepsPerSprint int
+	nowSource         dates.NowSource
+	xfunctions        *functions.Registry
+	templateCache     *excellent.TemplateCache
 }
 
 // NewSession creates a new session
@@ -37,9 +44,12 @@ func (e *engine) ReadSession(sa flows.SessionAssets, data json.RawMessage, missi
 	return readSession(e, sa, data, missing)
 }
 
-func (e *engine) HTTPClient() *http.Client { return e.httpClient }
-func (e *engine) Services() flows.Services { return e.services }
-func (e *engine) MaxStepsPerSprint() int   { return e.maxStepsPerSprint }
+func (e *engine) HTTPClient() *http.Client                { return e.httpClient }
+func (e *engine) Services() flows.Services                { return e.services }
+func (e *engine) MaxStepsPerSprint() int                  { return e.maxStepsPerSprint }
+func (e *engine) NowSource() dates.NowSource              { return e.nowSource }
+func (e *engine) XFunctions() *functions.Registry         { return e.xfunctions }
+func (e *engine) TemplateCache() *excellent.TemplateCache { return e.templateCache }
 
 var _ flows.Engine = (*engine)(nil)
 
@@ -59,6 +69,9 @@ func NewBuilder() *Builder {
 			httpClient:        http.DefaultClient,
 			services:          newEmptyServices(),
 			maxStepsPerSprint: 100,
+			nowSource:         dates.GlobalNowSource,
+			xfunctions:        functions.NewRegistry(),
+			templateCache:     excellent.NewTemplateCache(1000),
 		},
 	}
 }
@@ -87,11 +100,44 @@ func (b *Builder) WithAirtimeServiceFactory(f AirtimeServiceFactory) *Builder {
 	return b
 }
 
+// WithLLMServiceFactory sets the LLM service factory
+func (b *Builder) WithLLMServiceFactory(f LLMServiceFactory) *Builder {
+	b.eng.services.llm = f
+	return b
+}
+
+// WithCredentialServiceFactory sets the credential service factory
+func (b *Builder) WithCredentialServiceFactory(f CredentialServiceFactory) *Builder {
+	b.eng.services.credential = f
+	return b
+}
+
 // WithMaxStepsPerSprint sets the maximum number of steps allowed in a single sprint
 func (b *Builder) WithMaxStepsPerSprint(max int) *Builder {
 	b.eng.maxStepsPerSprint = max
 	return b
 }
 
+// WithNowSource sets the clock used for the timestamps of sessions, runs, events and results, by Excellent
+// functions like now(), and for deciding which resumes are due
+func (b *Builder) WithNowSource(source dates.NowSource) *Builder {
+	b.eng.nowSource = source
+	return b
+}
+
+// WithXFunction registers an Excellent function which is only available to sessions of this engine, and
+// which takes precedence over a builtin function with the same name
+func (b *Builder) WithXFunction(name string, function types.XFunction) *Builder {
+	b.eng.xfunctions.Register(name, function)
+	return b
+}
+
+// WithTemplateCacheSize sets the maximum number of compiled templates the engine keeps for reuse across
+// sessions, where zero disables caching
+func (b *Builder) WithTemplateCacheSize(size int) *Builder {
+	b.eng.templateCache = excellent.NewTemplateCache(size)
+	return b
+}
+
 // Build returns the final engine
 func (b *Builder) Build() flows.Engine { return b.eng }
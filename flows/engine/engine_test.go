@@ -1,30 +1,112 @@
 package engine_test
 
 import (
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/services/webhooks"
+	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils/dates"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuilder(t *testing.T) {
-	webhookSvc := webhooks.NewService("goflow", 1000)
+	webhookSvc := webhooks.NewService("goflow", 1000, nil, nil, nil)
 	httpClient := &http.Client{}
 
 	eng := engine.NewBuilder().
 		WithWebhookServiceFactory(func(flows.Session) (flows.WebhookService, error) { return webhookSvc, nil }).
 		WithHTTPClient(httpClient).
 		WithMaxStepsPerSprint(123).
+		WithTemplateCacheSize(0).
 		Build()
 
 	assert.Equal(t, httpClient, eng.HTTPClient())
 	assert.Equal(t, 123, eng.MaxStepsPerSprint())
+	assert.Equal(t, 0, eng.TemplateCache().Len())
 
 	svc, err := eng.Services().Webhook(nil)
 	assert.NoError(t, err)
 	assert.Equal(t, webhookSvc, svc)
 }
+
+func TestEngineTemplateCache(t *testing.T) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	cache := session.Engine().TemplateCache()
+	before := cache.Len()
+
+	// evaluating the same template again re-uses the compiled version
+	for i := 0; i < 2; i++ {
+		value, err := run.EvaluateTemplate(`Hi @(upper(contact.name)) @@ @(1 + 1)`)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hi RYAN LEWIS @ 2", value)
+		assert.Equal(t, before+1, cache.Len())
+	}
+
+	// as do templates evaluated as values, which are trimmed first
+	value, err := run.EvaluateTemplateValue(` @contact.name `)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewXText("Ryan Lewis"), value)
+
+	value, err = run.EvaluateTemplateValue(`@contact.name`)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewXText("Ryan Lewis"), value)
+	assert.Equal(t, before+2, cache.Len())
+}
+
+func TestEngineFunctions(t *testing.T) {
+	shout := func(env envs.Environment, args ...types.XValue) types.XValue {
+		return types.NewXText(strings.ToUpper(args[0].Render()) + "!")
+	}
+	upper := func(env envs.Environment, args ...types.XValue) types.XValue {
+		return types.NewXText("overridden")
+	}
+
+	eng1 := engine.NewBuilder().WithXFunction("shout", shout).WithXFunction("UPPER", upper).Build()
+	eng2 := engine.NewBuilder().Build()
+
+	assert.Equal(t, []string{"shout", "upper"}, eng1.XFunctions().Names())
+	assert.Equal(t, []string{}, eng2.XFunctions().Names())
+
+	assetsJSON, err := ioutil.ReadFile("testdata/timeout_test.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	contact := flows.NewEmptyContact(sa, "Joe", "eng", nil, dates.Now())
+
+	session1, _, err := eng1.NewSession(sa, triggers.NewManual(nil, flow.Reference(), contact, nil))
+	require.NoError(t, err)
+	session2, _, err := eng2.NewSession(sa, triggers.NewManual(nil, flow.Reference(), contact, nil))
+	require.NoError(t, err)
+
+	// engine functions are available to sessions of that engine and take precedence over builtins
+	result, err := session1.Runs()[0].EvaluateTemplate(`@(shout(contact.name)) @(upper("x"))`)
+	assert.NoError(t, err)
+	assert.Equal(t, "JOE! overridden", result)
+
+	// but not to sessions of other engines
+	result, err = session2.Runs()[0].EvaluateTemplate(`@(upper("x"))`)
+	assert.NoError(t, err)
+	assert.Equal(t, "X", result)
+
+	_, err = session2.Runs()[0].EvaluateTemplate(`@(shout(contact.name))`)
+	assert.EqualError(t, err, "error evaluating @(shout(contact.name)): context has no property 'shout'")
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nyaruka/goflow/utils"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+)
+
+// CurrentSessionSpecVersion is the session spec version written by this library
+var CurrentSessionSpecVersion = semver.MustParse("1.1")
+
+// sessions written before they were versioned are considered to have this version
+var unversionedSessionSpecVersion = semver.MustParse("0.0")
+
+// a session migration operates on a generic representation of session JSON
+type sessionMigrationFunc func(map[string]interface{}) error
+
+type sessionMigration struct {
+	version *semver.Version
+	migrate sessionMigrationFunc
+}
+
+// migrations to be applied in order to bring a session up to the current version
+var sessionMigrations = []sessionMigration{
+	{semver.MustParse("1.0"), migrateSessionToV1},
+	{semver.MustParse("1.1"), migrateSessionToV1_1},
+}
+
+type sessionHeader struct {
+	SpecVersion *semver.Version `json:"spec_version"`
+}
+
+// ReadSessionSpecVersion reads the spec version of the given session JSON
+func ReadSessionSpecVersion(data json.RawMessage) (*semver.Version, error) {
+	header := &sessionHeader{}
+	if err := json.Unmarshal(data, header); err != nil {
+		return nil, errors.Wrap(err, "unable to read session header")
+	}
+	if header.SpecVersion == nil {
+		return unversionedSessionSpecVersion, nil
+	}
+	return header.SpecVersion, nil
+}
+
+// MigrateSession migrates the given session JSON to the current session spec version
+func MigrateSession(data json.RawMessage) (json.RawMessage, error) {
+	version, err := ReadSessionSpecVersion(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// can't do anything with a newer version than this library supports
+	if version.GreaterThan(CurrentSessionSpecVersion) {
+		return nil, errors.Errorf("session spec version %s is newer than this library (%s)", version, CurrentSessionSpecVersion)
+	}
+	if version.Equal(CurrentSessionSpecVersion) {
+		return data, nil
+	}
+
+	generic, err := utils.JSONDecodeGeneric(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read session")
+	}
+	session, isMap := generic.(map[string]interface{})
+	if !isMap {
+		return nil, errors.New("unable to read session: not a JSON object")
+	}
+
+	for _, m := range sessionMigrations {
+		if m.version.GreaterThan(version) {
+			if err := m.migrate(session); err != nil {
+				return nil, errors.Wrapf(err, "unable to migrate session to version %s", m.version)
+			}
+		}
+	}
+
+	session["spec_version"] = CurrentSessionSpecVersion.String()
+
+	return json.Marshal(session)
+}
+
+// migrates an unversioned session to 1.0, which renamed the errored statuses of sessions and runs to failed, and
+// replaced fatal error events with failure events
+func migrateSessionToV1(session map[string]interface{}) error {
+	if session["status"] == "errored" {
+		session["status"] = "failed"
+	}
+
+	runs, _ := session["runs"].([]interface{})
+	for _, r := range runs {
+		run, isMap := r.(map[string]interface{})
+		if !isMap {
+			return errors.New("run is not a JSON object")
+		}
+		if run["status"] == "errored" {
+			run["status"] = "failed"
+		}
+
+		events, _ := run["events"].([]interface{})
+		for _, e := range events {
+			event, isMap := e.(map[string]interface{})
+			if !isMap {
+				return errors.New("event is not a JSON object")
+			}
+			if event["type"] == "error" && event["fatal"] == true {
+				event["type"] = "failure"
+				delete(event, "fatal")
+			}
+		}
+	}
+	return nil
+}
+
+// migrates a 1.0 session to 1.1, which added the time that a wait times out. For sessions waiting with a timeout,
+// this is calculated from when the waiting run began waiting.
+func migrateSessionToV1_1(session map[string]interface{}) error {
+	wait, _ := session["wait"].(map[string]interface{})
+	if wait == nil || wait["timeout_on"] != nil {
+		return nil
+	}
+	timeoutSeconds, hasTimeout := wait["timeout_seconds"].(json.Number)
+	if !hasTimeout {
+		return nil
+	}
+	seconds, err := timeoutSeconds.Int64()
+	if err != nil {
+		return errors.Wrap(err, "wait has invalid timeout")
+	}
+
+	// find the last wait event of the waiting run
+	var waitedOn interface{}
+
+	runs, _ := session["runs"].([]interface{})
+	for _, r := range runs {
+		run, isMap := r.(map[string]interface{})
+		if !isMap {
+			return errors.New("run is not a JSON object")
+		}
+		if run["status"] != "waiting" {
+			continue
+		}
+
+		events, _ := run["events"].([]interface{})
+		for _, e := range events {
+			event, isMap := e.(map[string]interface{})
+			if !isMap {
+				return errors.New("event is not a JSON object")
+			}
+			if event["type"] == "msg_wait" {
+				waitedOn = event["created_on"]
+			}
+		}
+	}
+
+	waitedOnStr, _ := waitedOn.(string)
+	if waitedOnStr == "" {
+		return nil
+	}
+
+	begun, err := time.Parse(time.RFC3339Nano, waitedOnStr)
+	if err != nil {
+		return errors.Wrap(err, "wait event has invalid created_on")
+	}
+
+	wait["timeout_on"] = begun.Add(time.Duration(seconds) * time.Second).Format(time.RFC3339Nano)
+	return nil
+}
@@ -0,0 +1,88 @@
+package engine_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSessionSpecVersion(t *testing.T) {
+	version, err := engine.ReadSessionSpecVersion([]byte(`{"uuid": "e7187099-7d38-4f60-955c-325957214c42"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0", version.String())
+
+	version, err = engine.ReadSessionSpecVersion([]byte(`{"spec_version": "1.0.0"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version.String())
+
+	_, err = engine.ReadSessionSpecVersion([]byte(`{"spec_version": "x"}`))
+	assert.Error(t, err)
+}
+
+func TestMigrateSession(t *testing.T) {
+	// current version sessions are returned as is
+	data := []byte(`{"spec_version": "1.1.0", "status": "completed"}`)
+	migrated, err := engine.MigrateSession(data)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(migrated))
+
+	// can't read sessions from a newer version of this library
+	_, err = engine.MigrateSession([]byte(`{"spec_version": "2.1.0", "status": "completed"}`))
+	assert.EqualError(t, err, "session spec version 2.1.0 is newer than this library (1.1.0)")
+
+	_, err = engine.MigrateSession([]byte(`[]`))
+	assert.EqualError(t, err, "unable to read session header: json: cannot unmarshal array into Go value of type engine.sessionHeader")
+
+	_, err = engine.MigrateSession([]byte(`{"runs": [1]}`))
+	assert.EqualError(t, err, "unable to migrate session to version 1.0.0: run is not a JSON object")
+
+	_, err = engine.MigrateSession([]byte(`{"spec_version": "1.0.0", "wait": {"type": "msg", "timeout_seconds": 60}, "runs": [{"status": "waiting", "events": [{"type": "msg_wait", "created_on": "xxx"}]}]}`))
+	assert.EqualError(t, err, "unable to migrate session to version 1.1.0: wait event has invalid created_on: parsing time \"xxx\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"xxx\" as \"2006\"")
+}
+
+func TestSessionMigrationsGolden(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	eng := engine.NewBuilder().Build()
+
+	paths, err := filepath.Glob("testdata/session_migrations/*.json")
+	require.NoError(t, err)
+	require.True(t, len(paths) > 0)
+
+	for _, path := range paths {
+		testJSON, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		tc := &struct {
+			Original json.RawMessage `json:"original"`
+			Migrated json.RawMessage `json:"migrated"`
+		}{}
+		require.NoError(t, json.Unmarshal(testJSON, tc))
+
+		migrated, err := engine.MigrateSession(tc.Original)
+		require.NoError(t, err, "unable to migrate session in %s", path)
+
+		test.AssertEqualJSON(t, tc.Migrated, migrated, "migrated session mismatch in %s", path)
+
+		// original session should also be readable and write out as the migrated version
+		session, err := eng.ReadSession(sa, tc.Original, assets.PanicOnMissing)
+		require.NoError(t, err, "unable to read session in %s", path)
+
+		marshaled, err := json.Marshal(session)
+		require.NoError(t, err)
+
+		test.AssertEqualJSON(t, tc.Migrated, marshaled, "re-marshaled session mismatch in %s", path)
+	}
+}
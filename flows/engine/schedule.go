@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/resumes"
+)
+
+// ScheduledResume is a resume which the caller will need to make at a future time if the session
+// isn't resumed by something else first, e.g. a wait timing out or a run expiring
+type ScheduledResume struct {
+	Type  string
+	DueOn time.Time
+}
+
+// IsDue returns whether this resume is due at the given time
+func (r *ScheduledResume) IsDue(now time.Time) bool {
+	return !now.Before(r.DueOn)
+}
+
+// NewResume creates the resume which the caller should make when this is due
+func (r *ScheduledResume) NewResume(env envs.Environment, contact *flows.Contact) flows.Resume {
+	if r.Type == resumes.TypeWaitTimeout {
+		return resumes.NewWaitTimeout(env, contact)
+	}
+	return resumes.NewRunExpiration(env, contact)
+}
+
+// NextScheduledResume inspects a waiting session and returns the next resume that will be due, which
+// is either a wait timeout or a run expiration, whichever comes first. Returns nil if the session isn't
+// waiting or its waiting run can't time out or expire.
+func NextScheduledResume(session flows.Session) *ScheduledResume {
+	if session.Status() != flows.SessionStatusWaiting {
+		return nil
+	}
+
+	var waitingRun flows.FlowRun
+	for _, run := range session.Runs() {
+		if run.Status() == flows.RunStatusWaiting {
+			waitingRun = run
+			break
+		}
+	}
+	if waitingRun == nil {
+		return nil
+	}
+
+	var next *ScheduledResume
+
+	if waitingRun.ExpiresOn() != nil {
+		next = &ScheduledResume{Type: resumes.TypeRunExpiration, DueOn: *waitingRun.ExpiresOn()}
+	}
+
+	if session.Wait() != nil && session.Wait().TimeoutOn() != nil {
+		timeoutOn := *session.Wait().TimeoutOn()
+
+		if next == nil || timeoutOn.Before(next.DueOn) {
+			next = &ScheduledResume{Type: resumes.TypeWaitTimeout, DueOn: timeoutOn}
+		}
+	}
+
+	return next
+}
+
+// DueResume returns the resume which the caller should make to the given session now according to the
+// clock of its engine, or nil if nothing is due yet. The resume is made with the session's environment so
+// that it's timestamped by the same clock.
+func DueResume(session flows.Session) flows.Resume {
+	next := NextScheduledResume(session)
+	if next == nil || !next.IsDue(session.Engine().NowSource().Now()) {
+		return nil
+	}
+	return next.NewResume(session.Environment(), nil)
+}
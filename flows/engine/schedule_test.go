@@ -0,0 +1,136 @@
+package engine_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/triggers"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// a clock which tests can move forward
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func TestScheduledResumes(t *testing.T) {
+	t1 := time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)
+
+	assetsJSON, err := ioutil.ReadFile("testdata/timeout_test.json")
+	require.NoError(t, err)
+
+	startSession := func(expireAfterMinutes string) (flows.Session, flows.Sprint, *testClock) {
+		data := test.JSONReplace(assetsJSON, []string{"flows", "[0]", "expire_after_minutes"}, []byte(expireAfterMinutes))
+
+		sa, err := test.CreateSessionAssets(json.RawMessage(data), "")
+		require.NoError(t, err)
+
+		clock := &testClock{now: t1}
+		eng := engine.NewBuilder().WithNowSource(clock).Build()
+
+		contact := flows.NewEmptyContact(sa, "Joe", "eng", nil, eng.NowSource().Now())
+		env := envs.NewClockedEnvironment(envs.NewBuilder().Build(), clock)
+		trigger := triggers.NewManual(env, assets.NewFlowReference("76f0a02f-3b75-4b86-9064-e9195e1b3a02", "Question With Timeout"), contact, nil)
+
+		session, sprint, err := eng.NewSession(sa, trigger)
+		require.NoError(t, err)
+		require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+		return session, sprint, clock
+	}
+
+	// flow expires after the wait times out
+	session, sprint, clock := startSession(`60`)
+
+	// timestamps come from the engine clock
+	assert.Equal(t, t1, session.Contact().CreatedOn())
+	assert.Equal(t, t1, session.Trigger().TriggeredOn())
+	assert.Equal(t, t1, session.Environment().Now())
+	assert.Equal(t, t1, session.Runs()[0].CreatedOn())
+	assert.Equal(t, t1.Add(time.Hour), *session.Runs()[0].ExpiresOn())
+	for _, event := range sprint.Events() {
+		assert.Equal(t, t1, event.CreatedOn())
+	}
+
+	// the wait records when it will time out
+	assert.Equal(t, t1.Add(10*time.Minute), *session.Wait().TimeoutOn())
+
+	next := engine.NextScheduledResume(session)
+	require.NotNil(t, next)
+	assert.Equal(t, resumes.TypeWaitTimeout, next.Type)
+	assert.Equal(t, t1.Add(10*time.Minute), next.DueOn)
+	assert.False(t, next.IsDue(t1))
+	assert.True(t, next.IsDue(t1.Add(10*time.Minute)))
+	assert.Nil(t, engine.DueResume(session))
+
+	clock.now = t1.Add(10 * time.Minute)
+
+	// the timeout doesn't move if the run is modified after it began waiting
+	session.Runs()[0].ResetExpiration(nil)
+	assert.Equal(t, t1.Add(10*time.Minute), engine.NextScheduledResume(session).DueOn)
+
+	// and survives the session being saved and read back
+	sessionJSON, err := json.Marshal(session)
+	require.NoError(t, err)
+	session, err = session.Engine().ReadSession(session.Assets(), sessionJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, t1.Add(10*time.Minute), engine.NextScheduledResume(session).DueOn)
+
+	// and is also found for sessions written before waits recorded when they time out
+	oldJSON := test.JSONDelete(sessionJSON, []string{"wait", "timeout_on"})
+	oldJSON = test.JSONReplace(oldJSON, []string{"spec_version"}, []byte(`"1.0.0"`))
+
+	oldSession, err := session.Engine().ReadSession(session.Assets(), oldJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, t1.Add(10*time.Minute), *oldSession.Wait().TimeoutOn())
+	assert.Equal(t, t1.Add(10*time.Minute), engine.NextScheduledResume(oldSession).DueOn)
+
+	resume := engine.DueResume(session)
+	require.NotNil(t, resume)
+	assert.Equal(t, resumes.TypeWaitTimeout, resume.Type())
+	assert.Equal(t, t1.Add(10*time.Minute), resume.ResumedOn())
+
+	sprint, err = session.Resume(resume)
+	require.NoError(t, err)
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	for _, event := range sprint.Events() {
+		assert.Equal(t, t1.Add(10*time.Minute), event.CreatedOn())
+	}
+	assert.Equal(t, t1.Add(10*time.Minute), session.Runs()[0].Results().Get("favorite_color").CreatedOn)
+
+	// nothing scheduled for sessions which aren't waiting
+	assert.Nil(t, engine.NextScheduledResume(session))
+	assert.Nil(t, engine.DueResume(session))
+
+	// flow expires before the wait times out
+	session, _, clock = startSession(`5`)
+
+	next = engine.NextScheduledResume(session)
+	require.NotNil(t, next)
+	assert.Equal(t, resumes.TypeRunExpiration, next.Type)
+	assert.Equal(t, t1.Add(5*time.Minute), next.DueOn)
+
+	clock.now = t1.Add(6 * time.Minute)
+
+	resume = engine.DueResume(session)
+	require.NotNil(t, resume)
+	assert.Equal(t, resumes.TypeRunExpiration, resume.Type())
+
+	_, err = session.Resume(resume)
+	require.NoError(t, err)
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	assert.Equal(t, flows.RunStatusExpired, session.Runs()[0].Status())
+	assert.Equal(t, t1.Add(6*time.Minute), *session.Runs()[0].ExitedOn())
+}
@@ -14,10 +14,18 @@ type ClassificationServiceFactory func(flows.Session, *flows.Classifier) (flows.
 // AirtimeServiceFactory resolves a session to an airtime service
 type AirtimeServiceFactory func(flows.Session) (flows.AirtimeService, error)
 
+// LLMServiceFactory resolves a session to an LLM service
+type LLMServiceFactory func(flows.Session) (flows.LLMService, error)
+
+// CredentialServiceFactory resolves a session to a credential service
+type CredentialServiceFactory func(flows.Session) (flows.CredentialService, error)
+
 type services struct {
 	webhook        WebhookServiceFactory
 	classification ClassificationServiceFactory
 	airtime        AirtimeServiceFactory
+	llm            LLMServiceFactory
+	credential     CredentialServiceFactory
 }
 
 func newEmptyServices() *services {
@@ -31,6 +39,12 @@ func newEmptyServices() *services {
 		airtime: func(flows.Session) (flows.AirtimeService, error) {
 			return nil, errors.New("no airtime service factory configured")
 		},
+		llm: func(flows.Session) (flows.LLMService, error) {
+			return nil, errors.New("no LLM service factory configured")
+		},
+		credential: func(flows.Session) (flows.CredentialService, error) {
+			return nil, errors.New("no credential service factory configured")
+		},
 	}
 }
 
@@ -45,3 +59,11 @@ func (s *services) Classification(session flows.Session, classifier *flows.Class
 func (s *services) Airtime(session flows.Session) (flows.AirtimeService, error) {
 	return s.airtime(session)
 }
+
+func (s *services) LLM(session flows.Session) (flows.LLMService, error) {
+	return s.llm(session)
+}
+
+func (s *services) Credentials(session flows.Session) (flows.CredentialService, error) {
+	return s.credential(session)
+}
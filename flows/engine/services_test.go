@@ -22,4 +22,12 @@ func TestEmptyServices(t *testing.T) {
 	airtimeSvc, err := eng.Services().Airtime(nil)
 	assert.EqualError(t, err, "no airtime service factory configured")
 	assert.Nil(t, airtimeSvc)
+
+	llmSvc, err := eng.Services().LLM(nil)
+	assert.EqualError(t, err, "no LLM service factory configured")
+	assert.Nil(t, llmSvc)
+
+	credentialSvc, err := eng.Services().Credentials(nil)
+	assert.EqualError(t, err, "no credential service factory configured")
+	assert.Nil(t, credentialSvc)
 }
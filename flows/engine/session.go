@@ -14,6 +14,7 @@ import (
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/utils"
 
+	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
 )
 
@@ -39,9 +40,10 @@ type session struct {
 	input   flows.Input
 
 	// state which is temporary to each call
-	runsByUUID map[flows.RunUUID]flows.FlowRun
-	pushedFlow *pushedFlow
-	parentRun  flows.RunSummary
+	runsByUUID    map[flows.RunUUID]flows.FlowRun
+	pushedFlow    *pushedFlow
+	parentRun     flows.RunSummary
+	currentResume flows.Resume
 
 	engine flows.Engine
 }
@@ -54,8 +56,12 @@ func (s *session) UUID() flows.SessionUUID { return s.uuid }
 func (s *session) Type() flows.FlowType         { return s.type_ }
 func (s *session) SetType(type_ flows.FlowType) { s.type_ = type_ }
 
-func (s *session) Environment() envs.Environment       { return s.env }
-func (s *session) SetEnvironment(env envs.Environment) { s.env = env }
+func (s *session) Environment() envs.Environment { return s.env }
+
+// SetEnvironment sets the environment of this session, which gets the current time from the engine's clock
+func (s *session) SetEnvironment(env envs.Environment) {
+	s.env = envs.NewClockedEnvironment(env, s.engine.NowSource())
+}
 
 func (s *session) Contact() *flows.Contact           { return s.contact }
 func (s *session) SetContact(contact *flows.Contact) { s.contact = contact }
@@ -99,6 +105,9 @@ func (s *session) ParentRun() flows.RunSummary {
 func (s *session) Status() flows.SessionStatus { return s.status }
 func (s *session) Wait() flows.ActivatedWait   { return s.wait }
 
+// CurrentResume gets the resume which resumed this session in the current sprint (if any)
+func (s *session) CurrentResume() flows.Resume { return s.currentResume }
+
 // looks through this session's run for the one that is waiting
 func (s *session) waitingRun() flows.FlowRun {
 	for _, run := range s.runs {
@@ -154,7 +163,7 @@ func (s *session) Resume(resume flows.Resume) (flows.Sprint, error) {
 
 	if err := s.tryToResume(sprint, waitingRun, resume); err != nil {
 		// if we got an error, add it to the log and shut everything down
-		failure(sprint, waitingRun, nil, err)
+		s.failure(sprint, waitingRun, nil, err)
 
 		s.status = flows.SessionStatusFailed
 	}
@@ -162,6 +171,38 @@ func (s *session) Resume(resume flows.Resume) (flows.Sprint, error) {
 	return sprint, nil
 }
 
+// Interrupt interrupts an active or waiting session, exiting all of its active and waiting runs
+func (s *session) Interrupt(reason string) (flows.Sprint, error) {
+	sprint := NewEmptySprint()
+
+	if s.status != flows.SessionStatusActive && s.status != flows.SessionStatusWaiting {
+		return sprint, errors.Errorf("only active or waiting sessions can be interrupted")
+	}
+	if reason == "" {
+		return sprint, errors.Errorf("a reason must be provided to interrupt a session")
+	}
+
+	event := events.NewSessionInterrupted(s.engine.NowSource().Now(), reason)
+
+	// log the event on the waiting run so that it's part of the run's history
+	if waitingRun := s.waitingRun(); waitingRun != nil && len(waitingRun.Path()) > 0 {
+		path := waitingRun.Path()
+		waitingRun.LogEvent(path[len(path)-1], event)
+	}
+	sprint.LogEvent(event)
+
+	for _, run := range s.runs {
+		if run.Status() == flows.RunStatusActive || run.Status() == flows.RunStatusWaiting {
+			run.Exit(flows.RunStatusInterrupted)
+		}
+	}
+
+	s.wait = nil
+	s.status = flows.SessionStatusInterrupted
+
+	return sprint, nil
+}
+
 // prepares the session for starting/resuming
 func (s *session) prepareForSprint() error {
 	if s.parentRun == nil {
@@ -197,11 +238,12 @@ func (s *session) tryToResume(sprint flows.Sprint, waitingRun flows.FlowRun, res
 
 	// try to end our wait which will return and log an error if it can't be ended with this resume
 	if err := node.Router().Wait().End(resume); err != nil {
-		sprint.LogEvent(events.NewError(err))
+		sprint.LogEvent(events.NewError(s.engine.NowSource().Now(), err))
 		return nil
 	}
 	s.wait = nil
 	s.status = flows.SessionStatusActive
+	s.currentResume = resume
 
 	logEvent := func(e flows.Event) {
 		waitingRun.LogEvent(step, e)
@@ -300,12 +342,12 @@ func (s *session) continueUntilWait(sprint flows.Sprint, currentRun flows.FlowRu
 					}
 
 					if destination, err = s.findResumeDestination(sprint, currentRun, false); err != nil {
-						failure(sprint, currentRun, step, errors.Wrapf(err, "can't resume run as node no longer exists"))
+						s.failure(sprint, currentRun, step, errors.Wrapf(err, "can't resume run as node no longer exists"))
 					}
 				} else {
 					// if we did error then that needs to bubble back up through the run hierarchy
 					step, _, _ := currentRun.PathLocation()
-					failure(sprint, currentRun, step, errors.Errorf("child run for flow '%s' ended in error, ending execution", childRun.Flow().UUID()))
+					s.failure(sprint, currentRun, step, errors.Errorf("child run for flow '%s' ended in error, ending execution", childRun.Flow().UUID()))
 				}
 
 			} else {
@@ -327,7 +369,7 @@ func (s *session) continueUntilWait(sprint flows.Sprint, currentRun flows.FlowRu
 
 			if numNewSteps > s.Engine().MaxStepsPerSprint() {
 				// we've hit the step limit - usually a sign of a loop
-				failure(sprint, currentRun, step, errors.Errorf("step limit exceeded, stopping execution before entering '%s'", destination))
+				s.failure(sprint, currentRun, step, errors.Errorf("step limit exceeded, stopping execution before entering '%s'", destination))
 				destination = noDestination
 			} else {
 				node := currentRun.Flow().GetNode(destination)
@@ -429,7 +471,7 @@ func (s *session) pickNodeExit(sprint flows.Sprint, run flows.FlowRun, node flow
 		}
 		// router didn't error.. but it failed to pick a category
 		if exitUUID == "" {
-			failure(sprint, run, step, errors.Errorf("router on node[uuid=%s] failed to pick a category", node.UUID()))
+			s.failure(sprint, run, step, errors.Errorf("router on node[uuid=%s] failed to pick a category", node.UUID()))
 			return noDestination, nil
 		}
 	} else if len(node.Exits()) > 0 {
@@ -453,8 +495,8 @@ func (s *session) pickNodeExit(sprint flows.Sprint, run flows.FlowRun, node flow
 const noDestination = flows.NodeUUID("")
 
 // utility to fail the session and log a failure event
-func failure(sprint flows.Sprint, run flows.FlowRun, step flows.Step, err error) {
-	event := events.NewFailure(err)
+func (s *session) failure(sprint flows.Sprint, run flows.FlowRun, step flows.Step, err error) {
+	event := events.NewFailure(s.engine.NowSource().Now(), err)
 	if run != nil {
 		run.Exit(flows.RunStatusFailed)
 		run.LogEvent(step, event)
@@ -467,6 +509,7 @@ func failure(sprint flows.Sprint, run flows.FlowRun, step flows.Step, err error)
 //------------------------------------------------------------------------------------------
 
 type sessionEnvelope struct {
+	SpecVersion *semver.Version     `json:"spec_version"`
 	UUID        flows.SessionUUID   `json:"uuid"` // TODO validate:"required"`
 	Type        flows.FlowType      `json:"type"` // TODO validate:"required"`
 	Environment json.RawMessage     `json:"environment"`
@@ -478,11 +521,22 @@ type sessionEnvelope struct {
 	Input       json.RawMessage     `json:"input,omitempty" validate:"omitempty"`
 }
 
-// ReadSession decodes a session from the passed in JSON
+// ReadSession decodes a session from the passed in JSON or compact encoding
 func readSession(eng flows.Engine, sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Session, error) {
-	e := &sessionEnvelope{}
 	var err error
 
+	// sessions might be in our compact encoding
+	if IsCompactSession(data) {
+		if data, err = decodeCompactSession(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if data, err = MigrateSession(data); err != nil {
+		return nil, err
+	}
+
+	e := &sessionEnvelope{}
 	if err = utils.UnmarshalAndValidate(data, e); err != nil {
 		return nil, errors.Wrap(err, "unable to read session")
 	}
@@ -497,10 +551,11 @@ func readSession(eng flows.Engine, sessionAssets flows.SessionAssets, data json.
 	}
 
 	// read our environment
-	s.env, err = envs.ReadEnvironment(e.Environment)
+	env, err := envs.ReadEnvironment(e.Environment)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to read environment")
 	}
+	s.SetEnvironment(env)
 
 	// read our trigger
 	if e.Trigger != nil {
@@ -550,9 +605,10 @@ func readSession(eng flows.Engine, sessionAssets flows.SessionAssets, data json.
 // MarshalJSON marshals this session into JSON
 func (s *session) MarshalJSON() ([]byte, error) {
 	e := &sessionEnvelope{
-		UUID:   s.uuid,
-		Type:   s.type_,
-		Status: s.status,
+		SpecVersion: CurrentSessionSpecVersion,
+		UUID:        s.uuid,
+		Type:        s.type_,
+		Status:      s.status,
 	}
 	var err error
 
@@ -690,7 +690,7 @@ func TestResumeWithMissingFlowAssets(t *testing.T) {
 	require.NoError(t, err)
 
 	env := envs.NewBuilder().Build()
-	contact := flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil)
+	contact := flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil, dates.Now())
 	trigger := triggers.NewManual(env, assets.NewFlowReference(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), "Parent Flow"), contact, nil)
 
 	// run session to wait in child flow
@@ -748,7 +748,7 @@ func TestWaitTimeout(t *testing.T) {
 	flow, err := sa.Flows().Get(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
 	require.NoError(t, err)
 
-	contact := flows.NewEmptyContact(sa, "Joe", "eng", nil)
+	contact := flows.NewEmptyContact(sa, "Joe", "eng", nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+18005555777"), nil))
 	trigger := triggers.NewManual(nil, flow.Reference(), contact, nil)
 
@@ -780,3 +780,61 @@ func TestWaitTimeout(t *testing.T) {
 	require.Equal(t, "2018-04-11T13:24:30.123456Z", result.Value)
 	require.Equal(t, "", result.Input)
 }
+
+func TestInterrupt(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().Build()
+	contact := flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil, dates.Now())
+	trigger := triggers.NewManual(env, assets.NewFlowReference(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), "Parent Flow"), contact, nil)
+
+	// run session to wait in child flow
+	eng := engine.NewBuilder().Build()
+	session, _, err := eng.NewSession(sa, trigger)
+	require.NoError(t, err)
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	assert.Equal(t, flows.RunStatusActive, session.Runs()[0].Status())
+	assert.Equal(t, flows.RunStatusWaiting, session.Runs()[1].Status())
+
+	// must provide a reason
+	_, err = session.Interrupt("")
+	assert.EqualError(t, err, "a reason must be provided to interrupt a session")
+
+	sprint, err := session.Interrupt("new_trigger")
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusInterrupted, session.Status())
+	assert.Nil(t, session.Wait())
+	assert.Equal(t, 0, len(sprint.Modifiers()))
+	require.Equal(t, 1, len(sprint.Events()))
+	assert.Equal(t, "session_interrupted", sprint.Events()[0].Type())
+	assert.Equal(t, "new_trigger", sprint.Events()[0].(*events.SessionInterruptedEvent).Reason)
+
+	for _, run := range session.Runs() {
+		assert.Equal(t, flows.RunStatusInterrupted, run.Status())
+		assert.NotNil(t, run.ExitedOn())
+	}
+
+	// event is also logged on the run which was waiting
+	childEvents := session.Runs()[1].Events()
+	assert.Equal(t, "session_interrupted", childEvents[len(childEvents)-1].Type())
+
+	// interrupted session can be saved and reloaded
+	sessionJSON, err := json.Marshal(session)
+	require.NoError(t, err)
+
+	session, err = eng.ReadSession(sa, sessionJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, flows.SessionStatusInterrupted, session.Status())
+
+	// but can't be interrupted or resumed again
+	_, err = session.Interrupt("new_trigger")
+	assert.EqualError(t, err, "only active or waiting sessions can be interrupted")
+
+	_, err = session.Resume(resumes.NewMsg(env, contact, flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, "Hello", nil)))
+	assert.EqualError(t, err, "only waiting sessions can be resumed")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/nyaruka/goflow/flows/actions/modifiers"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/utils/dates"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -16,8 +17,8 @@ func TestSprint(t *testing.T) {
 	mod1 := modifiers.NewName("Bob")
 	mod2 := modifiers.NewName("Joe")
 
-	event1 := events.NewError(errors.New("error 1"))
-	event2 := events.NewError(errors.New("error 1"))
+	event1 := events.NewError(dates.Now(), errors.New("error 1"))
+	event2 := events.NewError(dates.Now(), errors.New("error 1"))
 
 	sprint := engine.NewSprint([]flows.Modifier{mod1}, []flows.Event{event1})
 	sprint.LogModifier(mod2)
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/flows"
 
@@ -49,9 +51,9 @@ type AirtimeTransferredEvent struct {
 }
 
 // NewAirtimeTransferred creates a new airtime transferred event
-func NewAirtimeTransferred(t *flows.AirtimeTransfer, httpLogs []*flows.HTTPLog) *AirtimeTransferredEvent {
+func NewAirtimeTransferred(createdOn time.Time, t *flows.AirtimeTransfer, httpLogs []*flows.HTTPLog) *AirtimeTransferredEvent {
 	return &AirtimeTransferredEvent{
-		baseEvent:     newBaseEvent(TypeAirtimeTransferred),
+		baseEvent:     newBaseEvent(TypeAirtimeTransferred, createdOn),
 		Sender:        t.Sender,
 		Recipient:     t.Recipient,
 		Currency:      t.Currency,
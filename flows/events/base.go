@@ -6,7 +6,6 @@ import (
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/utils"
-	"github.com/nyaruka/goflow/utils/dates"
 
 	"github.com/pkg/errors"
 )
@@ -26,8 +25,8 @@ type baseEvent struct {
 }
 
 // creates a new base event
-func newBaseEvent(typeName string) baseEvent {
-	return baseEvent{Type_: typeName, CreatedOn_: dates.Now()}
+func newBaseEvent(typeName string, createdOn time.Time) baseEvent {
+	return baseEvent{Type_: typeName, CreatedOn_: createdOn}
 }
 
 // Type returns the type of this event
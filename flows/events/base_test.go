@@ -25,7 +25,8 @@ func TestEventMarshaling(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+	now := time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)
+	dates.SetNowSource(dates.NewFixedNowSource(now))
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
@@ -41,6 +42,7 @@ func TestEventMarshaling(t *testing.T) {
 	}{
 		{
 			events.NewAirtimeTransferred(
+				now,
 				&flows.AirtimeTransfer{
 					Sender:        urns.URN("tel:+593979099111"),
 					Recipient:     urns.URN("tel:+593979099222"),
@@ -81,6 +83,7 @@ func TestEventMarshaling(t *testing.T) {
 		},
 		{
 			events.NewBroadcastCreated(
+				now,
 				map[envs.Language]*events.BroadcastTranslation{
 					"eng": {Text: "Hello", Attachments: nil, QuickReplies: nil},
 					"spa": {Text: "Hola", Attachments: nil, QuickReplies: nil},
@@ -125,6 +128,7 @@ func TestEventMarshaling(t *testing.T) {
 		},
 		{
 			events.NewClassifierCalled(
+				now,
 				assets.NewClassifierReference(assets.ClassifierUUID("4b937f49-7fb7-43a5-8e57-14e2f028a471"), "Booking"),
 				[]*flows.HTTPLog{
 					&flows.HTTPLog{
@@ -158,6 +162,7 @@ func TestEventMarshaling(t *testing.T) {
 		},
 		{
 			events.NewContactFieldChanged(
+				now,
 				gender,
 				flows.NewValue(types.NewXText("male"), nil, nil, "", "", ""),
 			),
@@ -175,6 +180,7 @@ func TestEventMarshaling(t *testing.T) {
 		},
 		{
 			events.NewContactFieldChanged(
+				now,
 				gender,
 				nil, // value being cleared
 			),
@@ -190,6 +196,7 @@ func TestEventMarshaling(t *testing.T) {
 		},
 		{
 			events.NewContactGroupsChanged(
+				now,
 				[]*flows.Group{session.Assets().Groups().FindByName("Customers")},
 				nil,
 			),
@@ -205,7 +212,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewContactLanguageChanged(envs.Language("fra")),
+			events.NewContactLanguageChanged(now, envs.Language("fra")),
 			`{
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"language": "fra",
@@ -213,7 +220,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewContactRefreshed(session.Contact()),
+			events.NewContactRefreshed(now, session.Contact()),
 			`{
 				"contact": {
 					"created_on": "2018-06-20T11:40:30.123456789Z",
@@ -259,7 +266,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewContactNameChanged("Bryan"),
+			events.NewContactNameChanged(now, "Bryan"),
 			`{
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"name": "Bryan",
@@ -267,7 +274,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewContactTimezoneChanged(tz),
+			events.NewContactTimezoneChanged(now, tz),
 			`{
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"timezone": "Africa/Kigali",
@@ -275,7 +282,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewContactURNsChanged([]urns.URN{
+			events.NewContactURNsChanged(now, []urns.URN{
 				urns.URN("tel:+12345678900"),
 				urns.URN("twitterid:8764843252522#bob"),
 			}),
@@ -289,7 +296,25 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewEnvironmentRefreshed(session.Environment()),
+			events.NewDialEnded(now, flows.NewDial(flows.DialStatusAnswered, 10)),
+			`{
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"dial": {"status": "answered", "duration": 10},
+				"type": "dial_ended"
+			}`,
+		},
+		{
+			events.NewDialWait(now, urns.URN("tel:+593979123456"), 60, 120),
+			`{
+				"call_limit_seconds": 120,
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"dial_limit_seconds": 60,
+				"type": "dial_wait",
+				"urn": "tel:+593979123456"
+			}`,
+		},
+		{
+			events.NewEnvironmentRefreshed(now, session.Environment()),
 			`{
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"environment": {
@@ -311,8 +336,44 @@ func TestEventMarshaling(t *testing.T) {
 				"type": "environment_refreshed"
 			}`,
 		},
+		{
+			events.NewLLMCalled(
+				now,
+				"Translate to French",
+				"Hello",
+				"Bonjour",
+				[]*flows.HTTPLog{
+					&flows.HTTPLog{
+						CreatedOn: dates.Now(),
+						ElapsedMS: 12,
+						Request:   "POST /response HTTP/1.1\r\nHost: api.acme.ai\r\n\r\n",
+						Response:  "HTTP/1.0 200 OK\r\nContent-Length: 7\r\n\r\nBonjour",
+						Status:    flows.CallStatusSuccess,
+						URL:       "https://api.acme.ai/response",
+					},
+				},
+			),
+			`{
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"http_logs": [
+					{
+						"created_on": "2018-10-18T14:20:30.000123456Z",
+						"elapsed_ms": 12,
+						"request": "POST /response HTTP/1.1\r\nHost: api.acme.ai\r\n\r\n",
+						"response": "HTTP/1.0 200 OK\r\nContent-Length: 7\r\n\r\nBonjour",
+						"status": "success",
+						"url": "https://api.acme.ai/response"
+					}
+				],
+				"input": "Hello",
+				"instructions": "Translate to French",
+				"output": "Bonjour",
+				"type": "llm_called"
+			}`,
+		},
 		{
 			events.NewIVRCreated(
+				now,
 				flows.NewMsgOut(
 					urns.URN("tel:+12345678900"),
 					assets.NewChannelReference(assets.ChannelUUID("57f1078f-88aa-46f4-a59a-948a5739c03d"), "My Android Phone"),
@@ -335,7 +396,7 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewMsgWait(&timeout, hints.NewImageHint()),
+			events.NewMsgWait(now, &timeout, hints.NewImageHint()),
 			`{
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"hint": {"type": "image"},
@@ -343,8 +404,17 @@ func TestEventMarshaling(t *testing.T) {
 				"type": "msg_wait"
 			}`,
 		},
+		{
+			events.NewSessionInterrupted(now, "new_trigger"),
+			`{
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"reason": "new_trigger",
+				"type": "session_interrupted"
+			}`,
+		},
 		{
 			events.NewSessionTriggered(
+				now,
 				assets.NewFlowReference(assets.FlowUUID("e4d441f0-24e3-4627-85fb-1e99e733baf0"), "Collect Age"),
 				[]*assets.GroupReference{
 					assets.NewGroupReference(assets.GroupUUID("5f9fd4f7-4b0f-462a-a598-18bfc7810412"), "Supervisors"),
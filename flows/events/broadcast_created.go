@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
@@ -56,9 +58,9 @@ type BroadcastCreatedEvent struct {
 }
 
 // NewBroadcastCreated creates a new outgoing msg event for the given recipients
-func NewBroadcastCreated(translations map[envs.Language]*BroadcastTranslation, baseLanguage envs.Language, groups []*assets.GroupReference, contacts []*flows.ContactReference, urns []urns.URN) *BroadcastCreatedEvent {
+func NewBroadcastCreated(createdOn time.Time, translations map[envs.Language]*BroadcastTranslation, baseLanguage envs.Language, groups []*assets.GroupReference, contacts []*flows.ContactReference, urns []urns.URN) *BroadcastCreatedEvent {
 	return &BroadcastCreatedEvent{
-		baseEvent:    newBaseEvent(TypeBroadcastCreated),
+		baseEvent:    newBaseEvent(TypeBroadcastCreated, createdOn),
 		Translations: translations,
 		BaseLanguage: baseLanguage,
 		Groups:       groups,
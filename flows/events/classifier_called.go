@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -39,9 +41,9 @@ type ClassifierCalledEvent struct {
 }
 
 // NewClassifierCalled returns a classifier called event
-func NewClassifierCalled(classifier *assets.ClassifierReference, httpLogs []*flows.HTTPLog) *ClassifierCalledEvent {
+func NewClassifierCalled(createdOn time.Time, classifier *assets.ClassifierReference, httpLogs []*flows.HTTPLog) *ClassifierCalledEvent {
 	return &ClassifierCalledEvent{
-		baseEvent:  newBaseEvent(TypeClassifierCalled),
+		baseEvent:  newBaseEvent(TypeClassifierCalled, createdOn),
 		Classifier: classifier,
 		HTTPLogs:   httpLogs,
 	}
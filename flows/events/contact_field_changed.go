@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -31,9 +33,9 @@ type ContactFieldChangedEvent struct {
 }
 
 // NewContactFieldChanged returns a new save to contact event
-func NewContactFieldChanged(field *flows.Field, value *flows.Value) *ContactFieldChangedEvent {
+func NewContactFieldChanged(createdOn time.Time, field *flows.Field, value *flows.Value) *ContactFieldChangedEvent {
 	return &ContactFieldChangedEvent{
-		baseEvent: newBaseEvent(TypeContactFieldChanged),
+		baseEvent: newBaseEvent(TypeContactFieldChanged, createdOn),
 		Field:     field.Reference(),
 		Value:     value,
 	}
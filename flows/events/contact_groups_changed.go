@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -30,9 +32,9 @@ type ContactGroupsChangedEvent struct {
 }
 
 // NewContactGroupsChanged returns a new contact_groups_changed event
-func NewContactGroupsChanged(added []*flows.Group, removed []*flows.Group) *ContactGroupsChangedEvent {
+func NewContactGroupsChanged(createdOn time.Time, added []*flows.Group, removed []*flows.Group) *ContactGroupsChangedEvent {
 	return &ContactGroupsChangedEvent{
-		baseEvent:     newBaseEvent(TypeContactGroupsChanged),
+		baseEvent:     newBaseEvent(TypeContactGroupsChanged, createdOn),
 		GroupsAdded:   groupsToReferences(added),
 		GroupsRemoved: groupsToReferences(removed),
 	}
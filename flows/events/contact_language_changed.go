@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -28,9 +30,9 @@ type ContactLanguageChangedEvent struct {
 }
 
 // NewContactLanguageChanged returns a new contact language changed event
-func NewContactLanguageChanged(language envs.Language) *ContactLanguageChangedEvent {
+func NewContactLanguageChanged(createdOn time.Time, language envs.Language) *ContactLanguageChangedEvent {
 	return &ContactLanguageChangedEvent{
-		baseEvent: newBaseEvent(TypeContactLanguageChanged),
+		baseEvent: newBaseEvent(TypeContactLanguageChanged, createdOn),
 		Language:  string(language),
 	}
 }
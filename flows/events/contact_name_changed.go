@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -27,9 +29,9 @@ type ContactNameChangedEvent struct {
 }
 
 // NewContactNameChanged returns a new contact name changed event
-func NewContactNameChanged(name string) *ContactNameChangedEvent {
+func NewContactNameChanged(createdOn time.Time, name string) *ContactNameChangedEvent {
 	return &ContactNameChangedEvent{
-		baseEvent: newBaseEvent(TypeContactNameChanged),
+		baseEvent: newBaseEvent(TypeContactNameChanged, createdOn),
 		Name:      name,
 	}
 }
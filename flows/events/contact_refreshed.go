@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 )
@@ -33,10 +34,10 @@ type ContactRefreshedEvent struct {
 }
 
 // NewContactRefreshed creates a new contact changed event
-func NewContactRefreshed(contact *flows.Contact) *ContactRefreshedEvent {
+func NewContactRefreshed(createdOn time.Time, contact *flows.Contact) *ContactRefreshedEvent {
 	marshalled, _ := json.Marshal(contact)
 	return &ContactRefreshedEvent{
-		baseEvent: newBaseEvent(TypeContactRefreshed),
+		baseEvent: newBaseEvent(TypeContactRefreshed, createdOn),
 		Contact:   marshalled,
 	}
 }
@@ -3,6 +3,7 @@ package events
 import (
 	"time"
 
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -29,14 +30,14 @@ type ContactTimezoneChangedEvent struct {
 }
 
 // NewContactTimezoneChanged returns a new contact timezone changed event
-func NewContactTimezoneChanged(timezone *time.Location) *ContactTimezoneChangedEvent {
+func NewContactTimezoneChanged(createdOn time.Time, timezone *time.Location) *ContactTimezoneChangedEvent {
 	var tzname string
 	if timezone != nil {
 		tzname = timezone.String()
 	}
 
 	return &ContactTimezoneChangedEvent{
-		baseEvent: newBaseEvent(TypeContactTimezoneChanged),
+		baseEvent: newBaseEvent(TypeContactTimezoneChanged, createdOn),
 		Timezone:  tzname,
 	}
 }
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -31,9 +33,9 @@ type ContactURNsChangedEvent struct {
 }
 
 // NewContactURNsChanged returns a new add URN event
-func NewContactURNsChanged(urns []urns.URN) *ContactURNsChangedEvent {
+func NewContactURNsChanged(createdOn time.Time, urns []urns.URN) *ContactURNsChangedEvent {
 	return &ContactURNsChangedEvent{
-		baseEvent: newBaseEvent(TypeContactURNsChanged),
+		baseEvent: newBaseEvent(TypeContactURNsChanged, createdOn),
 		URNs:      urns,
 	}
 }
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeDialEnded, func() flows.Event { return &DialEndedEvent{} })
+}
+
+// TypeDialEnded is the type of our dial ended event
+const TypeDialEnded string = "dial_ended"
+
+// DialEndedEvent events are sent by the caller to tell the engine that a forwarded call has ended
+// and that it should try to resume the session.
+//
+//   {
+//     "type": "dial_ended",
+//     "created_on": "2019-01-02T15:04:05Z",
+//     "dial": {
+//       "status": "answered",
+//       "duration": 10
+//     }
+//   }
+//
+// @event dial_ended
+type DialEndedEvent struct {
+	baseEvent
+
+	Dial *flows.Dial `json:"dial" validate:"required"`
+}
+
+// NewDialEnded creates a new dial ended event
+func NewDialEnded(createdOn time.Time, dial *flows.Dial) *DialEndedEvent {
+	return &DialEndedEvent{
+		baseEvent: newBaseEvent(TypeDialEnded, createdOn),
+		Dial:      dial,
+	}
+}
+
+var _ flows.Event = (*DialEndedEvent)(nil)
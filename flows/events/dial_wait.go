@@ -0,0 +1,47 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeDialWait, func() flows.Event { return &DialWaitEvent{} })
+}
+
+// TypeDialWait is the type of our dial wait event
+const TypeDialWait string = "dial_wait"
+
+// DialWaitEvent events are created when a voice flow pauses to forward the call to another phone number. The
+// caller should bridge the call to the given URN and then resume the session with the result of the dial.
+//
+//   {
+//     "type": "dial_wait",
+//     "created_on": "2019-01-02T15:04:05Z",
+//     "urn": "tel:+593979123456",
+//     "dial_limit_seconds": 60,
+//     "call_limit_seconds": 120
+//   }
+//
+// @event dial_wait
+type DialWaitEvent struct {
+	baseEvent
+
+	URN              urns.URN `json:"urn" validate:"required,urn"`
+	DialLimitSeconds int      `json:"dial_limit_seconds,omitempty"`
+	CallLimitSeconds int      `json:"call_limit_seconds,omitempty"`
+}
+
+// NewDialWait returns a new dial wait with the passed in URN and limits
+func NewDialWait(createdOn time.Time, urn urns.URN, dialLimitSeconds, callLimitSeconds int) *DialWaitEvent {
+	return &DialWaitEvent{
+		baseEvent:        newBaseEvent(TypeDialWait, createdOn),
+		URN:              urn,
+		DialLimitSeconds: dialLimitSeconds,
+		CallLimitSeconds: callLimitSeconds,
+	}
+}
+
+var _ flows.Event = (*DialWaitEvent)(nil)
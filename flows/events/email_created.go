@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -31,9 +33,9 @@ type EmailCreatedEvent struct {
 }
 
 // NewEmailCreated returns a new email event with the passed in subject, body and emails
-func NewEmailCreated(addresses []string, subject string, body string) *EmailCreatedEvent {
+func NewEmailCreated(createdOn time.Time, addresses []string, subject string, body string) *EmailCreatedEvent {
 	return &EmailCreatedEvent{
-		baseEvent: newBaseEvent(TypeEmailCreated),
+		baseEvent: newBaseEvent(TypeEmailCreated, createdOn),
 		Addresses: addresses,
 		Subject:   subject,
 		Body:      body,
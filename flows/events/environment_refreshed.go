@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
@@ -36,10 +37,10 @@ type EnvironmentRefreshedEvent struct {
 }
 
 // NewEnvironmentRefreshed creates a new environment changed event
-func NewEnvironmentRefreshed(env envs.Environment) *EnvironmentRefreshedEvent {
+func NewEnvironmentRefreshed(createdOn time.Time, env envs.Environment) *EnvironmentRefreshedEvent {
 	marshalled, _ := json.Marshal(env)
 	return &EnvironmentRefreshedEvent{
-		baseEvent:   newBaseEvent(TypeEnvironmentRefreshed),
+		baseEvent:   newBaseEvent(TypeEnvironmentRefreshed, createdOn),
 		Environment: marshalled,
 	}
 }
@@ -2,6 +2,7 @@ package events
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 )
@@ -29,17 +30,17 @@ type ErrorEvent struct {
 }
 
 // NewError returns a new error event for the passed in error
-func NewError(err error) *ErrorEvent {
+func NewError(createdOn time.Time, err error) *ErrorEvent {
 	return &ErrorEvent{
-		baseEvent: newBaseEvent(TypeError),
+		baseEvent: newBaseEvent(TypeError, createdOn),
 		Text:      err.Error(),
 	}
 }
 
 // NewErrorf returns a new error event for the passed in format string and args
-func NewErrorf(format string, a ...interface{}) *ErrorEvent {
+func NewErrorf(createdOn time.Time, format string, a ...interface{}) *ErrorEvent {
 	return &ErrorEvent{
-		baseEvent: newBaseEvent(TypeError),
+		baseEvent: newBaseEvent(TypeError, createdOn),
 		Text:      fmt.Sprintf(format, a...),
 	}
 }
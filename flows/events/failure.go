@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -27,9 +29,9 @@ type FailureEvent struct {
 }
 
 // NewFailure returns a new failure event for the passed in error
-func NewFailure(err error) *FailureEvent {
+func NewFailure(createdOn time.Time, err error) *FailureEvent {
 	return &FailureEvent{
-		baseEvent: newBaseEvent(TypeFailure),
+		baseEvent: newBaseEvent(TypeFailure, createdOn),
 		Text:      err.Error(),
 	}
 }
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -32,9 +34,9 @@ type FlowEnteredEvent struct {
 }
 
 // NewFlowEntered returns a new flow entered event for the passed in flow and parent run
-func NewFlowEntered(flow *assets.FlowReference, parentRunUUID flows.RunUUID, terminal bool) *FlowEnteredEvent {
+func NewFlowEntered(createdOn time.Time, flow *assets.FlowReference, parentRunUUID flows.RunUUID, terminal bool) *FlowEnteredEvent {
 	return &FlowEnteredEvent{
-		baseEvent:     newBaseEvent(TypeFlowEntered),
+		baseEvent:     newBaseEvent(TypeFlowEntered, createdOn),
 		Flow:          flow,
 		ParentRunUUID: parentRunUUID,
 		Terminal:      terminal,
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -30,9 +32,9 @@ type InputLabelsAddedEvent struct {
 }
 
 // NewInputLabelsAdded returns a new labels added event
-func NewInputLabelsAdded(inputUUID flows.InputUUID, labels []*flows.Label) *InputLabelsAddedEvent {
+func NewInputLabelsAdded(createdOn time.Time, inputUUID flows.InputUUID, labels []*flows.Label) *InputLabelsAddedEvent {
 	return &InputLabelsAddedEvent{
-		baseEvent: newBaseEvent(TypeInputLabelsAdded),
+		baseEvent: newBaseEvent(TypeInputLabelsAdded, createdOn),
 		InputUUID: inputUUID,
 		Labels:    labelsToReferences(labels),
 	}
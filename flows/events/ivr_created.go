@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -33,9 +35,9 @@ type IVRCreatedEvent struct {
 }
 
 // NewIVRCreated creates a new IVR created event
-func NewIVRCreated(msg *flows.MsgOut) *IVRCreatedEvent {
+func NewIVRCreated(createdOn time.Time, msg *flows.MsgOut) *IVRCreatedEvent {
 	return &IVRCreatedEvent{
-		baseEvent: newBaseEvent(TypeIVRCreated),
+		baseEvent: newBaseEvent(TypeIVRCreated, createdOn),
 		Msg:       msg,
 	}
 }
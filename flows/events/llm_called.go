@@ -0,0 +1,57 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeLLMCalled, func() flows.Event { return &LLMCalledEvent{} })
+}
+
+// TypeLLMCalled is our type for the LLM called event
+const TypeLLMCalled string = "llm_called"
+
+// LLMCalledEvent events are created when an LLM service is called.
+//
+//   {
+//     "type": "llm_called",
+//     "created_on": "2006-01-02T15:04:05Z",
+//     "instructions": "Translate to French",
+//     "input": "Hello",
+//     "output": "Bonjour",
+//     "http_logs": [
+//       {
+//         "url": "https://api.openai.com/v1/responses",
+//         "status": "success",
+//         "request": "POST /v1/responses HTTP/1.1",
+//         "response": "HTTP/1.1 200 OK\r\n\r\n{\"output\":\"Bonjour\"}",
+//         "created_on": "2006-01-02T15:04:05Z",
+//         "elapsed_ms": 123
+//       }
+//     ]
+//   }
+//
+// @event llm_called
+type LLMCalledEvent struct {
+	baseEvent
+
+	Instructions string           `json:"instructions" validate:"required"`
+	Input        string           `json:"input"`
+	Output       string           `json:"output,omitempty"`
+	HTTPLogs     []*flows.HTTPLog `json:"http_logs"`
+}
+
+// NewLLMCalled returns a new LLM called event
+func NewLLMCalled(createdOn time.Time, instructions, input, output string, httpLogs []*flows.HTTPLog) *LLMCalledEvent {
+	return &LLMCalledEvent{
+		baseEvent:    newBaseEvent(TypeLLMCalled, createdOn),
+		Instructions: instructions,
+		Input:        input,
+		Output:       output,
+		HTTPLogs:     httpLogs,
+	}
+}
+
+var _ flows.Event = (*LLMCalledEvent)(nil)
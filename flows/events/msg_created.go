@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -33,9 +35,9 @@ type MsgCreatedEvent struct {
 }
 
 // NewMsgCreated creates a new outgoing msg event to a single contact
-func NewMsgCreated(msg *flows.MsgOut) *MsgCreatedEvent {
+func NewMsgCreated(createdOn time.Time, msg *flows.MsgOut) *MsgCreatedEvent {
 	return &MsgCreatedEvent{
-		baseEvent: newBaseEvent(TypeMsgCreated),
+		baseEvent: newBaseEvent(TypeMsgCreated, createdOn),
 		Msg:       msg,
 	}
 }
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -34,9 +36,9 @@ type MsgReceivedEvent struct {
 }
 
 // NewMsgReceived creates a new incoming msg event for the passed in channel, URN and text
-func NewMsgReceived(msg *flows.MsgIn) *MsgReceivedEvent {
+func NewMsgReceived(createdOn time.Time, msg *flows.MsgIn) *MsgReceivedEvent {
 	return &MsgReceivedEvent{
-		baseEvent: newBaseEvent(TypeMsgReceived),
+		baseEvent: newBaseEvent(TypeMsgReceived, createdOn),
 		Msg:       *msg,
 	}
 }
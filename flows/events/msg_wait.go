@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/routers/waits/hints"
@@ -39,9 +40,9 @@ type MsgWaitEvent struct {
 }
 
 // NewMsgWait returns a new msg wait with the passed in timeout
-func NewMsgWait(timeoutSeconds *int, hint flows.Hint) *MsgWaitEvent {
+func NewMsgWait(createdOn time.Time, timeoutSeconds *int, hint flows.Hint) *MsgWaitEvent {
 	return &MsgWaitEvent{
-		baseEvent:      newBaseEvent(TypeMsgWait),
+		baseEvent:      newBaseEvent(TypeMsgWait, createdOn),
 		TimeoutSeconds: timeoutSeconds,
 		Hint:           hint,
 	}
@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 )
@@ -37,9 +38,9 @@ type ResthookCalledEvent struct {
 }
 
 // NewResthookCalled returns a new webhook called event
-func NewResthookCalled(resthook string, payload json.RawMessage) *ResthookCalledEvent {
+func NewResthookCalled(createdOn time.Time, resthook string, payload json.RawMessage) *ResthookCalledEvent {
 	return &ResthookCalledEvent{
-		baseEvent: newBaseEvent(TypeResthookCalled),
+		baseEvent: newBaseEvent(TypeResthookCalled, createdOn),
 		Resthook:  resthook,
 		Payload:   payload,
 	}
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -27,9 +29,9 @@ type RunExpiredEvent struct {
 }
 
 // NewRunExpired creates a new run expired event
-func NewRunExpired(run flows.FlowRun) *RunExpiredEvent {
+func NewRunExpired(createdOn time.Time, run flows.FlowRun) *RunExpiredEvent {
 	return &RunExpiredEvent{
-		baseEvent: newBaseEvent(TypeRunExpired),
+		baseEvent: newBaseEvent(TypeRunExpired, createdOn),
 		RunUUID:   run.UUID(),
 	}
 }
@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 )
@@ -41,9 +42,9 @@ type RunResultChangedEvent struct {
 }
 
 // NewRunResultChanged returns a new save result event for the passed in values
-func NewRunResultChanged(result *flows.Result) *RunResultChangedEvent {
+func NewRunResultChanged(createdOn time.Time, result *flows.Result) *RunResultChangedEvent {
 	return &RunResultChangedEvent{
-		baseEvent:         newBaseEvent(TypeRunResultChanged),
+		baseEvent:         newBaseEvent(TypeRunResultChanged, createdOn),
 		Name:              result.Name,
 		Value:             result.Value,
 		Category:          result.Category,
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeSessionInterrupted, func() flows.Event { return &SessionInterruptedEvent{} })
+}
+
+// TypeSessionInterrupted is the type of our session interrupted event
+const TypeSessionInterrupted string = "session_interrupted"
+
+// SessionInterruptedEvent events are created when the caller interrupts a session, e.g. because a new
+// trigger has arrived for the contact. All active and waiting runs are exited with an interrupted status.
+//
+//   {
+//     "type": "session_interrupted",
+//     "created_on": "2006-01-02T15:04:05Z",
+//     "reason": "new_trigger"
+//   }
+//
+// @event session_interrupted
+type SessionInterruptedEvent struct {
+	baseEvent
+
+	Reason string `json:"reason" validate:"required"`
+}
+
+// NewSessionInterrupted returns a new session interrupted event
+func NewSessionInterrupted(createdOn time.Time, reason string) *SessionInterruptedEvent {
+	return &SessionInterruptedEvent{
+		baseEvent: newBaseEvent(TypeSessionInterrupted, createdOn),
+		Reason:    reason,
+	}
+}
+
+var _ flows.Event = (*SessionInterruptedEvent)(nil)
@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/assets"
@@ -57,9 +58,9 @@ type SessionTriggeredEvent struct {
 }
 
 // NewSessionTriggered returns a new session triggered event
-func NewSessionTriggered(flow *assets.FlowReference, groups []*assets.GroupReference, contacts []*flows.ContactReference, contactQuery string, createContact bool, urns []urns.URN, runSummary json.RawMessage) *SessionTriggeredEvent {
+func NewSessionTriggered(createdOn time.Time, flow *assets.FlowReference, groups []*assets.GroupReference, contacts []*flows.ContactReference, contactQuery string, createContact bool, urns []urns.URN, runSummary json.RawMessage) *SessionTriggeredEvent {
 	return &SessionTriggeredEvent{
-		baseEvent:     newBaseEvent(TypeSessionTriggered),
+		baseEvent:     newBaseEvent(TypeSessionTriggered, createdOn),
 		Flow:          flow,
 		Groups:        groups,
 		Contacts:      contacts,
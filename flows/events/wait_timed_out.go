@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -25,8 +27,8 @@ type WaitTimedOutEvent struct {
 }
 
 // NewWaitTimedOut creates a new wait timed out event
-func NewWaitTimedOut() *WaitTimedOutEvent {
-	return &WaitTimedOutEvent{baseEvent: newBaseEvent(TypeWaitTimedOut)}
+func NewWaitTimedOut(createdOn time.Time) *WaitTimedOutEvent {
+	return &WaitTimedOutEvent{baseEvent: newBaseEvent(TypeWaitTimedOut, createdOn)}
 }
 
 var _ flows.Event = (*WaitTimedOutEvent)(nil)
@@ -3,6 +3,7 @@ package events
 import (
 	"time"
 
+
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -15,7 +16,8 @@ const TypeWebhookCalled string = "webhook_called"
 
 // WebhookCalledEvent events are created when a webhook is called. The event contains
 // the URL and the status of the response, as well as a full dump of the
-// request and response.
+// request and response. If the call was retried, details of every attempt are included in `attempts`,
+// and if the response was reused from an earlier call, `cached` will be true.
 //
 //   {
 //     "type": "webhook_called",
@@ -32,20 +34,41 @@ const TypeWebhookCalled string = "webhook_called"
 type WebhookCalledEvent struct {
 	baseEvent
 
-	URL         string           `json:"url" validate:"required"`
-	Status      flows.CallStatus `json:"status" validate:"required"`
-	Request     string           `json:"request" validate:"required"`
-	Response    string           `json:"response"`
-	ElapsedMS   int              `json:"elapsed_ms"`
-	Resthook    string           `json:"resthook,omitempty"`
-	StatusCode  int              `json:"status_code,omitempty"`
-	BodyIgnored bool             `json:"body_ignored,omitempty"`
+	URL         string            `json:"url" validate:"required"`
+	Status      flows.CallStatus  `json:"status" validate:"required"`
+	Request     string            `json:"request" validate:"required"`
+	Response    string            `json:"response"`
+	ElapsedMS   int               `json:"elapsed_ms"`
+	Resthook    string            `json:"resthook,omitempty"`
+	StatusCode  int               `json:"status_code,omitempty"`
+	BodyIgnored bool              `json:"body_ignored,omitempty"`
+	Cached      bool              `json:"cached,omitempty"`
+	Attempts    []*WebhookAttempt `json:"attempts,omitempty"`
+}
+
+// WebhookAttempt is a single attempt of a webhook call which was retried
+type WebhookAttempt struct {
+	Status     flows.CallStatus `json:"status" validate:"required"`
+	StatusCode int              `json:"status_code,omitempty"`
+	Request    string           `json:"request" validate:"required"`
+	Response   string           `json:"response"`
+	ElapsedMS  int              `json:"elapsed_ms"`
 }
 
 // NewWebhookCalled returns a new webhook called event
-func NewWebhookCalled(webhook *flows.WebhookCall) *WebhookCalledEvent {
+func NewWebhookCalled(createdOn time.Time, webhook *flows.WebhookCall) *WebhookCalledEvent {
+	// if the call was retried, record every attempt including the final one
+	var attempts []*WebhookAttempt
+	if len(webhook.Attempts) > 0 {
+		attempts = make([]*WebhookAttempt, 0, len(webhook.Attempts)+1)
+		for _, a := range webhook.Attempts {
+			attempts = append(attempts, newWebhookAttempt(a))
+		}
+		attempts = append(attempts, newWebhookAttempt(webhook))
+	}
+
 	return &WebhookCalledEvent{
-		baseEvent:   newBaseEvent(TypeWebhookCalled),
+		baseEvent:   newBaseEvent(TypeWebhookCalled, createdOn),
 		URL:         webhook.URL,
 		Status:      webhook.Status,
 		Request:     string(webhook.Request),
@@ -54,5 +77,17 @@ func NewWebhookCalled(webhook *flows.WebhookCall) *WebhookCalledEvent {
 		Resthook:    webhook.Resthook,
 		StatusCode:  webhook.StatusCode,
 		BodyIgnored: webhook.BodyIgnored,
+		Cached:      webhook.Cached,
+		Attempts:    attempts,
+	}
+}
+
+func newWebhookAttempt(call *flows.WebhookCall) *WebhookAttempt {
+	return &WebhookAttempt{
+		Status:     call.Status,
+		StatusCode: call.StatusCode,
+		Request:    string(call.Request),
+		Response:   string(call.Response),
+		ElapsedMS:  int(call.TimeTaken / time.Millisecond),
 	}
 }
@@ -37,6 +37,7 @@ var RunContextTopLevels = []string{
 	"legacy_extra",
 	"parent",
 	"results",
+	"resume",
 	"run",
 	"trigger",
 	"urns",
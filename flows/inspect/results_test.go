@@ -15,6 +15,10 @@ func TestResults(t *testing.T) {
 	n := definition.NewNode(flows.NodeUUID("866b06e2-ff54-443e-9d79-2f60074514b5"), []flows.Action{
 		actions.NewSetContactName(flows.ActionUUID("52a91ae8-1115-4c17-99a2-58b15ed7de7f"), "Bob"),
 		actions.NewSetRunResult(flows.ActionUUID("94790ebc-4f24-4664-a15d-ac758781c720"), "Age", "32", "HasAge"),
+		actions.NewCallWebhook(flows.ActionUUID("5b3ba2e5-d6a3-4a74-8e24-7ab4b4bb8f8a"), "GET", "http://temba.io", nil, "", "Lookup", "", []*actions.WebhookMapping{
+			actions.NewWebhookMapping("Name", "response.name"),
+			actions.NewWebhookMapping("Email", "response.email"),
+		}),
 	}, nil, []flows.Exit{})
 
 	infos := make([]*flows.ResultInfo, 0)
@@ -24,5 +28,8 @@ func TestResults(t *testing.T) {
 
 	assert.Equal(t, []*flows.ResultInfo{
 		flows.NewResultInfo("Age", []string{"HasAge"}, n),
+		flows.NewResultInfo("Lookup", []string{"Success", "Failure"}, n),
+		flows.NewResultInfo("Name", []string{"Success", "Failure"}, n),
+		flows.NewResultInfo("Email", []string{"Success", "Failure"}, n),
 	}, infos)
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/tools"
 	"github.com/nyaruka/goflow/flows"
 )
@@ -73,9 +74,9 @@ var fieldRefPaths = [][]string{
 }
 
 // ExtractFieldReferences extracts fields references from the given template
-func ExtractFieldReferences(template string) []*assets.FieldReference {
+func ExtractFieldReferences(env envs.Environment, template string) []*assets.FieldReference {
 	fieldRefs := make([]*assets.FieldReference, 0)
-	tools.FindContextRefsInTemplate(template, flows.RunContextTopLevels, func(path []string) {
+	tools.FindContextRefsInTemplate(env, template, flows.RunContextTopLevels, func(path []string) {
 		isField, fieldKey := isFieldRefPath(path)
 		if isField {
 			fieldRefs = append(fieldRefs, assets.NewFieldReference(fieldKey, ""))
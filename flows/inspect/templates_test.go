@@ -79,6 +79,8 @@ func TestTemplatePaths(t *testing.T) {
 		"$.nodes[*].actions[@.type=\"add_contact_urn\"].path",
 		"$.nodes[*].actions[@.type=\"add_input_labels\"].labels[*].name_match",
 		"$.nodes[*].actions[@.type=\"call_classifier\"].input",
+		"$.nodes[*].actions[@.type=\"call_llm\"].input",
+		"$.nodes[*].actions[@.type=\"call_llm\"].instructions",
 		"$.nodes[*].actions[@.type=\"call_webhook\"].body",
 		"$.nodes[*].actions[@.type=\"call_webhook\"].headers[*]",
 		"$.nodes[*].actions[@.type=\"call_webhook\"].url",
@@ -130,7 +132,7 @@ func TestExtractFieldReferences(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		actual := inspect.ExtractFieldReferences(tc.template)
+		actual := inspect.ExtractFieldReferences(nil, tc.template)
 
 		assert.Equal(t, tc.refs, actual, "field refs mismatch for template '%s'", tc.template)
 	}
@@ -7,8 +7,11 @@ import (
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 )
 
@@ -80,6 +83,9 @@ const (
 
 	// SessionStatusFailed represents a session that encountered an unrecoverable error
 	SessionStatusFailed SessionStatus = "failed"
+
+	// SessionStatusInterrupted represents a session that was interrupted by the caller
+	SessionStatusInterrupted SessionStatus = "interrupted"
 )
 
 // RunStatus represents the current status of the flow run
@@ -100,6 +106,9 @@ const (
 
 	// RunStatusExpired represents a run that expired due to inactivity
 	RunStatusExpired RunStatus = "expired"
+
+	// RunStatusInterrupted represents a run that was interrupted by the caller
+	RunStatusInterrupted RunStatus = "interrupted"
 )
 
 type FlowAssets interface {
@@ -186,6 +195,7 @@ type Router interface {
 
 	Wait() Wait
 	ResultName() string
+	Categories() []Category
 
 	Validate([]Exit) error
 	AllowTimeout() bool
@@ -197,6 +207,12 @@ type Router interface {
 	EnumerateResults(Node, func(*ResultInfo))
 }
 
+type Category interface {
+	UUID() CategoryUUID
+	Name() string
+	ExitUUID() ExitUUID
+}
+
 type Exit interface {
 	UUID() ExitUUID
 	DestinationUUID() NodeUUID
@@ -211,15 +227,19 @@ type Wait interface {
 	utils.Typed
 
 	Timeout() Timeout
+	AllowedFlowTypes() []FlowType
 
 	Begin(FlowRun, EventCallback) ActivatedWait
 	End(Resume) error
+
+	EnumerateTemplates(Localization, func(string))
 }
 
 type ActivatedWait interface {
 	utils.Typed
 
 	TimeoutSeconds() *int
+	TimeoutOn() *time.Time
 }
 
 type Hint interface {
@@ -237,6 +257,7 @@ type Localization interface {
 type Translations interface {
 	GetTextArray(uuids.UUID, string) []string
 	SetTextArray(uuids.UUID, string, []string)
+	Items() []uuids.UUID
 }
 
 // Trigger represents something which can initiate a session with the flow engine
@@ -265,6 +286,7 @@ type TriggerWithRun interface {
 // Resume represents something which can resume a session with the flow engine
 type Resume interface {
 	utils.Typed
+	Contextable
 
 	Apply(FlowRun, EventCallback) error
 
@@ -323,6 +345,9 @@ type Engine interface {
 	HTTPClient() *http.Client
 	Services() Services
 	MaxStepsPerSprint() int
+	NowSource() dates.NowSource
+	XFunctions() *functions.Registry
+	TemplateCache() *excellent.TemplateCache
 }
 
 // Sprint is an interaction with the engine - i.e. a start or resume of a session
@@ -356,6 +381,8 @@ type Session interface {
 	Wait() ActivatedWait
 
 	Resume(Resume) (Sprint, error)
+	Interrupt(string) (Sprint, error)
+	CurrentResume() Resume
 	Runs() []FlowRun
 	GetRun(RunUUID) (FlowRun, error)
 	GetCurrentChild(FlowRun) FlowRun
@@ -374,10 +401,13 @@ type RunSummary interface {
 	Results() Results
 }
 
-// RunEnvironment is a run specific environment which adds location functionality required by some router tests
+// RunEnvironment is a run specific environment which adds location functionality required by some router tests,
+// and the languages of the run which some router tests use to read text
 type RunEnvironment interface {
 	envs.Environment
 
+	Languages() []envs.Language
+	Locale() envs.Locale
 	FindLocations(string, utils.LocationLevel, *utils.Location) ([]*utils.Location, error)
 	FindLocationsFuzzy(string, utils.LocationLevel, *utils.Location) ([]*utils.Location, error)
 	LookupLocation(utils.LocationPath) (*utils.Location, error)
@@ -421,6 +451,8 @@ type FlowRun interface {
 	ResetExpiration(*time.Time)
 	ExitedOn() *time.Time
 	Exit(RunStatus)
+
+	Now() time.Time
 }
 
 // LegacyExtraContributor is something which contributes results for constructing @legacy_extra
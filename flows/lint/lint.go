@@ -0,0 +1,299 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/tools"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/actions"
+	"github.com/nyaruka/goflow/flows/inspect"
+	"github.com/nyaruka/goflow/flows/routers"
+	"github.com/nyaruka/goflow/utils/uuids"
+)
+
+// Severity is how serious a diagnostic is
+type Severity string
+
+// possible values for diagnostic severity
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// possible diagnostic codes
+const (
+	CodeInvalidTemplate      = "invalid_template"
+	CodeUnreachableNode      = "unreachable_node"
+	CodeUndefinedResult      = "undefined_result"
+	CodeTimeoutNoRoute       = "timeout_no_route"
+	CodeWebhookBeforeCall    = "webhook_before_call"
+	CodeOrphanedTranslation  = "orphaned_translation"
+	CodeCategoryWithoutCases = "category_without_cases"
+)
+
+// Diagnostic is a possible problem found in a flow
+type Diagnostic struct {
+	Severity   Severity         `json:"severity"`
+	Code       string           `json:"code"`
+	Message    string           `json:"message"`
+	NodeUUID   flows.NodeUUID   `json:"node_uuid,omitempty"`
+	ActionUUID flows.ActionUUID `json:"action_uuid,omitempty"`
+}
+
+// String returns a readable representation of this diagnostic
+func (d *Diagnostic) String() string {
+	location := ""
+	if d.ActionUUID != "" {
+		location = fmt.Sprintf(" [action=%s]", d.ActionUUID)
+	} else if d.NodeUUID != "" {
+		location = fmt.Sprintf(" [node=%s]", d.NodeUUID)
+	}
+	return fmt.Sprintf("%s: %s%s", d.Severity, d.Message, location)
+}
+
+// Lint checks the given flow for likely mistakes which don't make it invalid, returning diagnostics
+// in the order of the nodes they relate to. Templates are checked using the functions available in the
+// given environment, e.g. one carrying the registry of the engine the flow will run on.
+func Lint(env envs.Environment, flow flows.Flow) []*Diagnostic {
+	l := &linter{env: env, flow: flow, diagnostics: make([]*Diagnostic, 0)}
+	l.lint()
+	return l.diagnostics
+}
+
+type linter struct {
+	env         envs.Environment
+	flow        flows.Flow
+	diagnostics []*Diagnostic
+}
+
+func (l *linter) add(severity Severity, code string, nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, message string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, &Diagnostic{
+		Severity:   severity,
+		Code:       code,
+		Message:    fmt.Sprintf(message, args...),
+		NodeUUID:   nodeUUID,
+		ActionUUID: actionUUID,
+	})
+}
+
+func (l *linter) lint() {
+	nodes := l.flow.Nodes()
+	if len(nodes) == 0 {
+		return
+	}
+
+	reachable := l.reachableFrom([]flows.NodeUUID{nodes[0].UUID()})
+
+	// find the nodes which are reachable after a webhook call
+	callers := make([]flows.NodeUUID, 0)
+	for _, node := range nodes {
+		if callingIndex(node) >= 0 {
+			for _, exit := range node.Exits() {
+				if exit.DestinationUUID() != "" {
+					callers = append(callers, exit.DestinationUUID())
+				}
+			}
+		}
+	}
+	afterWebhook := l.reachableFrom(callers)
+
+	resultKeys := make(map[string]bool)
+	for _, result := range l.flow.ExtractResults() {
+		resultKeys[result.Key] = true
+	}
+
+	for _, node := range nodes {
+		if !reachable[node.UUID()] {
+			l.add(SeverityWarning, CodeUnreachableNode, node.UUID(), "", "node can't be reached from the start of the flow")
+		}
+
+		firstCall := callingIndex(node)
+
+		for i, action := range node.Actions() {
+			webhookSet := afterWebhook[node.UUID()] || (firstCall >= 0 && i > firstCall)
+
+			inspect.Templates(action, l.flow.Localization(), func(template string) {
+				l.checkTemplate(template, node.UUID(), action.UUID(), resultKeys, webhookSet)
+			})
+		}
+
+		if node.Router() != nil {
+			webhookSet := afterWebhook[node.UUID()] || firstCall >= 0
+
+			node.Router().EnumerateTemplates(l.flow.Localization(), func(template string) {
+				l.checkTemplate(template, node.UUID(), "", resultKeys, webhookSet)
+			})
+
+			l.checkRouter(node)
+		}
+	}
+
+	l.checkLocalization()
+}
+
+// gets the index of the first action in the node which makes a webhook call, or -1
+func callingIndex(node flows.Node) int {
+	for i, action := range node.Actions() {
+		if action.Type() == actions.TypeCallWebhook || action.Type() == actions.TypeCallResthook {
+			return i
+		}
+	}
+	return -1
+}
+
+// finds all the nodes which can be reached by following exits from the given nodes, including those nodes
+func (l *linter) reachableFrom(start []flows.NodeUUID) map[flows.NodeUUID]bool {
+	reached := make(map[flows.NodeUUID]bool)
+	queue := append([]flows.NodeUUID{}, start...)
+
+	for len(queue) > 0 {
+		nodeUUID := queue[0]
+		queue = queue[1:]
+
+		if reached[nodeUUID] {
+			continue
+		}
+		reached[nodeUUID] = true
+
+		node := l.flow.GetNode(nodeUUID)
+		if node == nil {
+			continue
+		}
+		for _, exit := range node.Exits() {
+			if exit.DestinationUUID() != "" {
+				queue = append(queue, exit.DestinationUUID())
+			}
+		}
+	}
+	return reached
+}
+
+func (l *linter) checkTemplate(template string, nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, resultKeys map[string]bool, webhookSet bool) {
+	undefinedResults := make([]string, 0)
+	usesWebhook := false
+
+	err := tools.FindContextRefsInTemplate(l.env, template, flows.RunContextTopLevels, func(path []string) {
+		resultKey := ""
+		if len(path) == 2 && strings.ToLower(path[0]) == "results" {
+			resultKey = strings.ToLower(path[1])
+		} else if len(path) == 3 && strings.ToLower(path[0]) == "run" && strings.ToLower(path[1]) == "results" {
+			resultKey = strings.ToLower(path[2])
+		}
+
+		if resultKey != "" && !resultKeys[resultKey] && !contains(undefinedResults, resultKey) {
+			undefinedResults = append(undefinedResults, resultKey)
+		}
+
+		if len(path) == 1 && strings.ToLower(path[0]) == "webhook" {
+			usesWebhook = true
+		}
+	})
+
+	if err != nil {
+		l.add(SeverityError, CodeInvalidTemplate, nodeUUID, actionUUID, "invalid template: %s", err)
+		return
+	}
+
+	for _, key := range undefinedResults {
+		l.add(SeverityWarning, CodeUndefinedResult, nodeUUID, actionUUID, "result '%s' is referenced but never set in this flow", key)
+	}
+
+	if usesWebhook && !webhookSet {
+		l.add(SeverityWarning, CodeWebhookBeforeCall, nodeUUID, actionUUID, "@webhook is used but no webhook call can precede it")
+	}
+}
+
+func (l *linter) checkRouter(node flows.Node) {
+	router := node.Router()
+
+	// check that a wait timeout goes somewhere
+	if router.AllowTimeout() {
+		for _, category := range router.Categories() {
+			if category.UUID() == router.Wait().Timeout().CategoryUUID() && !exitHasDestination(node, category.ExitUUID()) {
+				l.add(SeverityWarning, CodeTimeoutNoRoute, node.UUID(), "", "wait timeout category '%s' doesn't route anywhere", category.Name())
+			}
+		}
+	}
+
+	// check that every category of a switch router other than the default or timeout can be matched
+	if switchRouter, isSwitch := router.(*routers.SwitchRouter); isSwitch {
+		matched := make(map[flows.CategoryUUID]bool)
+		for _, c := range switchRouter.Cases() {
+			matched[c.CategoryUUID] = true
+		}
+		matched[switchRouter.DefaultCategory()] = true
+		if router.AllowTimeout() {
+			matched[router.Wait().Timeout().CategoryUUID()] = true
+		}
+
+		for _, category := range router.Categories() {
+			if !matched[category.UUID()] {
+				l.add(SeverityWarning, CodeCategoryWithoutCases, node.UUID(), "", "category '%s' has no cases and can never be used", category.Name())
+			}
+		}
+	}
+}
+
+func exitHasDestination(node flows.Node, exitUUID flows.ExitUUID) bool {
+	for _, exit := range node.Exits() {
+		if exit.UUID() == exitUUID {
+			return exit.DestinationUUID() != ""
+		}
+	}
+	return false
+}
+
+// checks for translations of items which no longer exist in the flow
+func (l *linter) checkLocalization() {
+	localization := l.flow.Localization()
+	if localization == nil {
+		return
+	}
+
+	items := make(map[uuids.UUID]bool)
+	for _, node := range l.flow.Nodes() {
+		for _, action := range node.Actions() {
+			items[uuids.UUID(action.UUID())] = true
+		}
+		if node.Router() != nil {
+			for _, category := range node.Router().Categories() {
+				items[uuids.UUID(category.UUID())] = true
+			}
+			if switchRouter, isSwitch := node.Router().(*routers.SwitchRouter); isSwitch {
+				for _, c := range switchRouter.Cases() {
+					items[c.UUID] = true
+				}
+			}
+		}
+	}
+
+	languages := localization.Languages()
+	sort.Slice(languages, func(i, j int) bool { return languages[i] < languages[j] })
+
+	for _, lang := range languages {
+		orphaned := make([]string, 0)
+		for _, item := range localization.GetTranslations(lang).Items() {
+			if !items[item] {
+				orphaned = append(orphaned, string(item))
+			}
+		}
+		sort.Strings(orphaned)
+
+		for _, item := range orphaned {
+			l.add(SeverityInfo, CodeOrphanedTranslation, "", "", "%s translation exists for item %s which isn't in the flow", lang, item)
+		}
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package lint_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/flows/definition"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/lint"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	flowJSON, err := ioutil.ReadFile("testdata/lint.json")
+	require.NoError(t, err)
+
+	flow, err := definition.ReadFlow(flowJSON)
+	require.NoError(t, err)
+
+	diagnostics := lint.Lint(envs.NewBuilder().Build(), flow)
+
+	assert.Equal(t, []*lint.Diagnostic{
+		{
+			Severity:   lint.SeverityWarning,
+			Code:       lint.CodeUndefinedResult,
+			Message:    "result 'age' is referenced but never set in this flow",
+			NodeUUID:   "a58be63b-907d-4a1a-856b-0bb5579d7507",
+			ActionUUID: "9d9a7164-0f19-4e8b-9e1a-4c2c5d8a2e8e",
+		},
+		{
+			Severity:   lint.SeverityWarning,
+			Code:       lint.CodeWebhookBeforeCall,
+			Message:    "@webhook is used but no webhook call can precede it",
+			NodeUUID:   "a58be63b-907d-4a1a-856b-0bb5579d7507",
+			ActionUUID: "9d9a7164-0f19-4e8b-9e1a-4c2c5d8a2e8e",
+		},
+		{
+			Severity: lint.SeverityWarning,
+			Code:     lint.CodeTimeoutNoRoute,
+			Message:  "wait timeout category 'No Response' doesn't route anywhere",
+			NodeUUID: "3dcccbb4-d29c-41dd-a01f-16d814c9ab82",
+		},
+		{
+			Severity: lint.SeverityWarning,
+			Code:     lint.CodeCategoryWithoutCases,
+			Message:  "category 'Blue' has no cases and can never be used",
+			NodeUUID: "3dcccbb4-d29c-41dd-a01f-16d814c9ab82",
+		},
+		{
+			Severity:   lint.SeverityWarning,
+			Code:       lint.CodeWebhookBeforeCall,
+			Message:    "@webhook is used but no webhook call can precede it",
+			NodeUUID:   "6cbb44f8-ba2d-4bb2-8a49-a8b2f2d4e1a0",
+			ActionUUID: "4cd3a6a4-9a2c-4b2e-8e58-5b1d8c3f7a55",
+		},
+		{
+			Severity: lint.SeverityWarning,
+			Code:     lint.CodeUnreachableNode,
+			Message:  "node can't be reached from the start of the flow",
+			NodeUUID: "d1f0e2a3-4b5c-4d6e-8f7a-9b0c1d2e3f40",
+		},
+		{
+			Severity:   lint.SeverityError,
+			Code:       lint.CodeInvalidTemplate,
+			Message:    "invalid template: error evaluating @(1 +): syntax error at ",
+			NodeUUID:   "d1f0e2a3-4b5c-4d6e-8f7a-9b0c1d2e3f40",
+			ActionUUID: "c2e4a6b8-0d1f-4a3c-9e5b-7d9f1a3c5e62",
+		},
+		{
+			Severity: lint.SeverityInfo,
+			Code:     lint.CodeOrphanedTranslation,
+			Message:  "spa translation exists for item e3a8b1c4-6d2f-4e9a-b7c5-0f1d3e5a7b96 which isn't in the flow",
+		},
+	}, diagnostics)
+
+	assert.Equal(t, "warning: node can't be reached from the start of the flow [node=d1f0e2a3-4b5c-4d6e-8f7a-9b0c1d2e3f40]", diagnostics[5].String())
+	assert.Equal(t, "error: invalid template: error evaluating @(1 +): syntax error at  [action=c2e4a6b8-0d1f-4a3c-9e5b-7d9f1a3c5e62]", diagnostics[6].String())
+	assert.Equal(t, "info: spa translation exists for item e3a8b1c4-6d2f-4e9a-b7c5-0f1d3e5a7b96 which isn't in the flow", diagnostics[7].String())
+
+	// a flow without problems has no diagnostics
+	clean := test.JSONReplace(flowJSON, []string{"nodes", "[0]", "actions", "[0]", "text"}, []byte(`"What is your favorite color?"`))
+	clean = test.JSONReplace(clean, []string{"nodes", "[1]", "router", "cases"}, []byte(`[
+		{"uuid": "98503572-25bf-40ce-ad72-8836b6549a38", "type": "has_any_word", "arguments": ["red"], "category_uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"},
+		{"uuid": "a51e5c8c-c891-401d-9c62-15fc37278c94", "type": "has_any_word", "arguments": ["blue"], "category_uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e"}
+	]`))
+	clean = test.JSONReplace(clean, []string{"nodes", "[1]", "exits", "[3]", "destination_uuid"}, []byte(`"6cbb44f8-ba2d-4bb2-8a49-a8b2f2d4e1a0"`))
+	clean = test.JSONReplace(clean, []string{"nodes", "[2]", "actions", "[0]", "text"}, []byte(`"Checking @results.favorite_color.category..."`))
+	clean = test.JSONReplace(clean, []string{"nodes", "[2]", "exits", "[0]", "destination_uuid"}, []byte(`"d1f0e2a3-4b5c-4d6e-8f7a-9b0c1d2e3f40"`))
+	clean = test.JSONReplace(clean, []string{"nodes", "[3]", "actions", "[0]", "text"}, []byte(`"Thanks @(shout(contact.name))"`))
+	clean = test.JSONDelete(clean, []string{"localization", "spa", "e3a8b1c4-6d2f-4e9a-b7c5-0f1d3e5a7b96"})
+
+	flow, err = definition.ReadFlow(clean)
+	require.NoError(t, err)
+
+	// flow uses a function which is only registered on the engine
+	eng := engine.NewBuilder().WithXFunction("shout", functions.OneTextFunction(func(env envs.Environment, s types.XText) types.XValue {
+		return types.NewXText(strings.ToUpper(s.Native()) + "!")
+	})).Build()
+	env := functions.NewScopedEnvironment(envs.NewBuilder().Build(), eng.XFunctions())
+
+	assert.Equal(t, []*lint.Diagnostic{}, lint.Lint(env, flow))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/triggers"
@@ -32,9 +33,13 @@ type baseResume struct {
 	resumedOn   time.Time
 }
 
-// creates a new base resume
+// creates a new base resume, timestamped by the clock of its environment if it has one
 func newBaseResume(typeName string, env envs.Environment, contact *flows.Contact) baseResume {
-	return baseResume{type_: typeName, environment: env, contact: contact, resumedOn: dates.Now()}
+	resumedOn := dates.Now()
+	if env != nil {
+		resumedOn = env.Now()
+	}
+	return baseResume{type_: typeName, environment: env, contact: contact, resumedOn: resumedOn}
 }
 
 // Type returns the type of this resume
@@ -48,14 +53,14 @@ func (r *baseResume) ResumedOn() time.Time          { return r.resumedOn }
 func (r *baseResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) error {
 	if r.environment != nil {
 		if !run.Session().Environment().Equal(r.environment) {
-			logEvent(events.NewEnvironmentRefreshed(r.environment))
+			logEvent(events.NewEnvironmentRefreshed(run.Now(), r.environment))
 		}
 
 		run.Session().SetEnvironment(r.environment)
 	}
 	if r.contact != nil {
 		if !run.Session().Contact().Equal(r.contact) {
-			logEvent(events.NewContactRefreshed(r.contact))
+			logEvent(events.NewContactRefreshed(run.Now(), r.contact))
 		}
 
 		run.Session().SetContact(r.contact)
@@ -70,6 +75,19 @@ func (r *baseResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) erro
 	return nil
 }
 
+// Context returns the properties available in expressions
+//
+//   type:text -> the type of resume that resumed this session
+//   dial:dial -> the result of the dial if this resume was the end of a dial wait
+//
+// @context resume
+func (r *baseResume) Context(env envs.Environment) map[string]types.XValue {
+	return map[string]types.XValue{
+		"type": types.NewXText(r.type_),
+		"dial": nil,
+	}
+}
+
 //------------------------------------------------------------------------------------------
 // JSON Encoding / Decoding
 //------------------------------------------------------------------------------------------
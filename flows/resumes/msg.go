@@ -67,7 +67,7 @@ func (r *MsgResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) error
 
 	run.Session().SetInput(input)
 	run.ResetExpiration(nil)
-	logEvent(events.NewMsgReceived(r.msg))
+	logEvent(events.NewMsgReceived(run.Now(), r.msg))
 
 	return r.baseResume.Apply(run, logEvent)
 }
@@ -47,7 +47,7 @@ func NewRunExpiration(env envs.Environment, contact *flows.Contact) *RunExpirati
 // Apply applies our state changes and saves any events to the run
 func (r *RunExpirationResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) error {
 	run.Exit(flows.RunStatusExpired)
-	logEvent(events.NewRunExpired(run))
+	logEvent(events.NewRunExpired(run.Now(), run))
 
 	return r.baseResume.Apply(run, logEvent)
 }
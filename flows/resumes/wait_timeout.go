@@ -48,7 +48,7 @@ func NewWaitTimeout(env envs.Environment, contact *flows.Contact) *WaitTimeoutRe
 func (r *WaitTimeoutResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) error {
 	// clear the last input
 	run.Session().SetInput(nil)
-	logEvent(events.NewWaitTimedOut())
+	logEvent(events.NewWaitTimedOut(run.Now()))
 
 	return r.baseResume.Apply(run, logEvent)
 }
@@ -61,8 +61,20 @@ func (r *baseRouter) AllowTimeout() bool {
 // ResultName returns the name which the result of this router should be saved as (if any)
 func (r *baseRouter) ResultName() string { return r.resultName }
 
+// Categories returns the categories of this router
+func (r *baseRouter) Categories() []flows.Category {
+	categories := make([]flows.Category, len(r.categories))
+	for i := range r.categories {
+		categories[i] = r.categories[i]
+	}
+	return categories
+}
+
 // EnumerateTemplates enumerates all expressions on this object and its children
 func (r *baseRouter) EnumerateTemplates(localization flows.Localization, include func(string)) {
+	if r.wait != nil {
+		r.wait.EnumerateTemplates(localization, include)
+	}
 }
 
 // EnumerateDependencies enumerates all dependencies on this object
@@ -166,9 +178,9 @@ func (r *baseRouter) routeToCategory(run flows.FlowRun, step flows.Step, categor
 		if extra != nil {
 			extraJSON, _ = json.Marshal(extra)
 		}
-		result := flows.NewResult(r.resultName, match, category.Name(), localizedCategory, step.NodeUUID(), input, extraJSON, dates.Now())
+		result := flows.NewResult(r.resultName, match, category.Name(), localizedCategory, step.NodeUUID(), input, extraJSON, run.Session().Engine().NowSource().Now())
 		run.SaveResult(result)
-		logEvent(events.NewRunResultChanged(result))
+		logEvent(events.NewRunResultChanged(run.Now(), result))
 	}
 
 	return category.ExitUUID(), nil
@@ -27,6 +27,9 @@ func init() {
 	for name, testFunc := range XTESTS {
 		functions.RegisterXFunction(name, testFunc)
 	}
+	for name, signature := range xtestSignatures {
+		functions.RegisterXSignature(name, functions.MustParseSignature(signature))
+	}
 }
 
 // RegisterXTest registers a new router test (and Excellent function)
@@ -76,6 +79,47 @@ var XTESTS = map[string]types.XFunction{
 	"has_ward":     HasWard,
 }
 
+// signatures of our router tests used for static checking of expressions
+var xtestSignatures = map[string]string{
+	"has_error": "(any) object",
+	"has_value": "(any) object",
+
+	"has_only_text":   "(text, text) object",
+	"has_phrase":      "(text, text) object",
+	"has_only_phrase": "(text, text) object",
+	"has_any_word":    "(text, text) object",
+	"has_all_words":   "(text, text) object",
+	"has_beginning":   "(text, text) object",
+	"has_text":        "(text) object",
+	"has_pattern":     "(text, text) object",
+
+	"has_number":         "(text) object",
+	"has_number_between": "(text, number, number) object",
+	"has_number_lt":      "(text, number) object",
+	"has_number_lte":     "(text, number) object",
+	"has_number_eq":      "(text, number) object",
+	"has_number_gte":     "(text, number) object",
+	"has_number_gt":      "(text, number) object",
+
+	"has_date":    "(text) object",
+	"has_date_lt": "(text, datetime) object",
+	"has_date_eq": "(text, datetime) object",
+	"has_date_gt": "(text, datetime) object",
+
+	"has_time":  "(text) object",
+	"has_phone": "(text, text?) object",
+	"has_email": "(text) object",
+	"has_group": "(array, text, text?) object",
+
+	"has_category":   "(object, text, text...) object",
+	"has_intent":     "(object, text, number) object",
+	"has_top_intent": "(object, text, number) object",
+
+	"has_state":    "(text) object",
+	"has_district": "(text, text?) object",
+	"has_ward":     "(text, text?, text?) object",
+}
+
 //------------------------------------------------------------------------------------------
 // Results
 //------------------------------------------------------------------------------------------
@@ -347,11 +391,13 @@ func HasPattern(env envs.Environment, text types.XText, pattern types.XText) typ
 	return FalseResult
 }
 
-// HasNumber tests whether `text` contains a number
+// HasNumber tests whether `text` contains a number. Numbers can be written in digits or in words
+// in any of the languages of the run, e.g. "twenty five" or "vingt-cinq". This applies to all the number tests.
 //
 //   @(has_number("the number is 42")) -> true
 //   @(has_number("the number is 42").match) -> 42
-//   @(has_number("the number is forty two")) -> false
+//   @(has_number("forty two").match) -> 42
+//   @(has_number("the number is none")) -> false
 //
 // @test has_number(text)
 func HasNumber(env envs.Environment, text types.XText) types.XValue {
@@ -449,10 +495,13 @@ func HasNumberGT(env envs.Environment, text types.XText, num types.XNumber) type
 	return testNumber(env, text, num, types.XNumberZero, isNumberGT)
 }
 
-// HasDate tests whether `text` contains a date formatted according to our environment
+// HasDate tests whether `text` contains a date formatted according to our environment, or written in
+// words in any of the languages of the run. Relative dates like "tomorrow" or "next friday" are resolved
+// against the current date.
 //
 //   @(has_date("the date is 15/01/2017")) -> true
 //   @(has_date("the date is 15/01/2017").match) -> 2017-01-15T13:24:30.123456-05:00
+//   @(has_date("the date is 15 January 2017").match) -> 2017-01-15T13:24:30.123456-05:00
 //   @(has_date("there is no date here, just a year 2017")) -> false
 //
 // @test has_date(text)
@@ -897,12 +946,18 @@ func ParseDecimalFuzzy(val string, format *envs.NumberFormat) (decimal.Decimal,
 type decimalTest func(value decimal.Decimal, test1 decimal.Decimal, test2 decimal.Decimal) bool
 
 func testNumber(env envs.Environment, str types.XText, testNum1 types.XNumber, testNum2 types.XNumber, testFunc decimalTest) types.XValue {
-	// create a number finding regex based on current environment
-	pattern := regexp.MustCompile(fmt.Sprintf(`[-+]?([\pN\%[1]s]+(\%[2]s[\pN]+)?|(\W|^)\%[2]s[\pN]+)`, env.NumberFormat().DigitGroupingSymbol, env.NumberFormat().DecimalSymbol))
+	// create a number finding regex based on current environment, though whitespace isn't treated as a grouping
+	// symbol because it's more likely to be separating numbers, e.g. "10 20"
+	format := env.NumberFormat()
+	grouping := format.DigitGroupingSymbol
+	if strings.TrimSpace(grouping) == "" {
+		grouping = ""
+	}
+	pattern := regexp.MustCompile(fmt.Sprintf(`[-+]?([\pN%[1]s]+(%[2]s[\pN]+)?|(\W|^)%[2]s[\pN]+)`, regexp.QuoteMeta(grouping), regexp.QuoteMeta(format.DecimalSymbol)))
 
 	// look for number like things in the input and use the first one that we can actually parse
 	for _, value := range pattern.FindAllString(str.Native(), -1) {
-		num, err := ParseDecimalFuzzy(value, env.NumberFormat())
+		num, err := ParseDecimalFuzzy(value, format)
 		if err == nil {
 			if testFunc(num, testNum1.Native(), testNum2.Native()) {
 				return NewTrueResult(types.NewXNumber(num))
@@ -910,6 +965,14 @@ func testNumber(env envs.Environment, str types.XText, testNum1 types.XNumber, t
 		}
 	}
 
+	// then look for numbers written in words
+	for _, value := range envs.FindSpelledNumbers(envs.LanguagesOf(env), str.Native()) {
+		num := decimal.New(value, 0)
+		if testFunc(num, testNum1.Native(), testNum2.Native()) {
+			return NewTrueResult(types.NewXNumber(num))
+		}
+	}
+
 	return FalseResult
 }
 
@@ -948,17 +1011,17 @@ func isNumberBetween(value decimal.Decimal, test1 decimal.Decimal, test2 decimal
 type dateTest func(dates.Date, dates.Date) bool
 
 func testDate(env envs.Environment, str types.XText, testDate types.XDateTime, testFunc dateTest) types.XValue {
-	// first parse with time filling which will be the test result
-	value, xerr := types.ToXDateTimeWithTimeFill(env, str)
+	// first parse with time filling which will be the test result, and allowing dates written in words
+	parsed, err := envs.DateTimeFromNaturalString(env, str.Native(), true)
+	if err != nil {
+		return FalseResult
+	}
+	value := types.NewXDateTime(parsed)
 
 	// but comparsion should be against only the date portions
 	valueAsDate := dates.ExtractDate(value.In(env.Timezone()).Native())
 	testAsDate := dates.ExtractDate(testDate.In(env.Timezone()).Native())
 
-	if xerr != nil {
-		return FalseResult
-	}
-
 	if testFunc(valueAsDate, testAsDate) {
 		return NewTrueResult(value)
 	}
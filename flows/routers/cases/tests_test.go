@@ -1,12 +1,14 @@
 package cases_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows/routers/cases"
 	"github.com/nyaruka/goflow/test"
@@ -121,6 +123,14 @@ var testTests = []struct {
 	{"has_number", []types.XValue{xs("hi .51")}, result(xn("0.51"))},
 	{"has_number", []types.XValue{xs(".51")}, result(xn("0.51"))},
 	{"has_number", []types.XValue{xs("nothing here")}, falseResult},
+	{"has_number", []types.XValue{xs("twenty five")}, result(xn("25"))},
+	{"has_number", []types.XValue{xs("I have Three Hundred and Six cows")}, result(xn("306"))},
+	{"has_number", []types.XValue{xs("vingt-cinq")}, falseResult}, // French isn't an environment language
+	{"has_number", []types.XValue{xs("j'ai une question")}, falseResult},
+	{"has_number", []types.XValue{xs("tengo una pregunta")}, falseResult},
+	{"has_number", []types.XValue{xs("tenho um problema")}, falseResult},
+	{"has_number", []types.XValue{xs("no one knows")}, falseResult},
+	{"has_number", []types.XValue{xs("once upon a time")}, falseResult},
 	{"has_number", []types.XValue{xs("lOO")}, falseResult}, // no longer do substitutions
 	{"has_number", []types.XValue{xs("one"), xs("two"), xs("three")}, ERROR},
 	{"has_number", []types.XValue{}, ERROR},
@@ -129,6 +139,7 @@ var testTests = []struct {
 	{"has_number_lt", []types.XValue{xs("another is -12.51"), xs("12")}, result(xn("-12.51"))},
 	{"has_number_lt", []types.XValue{xs("nothing here"), xs("12")}, falseResult},
 	{"has_number_lt", []types.XValue{xs("too big 15"), xs("12")}, falseResult},
+	{"has_number_lt", []types.XValue{xs("15 or eleven"), xs("12")}, result(xn("11"))},
 	{"has_number_lt", []types.XValue{xs("one"), xs("two"), xs("three")}, ERROR},
 	{"has_number_lt", []types.XValue{xs("but foo"), falseResult}, ERROR},
 	{"has_number_lt", []types.XValue{nil, xs("but foo")}, ERROR},
@@ -163,6 +174,7 @@ var testTests = []struct {
 	{"has_number_gt", []types.XValue{}, ERROR},
 
 	{"has_number_between", []types.XValue{xs("the number 10"), xs("8"), xs("12")}, result(xn("10"))},
+	{"has_number_between", []types.XValue{xs("one hundred or ten"), xs("8"), xs("12")}, result(xn("10"))},
 	{"has_number_between", []types.XValue{xs("24ans"), xn("20"), xn("24")}, result(xn("24"))},
 	{"has_number_between", []types.XValue{xs("another is -12.51"), xs("-12.51"), xs("-10")}, result(xn("-12.51"))},
 	{"has_number_between", []types.XValue{xs("nothing here"), xs("10"), xs("15")}, falseResult},
@@ -176,12 +188,17 @@ var testTests = []struct {
 	{"has_date", []types.XValue{xs("last date was 1.10.99")}, result(xd(time.Date(1999, 10, 1, 15, 24, 30, 123456000, kgl)))},
 	{"has_date", []types.XValue{xs("this isn't a valid date 33.2.99")}, falseResult},
 	{"has_date", []types.XValue{xs("no date at all")}, falseResult},
+	{"has_date", []types.XValue{xs("tomorrow")}, result(xd(time.Date(2018, 4, 12, 15, 24, 30, 123456000, kgl)))},
+	{"has_date", []types.XValue{xs("next friday at 9am")}, result(xd(time.Date(2018, 4, 13, 9, 0, 0, 0, kgl)))},
+	{"has_date", []types.XValue{xs("it was 15 march")}, result(xd(time.Date(2018, 3, 15, 15, 24, 30, 123456000, kgl)))},
 	{"has_date", []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_date", []types.XValue{}, ERROR},
 
 	{"has_date_lt", []types.XValue{xs("last date was 1.10.2017"), xs("3.10.2017")}, result(xd(time.Date(2017, 10, 1, 15, 24, 30, 123456000, kgl)))},
 	{"has_date_lt", []types.XValue{xs("last date was 1.10.99"), xs("3.10.98")}, falseResult},
 	{"has_date_lt", []types.XValue{xs("no date at all"), xs("3.10.98")}, falseResult},
+	{"has_date_lt", []types.XValue{xs("yesterday"), xs("11.4.2018")}, result(xd(time.Date(2018, 4, 10, 15, 24, 30, 123456000, kgl)))},
+	{"has_date_lt", []types.XValue{xs("today"), xs("11.4.2018")}, falseResult},
 	{"has_date_lt", []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_date_lt", []types.XValue{xs("last date was 1.10.2017"), nil}, ERROR},
 	{"has_date_lt", []types.XValue{nil, xs("but foo")}, ERROR},
@@ -192,12 +209,15 @@ var testTests = []struct {
 	{"has_date_eq", []types.XValue{xs("2017-10-01T23:55:55.123456+02:00"), xs("1.10.2017")}, result(xd(time.Date(2017, 10, 1, 23, 55, 55, 123456000, kgl)))},
 	{"has_date_eq", []types.XValue{xs("2017-10-01T23:55:55.123456+01:00"), xs("1.10.2017")}, falseResult}, // would have been 2017-10-02 in env timezone
 	{"has_date_eq", []types.XValue{xs("no date at all"), xs("3.10.98")}, falseResult},
+	{"has_date_eq", []types.XValue{xs("in 2 days"), xs("13.4.2018")}, result(xd(time.Date(2018, 4, 13, 15, 24, 30, 123456000, kgl)))},
 	{"has_date_eq", []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_date_eq", []types.XValue{}, ERROR},
 
 	{"has_date_gt", []types.XValue{xs("last date was 1.10.2017"), xs("3.10.2016")}, result(xd(time.Date(2017, 10, 1, 15, 24, 30, 123456000, kgl)))},
 	{"has_date_gt", []types.XValue{xs("last date was 1.10.99"), xs("3.10.01")}, falseResult},
 	{"has_date_gt", []types.XValue{xs("no date at all"), xs("3.10.98")}, falseResult},
+	{"has_date_gt", []types.XValue{xs("May 1st"), xs("11.4.2018")}, result(xd(time.Date(2018, 5, 1, 15, 24, 30, 123456000, kgl)))},
+	{"has_date_gt", []types.XValue{xs("last monday"), xs("11.4.2018")}, falseResult},
 	{"has_date_gt", []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_date_gt", []types.XValue{}, ERROR},
 
@@ -417,6 +437,47 @@ func TestTests(t *testing.T) {
 	}
 }
 
+func TestSignatures(t *testing.T) {
+	// every router test should have a signature so that it can be statically checked
+	for name := range cases.XTESTS {
+		assert.NotNil(t, functions.LookupSignature(name), "missing signature for test %s", name)
+	}
+}
+
+func TestSignatureArities(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	samples := map[functions.Type]types.XValue{
+		functions.TypeAny:      xs("1"),
+		functions.TypeText:     xs("1"),
+		functions.TypeNumber:   xi(1),
+		functions.TypeDateTime: xd(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		functions.TypeArray:    xa(xs("1")),
+		functions.TypeObject:   xj(`{"a": "1"}`),
+	}
+	argsFor := func(sig *functions.Signature, count int) []types.XValue {
+		args := make([]types.XValue, count)
+		for i := range args {
+			args[i] = samples[sig.ParamType(i)]
+		}
+		return args
+	}
+
+	// calling a test with fewer or more arguments than its signature allows should be an error
+	for name, test := range cases.XTESTS {
+		sig := functions.LookupSignature(name)
+		require.NotNil(t, sig, "missing signature for test %s", name)
+
+		if sig.MinArgs() > 0 {
+			result := test(env, argsFor(sig, sig.MinArgs()-1)...)
+			assert.True(t, types.IsXError(result), "expected error calling %s with %d args, got %s", name, sig.MinArgs()-1, result)
+		}
+		if sig.MaxArgs() >= 0 {
+			result := test(env, argsFor(sig, sig.MaxArgs()+1)...)
+			assert.True(t, types.IsXError(result), "expected error calling %s with %d args, got %s", name, sig.MaxArgs()+1, result)
+		}
+	}
+}
+
 func TestEvaluateTemplate(t *testing.T) {
 	vars := types.NewXObject(map[string]types.XValue{
 		"int1":   types.NewXNumberFromInt(1),
@@ -522,3 +583,36 @@ func TestParseDecimalFuzzy(t *testing.T) {
 		assert.Equal(t, test.expected, val, "parse decimal failed for input '%s'", test.input)
 	}
 }
+
+func TestHasNumberWithInferredFormat(t *testing.T) {
+	// French uses commas for decimals and Swiss German uses apostrophes to group digits
+	fra := envs.NewBuilder().WithAutoNumberFormat().WithDefaultLanguage("fra").WithDefaultCountry("FR").Build()
+	deu := envs.NewBuilder().WithAutoNumberFormat().WithDefaultLanguage("deu").WithDefaultCountry("CH").Build()
+
+	test.AssertXEqual(t, result(xn("1234.5")), cases.HasNumber(fra, xs("j'ai 1234,5 francs")))
+	test.AssertXEqual(t, result(xn("1234.5")), cases.HasNumber(deu, xs("1’234.5 Franken")))
+
+	// but spaces aren't treated as grouping digits as they usually separate numbers
+	test.AssertXEqual(t, result(xn("10")), cases.HasNumber(fra, xs("10 20")))
+	test.AssertXEqual(t, result(xn("3")), cases.HasNumber(fra, xs("I have 3 4")))
+
+	// environments read without a number format don't infer one
+	spa, err := envs.ReadEnvironment(json.RawMessage(`{"default_language": "spa", "default_country": "EC"}`))
+	require.NoError(t, err)
+	test.AssertXEqual(t, result(xn("1.5")), cases.HasNumber(spa, xs("1.5")))
+}
+
+func TestHasNumberInWords(t *testing.T) {
+	// outside of a run, numbers in words are read in the environment languages
+	env := envs.NewBuilder().WithDefaultLanguage("fra").WithAllowedLanguages([]envs.Language{"fra", "spa"}).Build()
+
+	test.AssertXEqual(t, result(xn("25")), cases.HasNumber(env, xs("vingt-cinq")))
+	test.AssertXEqual(t, result(xn("99")), cases.HasNumber(env, xs("quatre-vingt-dix-neuf ans")))
+	test.AssertXEqual(t, result(xn("35")), cases.HasNumber(env, xs("treinta y cinco")))
+	test.AssertXEqual(t, falseResult, cases.HasNumber(env, xs("twenty five")))
+	test.AssertXEqual(t, result(xn("-3")), cases.HasNumberLT(env, xs("moins trois"), xn("0")))
+	test.AssertXEqual(t, result(xn("1")), cases.HasNumber(env, xs("une")))
+	test.AssertXEqual(t, falseResult, cases.HasNumber(env, xs("j'ai une question")))
+	test.AssertXEqual(t, falseResult, cases.HasNumber(env, xs("tengo una pregunta")))
+	test.AssertXEqual(t, falseResult, cases.HasNumber(env, xs("once upon a time")))
+}
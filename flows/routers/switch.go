@@ -90,6 +90,9 @@ func NewSwitch(wait flows.Wait, resultName string, categories []*Category, opera
 
 func (r *SwitchRouter) Cases() []*Case { return r.cases }
 
+// DefaultCategory returns the UUID of the category used when no case matches
+func (r *SwitchRouter) DefaultCategory() flows.CategoryUUID { return r.default_ }
+
 // Validate validates the arguments for this router
 func (r *SwitchRouter) Validate(exits []flows.Exit) error {
 	// check the default category is valid
@@ -210,6 +213,8 @@ func (r *SwitchRouter) matchCase(run flows.FlowRun, step flows.Step, operand typ
 
 // EnumerateTemplates enumerates all expressions on this object and its children
 func (r *SwitchRouter) EnumerateTemplates(localization flows.Localization, include func(string)) {
+	r.baseRouter.EnumerateTemplates(localization, include)
+
 	include(r.operand)
 
 	inspect.Templates(r.cases, localization, include)
@@ -2,6 +2,7 @@ package waits
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/resumes"
@@ -52,6 +53,11 @@ func (w *baseWait) Type() string { return w.type_ }
 // Timeout returns the timeout of this wait or nil if no timeout is set
 func (w *baseWait) Timeout() flows.Timeout { return w.timeout }
 
+// AllowedFlowTypes returns the flow types which this wait is allowed to occur in
+func (w *baseWait) AllowedFlowTypes() []flows.FlowType {
+	return []flows.FlowType{flows.FlowTypeMessaging, flows.FlowTypeMessagingOffline, flows.FlowTypeVoice}
+}
+
 // End ends this wait or returns an error
 func (w *baseWait) End(resume flows.Resume) error {
 	switch resume.Type() {
@@ -66,15 +72,22 @@ func (w *baseWait) End(resume flows.Resume) error {
 	return nil
 }
 
+// EnumerateTemplates enumerates all expressions on this object
+func (w *baseWait) EnumerateTemplates(localization flows.Localization, include func(string)) {}
+
 type baseActivatedWait struct {
 	type_          string
 	timeoutSeconds *int
+	timeoutOn      *time.Time
 }
 
 func (w *baseActivatedWait) Type() string { return w.type_ }
 
 func (w *baseActivatedWait) TimeoutSeconds() *int { return w.timeoutSeconds }
 
+// TimeoutOn returns when this wait will time out, or nil if it doesn't have a timeout
+func (w *baseActivatedWait) TimeoutOn() *time.Time { return w.timeoutOn }
+
 //------------------------------------------------------------------------------------------
 // JSON Encoding / Decoding
 //------------------------------------------------------------------------------------------
@@ -125,18 +138,21 @@ func ReadActivatedWait(data json.RawMessage) (flows.ActivatedWait, error) {
 }
 
 type baseActivatedWaitEnvelope struct {
-	Type           string `json:"type" validate:"required"`
-	TimeoutSeconds *int   `json:"timeout_seconds,omitempty"`
+	Type           string     `json:"type" validate:"required"`
+	TimeoutSeconds *int       `json:"timeout_seconds,omitempty"`
+	TimeoutOn      *time.Time `json:"timeout_on,omitempty"`
 }
 
 func (w *baseActivatedWait) unmarshal(e *baseActivatedWaitEnvelope) error {
 	w.type_ = e.Type
 	w.timeoutSeconds = e.TimeoutSeconds
+	w.timeoutOn = e.TimeoutOn
 	return nil
 }
 
 func (w *baseActivatedWait) marshal(e *baseActivatedWaitEnvelope) error {
 	e.Type = w.type_
 	e.TimeoutSeconds = w.timeoutSeconds
+	e.TimeoutOn = w.timeoutOn
 	return nil
 }
@@ -38,4 +38,20 @@ func TestReadWait(t *testing.T) {
 	data, err = json.Marshal(wait)
 	assert.NoError(t, err)
 	assert.Equal(t, `{"type":"msg","hint":{"type":"image"}}`, string(data))
+
+	// read dial wait
+	wait, err = waits.ReadWait([]byte(`{"type": "dial", "phone": "@fields.agent", "dial_limit_seconds": 60}`))
+	assert.NoError(t, err)
+	assert.Equal(t, waits.TypeDial, wait.Type())
+	assert.Equal(t, "@fields.agent", wait.(*waits.DialWait).Phone())
+	assert.Equal(t, 60, wait.(*waits.DialWait).DialLimitSeconds())
+
+	// marshal back to JSON
+	data, err = json.Marshal(wait)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"dial","phone":"@fields.agent","dial_limit_seconds":60}`, string(data))
+
+	// error if dial wait has no phone
+	_, err = waits.ReadWait([]byte(`{"type": "dial"}`))
+	assert.EqualError(t, err, "field 'phone' is required")
 }
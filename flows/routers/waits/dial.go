@@ -0,0 +1,204 @@
+package waits
+
+import (
+	"encoding/json"
+
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/utils"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registerType(TypeDial, readDialWait, readActivatedDialWait)
+}
+
+// TypeDial is the type of our dial wait
+const TypeDial string = "dial"
+
+// DialWait is a wait which waits for a call to be forwarded to another phone number (i.e. a dial resume)
+type DialWait struct {
+	baseWait
+
+	phone            string
+	dialLimitSeconds int
+	callLimitSeconds int
+}
+
+// NewDialWait creates a new dial wait
+func NewDialWait(phone string, dialLimitSeconds, callLimitSeconds int) *DialWait {
+	return &DialWait{
+		baseWait:         newBaseWait(TypeDial, nil),
+		phone:            phone,
+		dialLimitSeconds: dialLimitSeconds,
+		callLimitSeconds: callLimitSeconds,
+	}
+}
+
+// Phone returns the phone number template
+func (w *DialWait) Phone() string { return w.phone }
+
+// DialLimitSeconds returns the number of seconds to let the phone ring for before giving up
+func (w *DialWait) DialLimitSeconds() int { return w.dialLimitSeconds }
+
+// CallLimitSeconds returns the maximum number of seconds the forwarded call can last
+func (w *DialWait) CallLimitSeconds() int { return w.callLimitSeconds }
+
+// AllowedFlowTypes returns the flow types which this wait is allowed to occur in
+func (w *DialWait) AllowedFlowTypes() []flows.FlowType {
+	return []flows.FlowType{flows.FlowTypeVoice}
+}
+
+// Begin beings waiting at this wait
+func (w *DialWait) Begin(run flows.FlowRun, log flows.EventCallback) flows.ActivatedWait {
+	phone, err := run.EvaluateTemplate(w.phone)
+	if err != nil {
+		log(events.NewError(run.Now(), err))
+	}
+
+	country := string(run.Environment().DefaultCountry())
+
+	// if we can't parse the phone number, there's nothing to dial so skip this wait
+	urn, err := urns.NewTelURNForCountry(phone, country)
+	if err != nil {
+		log(events.NewErrorf(run.Now(), "unable to dial '%s': %s", phone, err.Error()))
+		return nil
+	}
+
+	log(events.NewDialWait(run.Now(), urn, w.dialLimitSeconds, w.callLimitSeconds))
+
+	return NewActivatedDialWait(urn, w.dialLimitSeconds, w.callLimitSeconds)
+}
+
+// End ends this wait or returns an error
+func (w *DialWait) End(resume flows.Resume) error {
+	// if we have a dial result we can definitely resume
+	if resume.Type() == resumes.TypeDial {
+		return nil
+	}
+	if resume.Type() == resumes.TypeMsg {
+		return errors.Errorf("can't end a dial wait with a msg resume")
+	}
+
+	return w.baseWait.End(resume)
+}
+
+// EnumerateTemplates enumerates all expressions on this object
+func (w *DialWait) EnumerateTemplates(localization flows.Localization, include func(string)) {
+	include(w.phone)
+}
+
+var _ flows.Wait = (*DialWait)(nil)
+
+// ActivatedDialWait is an activated dial wait which tells the caller which URN to forward the call to
+type ActivatedDialWait struct {
+	baseActivatedWait
+
+	urn              urns.URN
+	dialLimitSeconds int
+	callLimitSeconds int
+}
+
+// NewActivatedDialWait creates a new activated dial wait
+func NewActivatedDialWait(urn urns.URN, dialLimitSeconds, callLimitSeconds int) *ActivatedDialWait {
+	return &ActivatedDialWait{
+		baseActivatedWait: baseActivatedWait{type_: TypeDial},
+		urn:               urn,
+		dialLimitSeconds:  dialLimitSeconds,
+		callLimitSeconds:  callLimitSeconds,
+	}
+}
+
+// URN returns the URN to be dialed
+func (w *ActivatedDialWait) URN() urns.URN { return w.urn }
+
+// DialLimitSeconds returns the number of seconds to let the phone ring for before giving up
+func (w *ActivatedDialWait) DialLimitSeconds() int { return w.dialLimitSeconds }
+
+// CallLimitSeconds returns the maximum number of seconds the forwarded call can last
+func (w *ActivatedDialWait) CallLimitSeconds() int { return w.callLimitSeconds }
+
+var _ flows.ActivatedWait = (*ActivatedDialWait)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type dialWaitEnvelope struct {
+	baseWaitEnvelope
+
+	Phone            string `json:"phone"                        validate:"required"`
+	DialLimitSeconds int    `json:"dial_limit_seconds,omitempty" validate:"min=0"`
+	CallLimitSeconds int    `json:"call_limit_seconds,omitempty" validate:"min=0"`
+}
+
+func readDialWait(data json.RawMessage) (flows.Wait, error) {
+	e := &dialWaitEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	w := &DialWait{
+		phone:            e.Phone,
+		dialLimitSeconds: e.DialLimitSeconds,
+		callLimitSeconds: e.CallLimitSeconds,
+	}
+
+	return w, w.unmarshal(&e.baseWaitEnvelope)
+}
+
+// MarshalJSON marshals this wait into JSON
+func (w *DialWait) MarshalJSON() ([]byte, error) {
+	e := &dialWaitEnvelope{
+		Phone:            w.phone,
+		DialLimitSeconds: w.dialLimitSeconds,
+		CallLimitSeconds: w.callLimitSeconds,
+	}
+
+	if err := w.marshal(&e.baseWaitEnvelope); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(e)
+}
+
+type activatedDialWaitEnvelope struct {
+	baseActivatedWaitEnvelope
+
+	URN              urns.URN `json:"urn"                          validate:"required,urn"`
+	DialLimitSeconds int      `json:"dial_limit_seconds,omitempty" validate:"min=0"`
+	CallLimitSeconds int      `json:"call_limit_seconds,omitempty" validate:"min=0"`
+}
+
+func readActivatedDialWait(data json.RawMessage) (flows.ActivatedWait, error) {
+	e := &activatedDialWaitEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	w := &ActivatedDialWait{
+		urn:              e.URN,
+		dialLimitSeconds: e.DialLimitSeconds,
+		callLimitSeconds: e.CallLimitSeconds,
+	}
+
+	return w, w.unmarshal(&e.baseActivatedWaitEnvelope)
+}
+
+// MarshalJSON marshals this wait into JSON
+func (w *ActivatedDialWait) MarshalJSON() ([]byte, error) {
+	e := &activatedDialWaitEnvelope{
+		URN:              w.urn,
+		DialLimitSeconds: w.dialLimitSeconds,
+		CallLimitSeconds: w.callLimitSeconds,
+	}
+
+	if err := w.marshal(&e.baseActivatedWaitEnvelope); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(e)
+}
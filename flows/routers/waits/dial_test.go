@@ -0,0 +1,78 @@
+package waits_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/routers/waits"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialWait(t *testing.T) {
+	wait := waits.NewDialWait("+593979123456", 60, 120)
+	marshaled, err := json.Marshal(wait)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"dial","phone":"+593979123456","dial_limit_seconds":60,"call_limit_seconds":120}`, string(marshaled))
+
+	assert.Equal(t, []flows.FlowType{flows.FlowTypeVoice}, wait.AllowedFlowTypes())
+
+	// phone number is a template
+	templates := make([]string, 0)
+	wait.EnumerateTemplates(nil, func(t string) { templates = append(templates, t) })
+	assert.Equal(t, []string{"+593979123456"}, templates)
+
+	env := envs.NewBuilder().Build()
+
+	// can be ended by a dial resume or a run expiration, but not by a msg resume
+	assert.NoError(t, wait.End(resumes.NewDial(env, nil, flows.NewDial(flows.DialStatusAnswered, 10))))
+	assert.NoError(t, wait.End(resumes.NewRunExpiration(env, nil)))
+	assert.EqualError(t, wait.End(resumes.NewMsg(env, nil, nil)), "can't end a dial wait with a msg resume")
+	assert.EqualError(t, wait.End(resumes.NewWaitTimeout(env, nil)), "can't end with timeout as wait doesn't have a timeout")
+
+	// and msg waits can't be ended by dial resumes
+	msgWait := waits.NewMsgWait(nil, nil)
+	assert.EqualError(t, msgWait.End(resumes.NewDial(env, nil, flows.NewDial(flows.DialStatusBusy, 0))), "can't end a msg wait with a dial resume")
+}
+
+func TestDialWaitBegin(t *testing.T) {
+	session, _, err := test.CreateTestVoiceSession("")
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	log := test.NewEventLog()
+
+	wait := waits.NewDialWait(`@("+1 206 555 " & "1313")`, 60, 120)
+	activated := wait.Begin(run, log.Log).(*waits.ActivatedDialWait)
+
+	assert.Equal(t, waits.TypeDial, activated.Type())
+	assert.Nil(t, activated.TimeoutSeconds())
+	assert.Equal(t, "tel:+12065551313", string(activated.URN()))
+	assert.Equal(t, 60, activated.DialLimitSeconds())
+	assert.Equal(t, 120, activated.CallLimitSeconds())
+
+	require.Equal(t, 1, len(log.Events))
+	assert.Equal(t, "dial_wait", log.Events[0].Type())
+
+	marshaled, err := json.Marshal(activated)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"dial","urn":"tel:+12065551313","dial_limit_seconds":60,"call_limit_seconds":120}`, string(marshaled))
+
+	// and read back
+	read, err := waits.ReadActivatedWait(marshaled)
+	require.NoError(t, err)
+	assert.Equal(t, activated, read)
+
+	// if phone number isn't valid, we log an error and skip the wait
+	log = test.NewEventLog()
+	wait = waits.NewDialWait(`@("!!")`, 60, 120)
+	assert.Nil(t, wait.Begin(run, log.Log))
+
+	require.Equal(t, 1, len(log.Events))
+	assert.Equal(t, "error", log.Events[0].Type())
+}
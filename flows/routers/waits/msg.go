@@ -2,6 +2,7 @@ package waits
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
@@ -58,9 +59,18 @@ func (w *MsgWait) Begin(run flows.FlowRun, log flows.EventCallback) flows.Activa
 		return nil
 	}
 
-	log(events.NewMsgWait(timeoutSeconds, w.hint))
+	now := run.Now()
 
-	return NewActivatedMsgWait(timeoutSeconds, w.hint)
+	log(events.NewMsgWait(now, timeoutSeconds, w.hint))
+
+	// the timeout starts from when we began waiting
+	var timeoutOn *time.Time
+	if timeoutSeconds != nil {
+		t := now.Add(time.Second * time.Duration(*timeoutSeconds))
+		timeoutOn = &t
+	}
+
+	return NewActivatedMsgWait(timeoutSeconds, timeoutOn, w.hint)
 }
 
 // End ends this wait or returns an error
@@ -69,6 +79,9 @@ func (w *MsgWait) End(resume flows.Resume) error {
 	if resume.Type() == resumes.TypeMsg {
 		return nil
 	}
+	if resume.Type() == resumes.TypeDial {
+		return errors.Errorf("can't end a msg wait with a dial resume")
+	}
 
 	return w.baseWait.End(resume)
 }
@@ -81,9 +94,9 @@ type ActivatedMsgWait struct {
 	hint flows.Hint
 }
 
-func NewActivatedMsgWait(timeoutSeconds *int, hint flows.Hint) *ActivatedMsgWait {
+func NewActivatedMsgWait(timeoutSeconds *int, timeoutOn *time.Time, hint flows.Hint) *ActivatedMsgWait {
 	return &ActivatedMsgWait{
-		baseActivatedWait: baseActivatedWait{type_: TypeMsg, timeoutSeconds: timeoutSeconds},
+		baseActivatedWait: baseActivatedWait{type_: TypeMsg, timeoutSeconds: timeoutSeconds, timeoutOn: timeoutOn},
 		hint:              hint,
 	}
 }
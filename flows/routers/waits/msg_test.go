@@ -11,6 +11,7 @@ import (
 	"github.com/nyaruka/goflow/flows/routers/waits/hints"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/test"
+	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/stretchr/testify/assert"
@@ -74,7 +75,7 @@ func TestMsgWaitSkipIfInitial(t *testing.T) {
 	eng := test.NewEngine()
 	env := envs.NewBuilder().Build()
 	sa, flow := initializeSessionAssets(t)
-	contact := flows.NewEmptyContact(sa, "Ben Haggerty", envs.Language("eng"), nil)
+	contact := flows.NewEmptyContact(sa, "Ben Haggerty", envs.Language("eng"), nil, dates.Now())
 
 	// a manual trigger will wait at the initial wait
 	trigger := triggers.NewManual(env, flow.Reference(), contact, nil)
@@ -5,8 +5,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/utils"
 
@@ -34,6 +36,36 @@ func (e *runEnvironment) Timezone() *time.Location {
 	return e.run.Session().Environment().Timezone()
 }
 
+// Languages returns the languages of the run in the order they're used for localization
+func (e *runEnvironment) Languages() []envs.Language {
+	return e.run.getLanguages()
+}
+
+// Locale returns the locale of the contact, which is the first language of the run and the country of the contact's
+// preferred phone number, falling back to the default country
+func (e *runEnvironment) Locale() envs.Locale {
+	language := envs.NilLanguage
+	if languages := e.Languages(); len(languages) > 0 {
+		language = languages[0]
+	}
+
+	country := e.DefaultCountry()
+	if contact := e.run.Contact(); contact != nil {
+		if urn := contact.PreferredURN(); urn != nil && urn.URN().Scheme() == urns.TelScheme {
+			if telCountry := utils.DeriveCountryFromTel(urn.URN().Path()); telCountry != "" {
+				country = envs.Country(telCountry)
+			}
+		}
+	}
+
+	return envs.NewLocale(language, country)
+}
+
+// XFunctions returns the Excellent functions registered on the engine, which are looked up before the builtins
+func (e *runEnvironment) XFunctions() *functions.Registry {
+	return e.run.Session().Engine().XFunctions()
+}
+
 func (e *runEnvironment) Locations() (assets.LocationHierarchy, error) {
 	sessionAssets := e.run.Session().Assets()
 	hierarchies := sessionAssets.Locations().Hierarchies()
@@ -2,16 +2,15 @@ package runs
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
-	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/utils"
-	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/pkg/errors"
@@ -41,7 +40,7 @@ type flowRun struct {
 
 // NewRun initializes a new context and flow run for the passed in flow and contact
 func NewRun(session flows.Session, flow flows.Flow, parent flows.FlowRun) flows.FlowRun {
-	now := dates.Now()
+	now := session.Engine().NowSource().Now()
 	r := &flowRun{
 		uuid:       flows.RunUUID(uuids.New()),
 		session:    session,
@@ -80,13 +79,13 @@ func (r *flowRun) SaveResult(result *flows.Result) {
 	}
 
 	r.results.Save(result)
-	r.modifiedOn = dates.Now()
+	r.modifiedOn = r.Now()
 
 	r.legacyExtra.addResult(result)
 }
 
 func (r *flowRun) Exit(status flows.RunStatus) {
-	now := dates.Now()
+	now := r.Now()
 
 	r.status = status
 	r.exitedOn = &now
@@ -95,7 +94,7 @@ func (r *flowRun) Exit(status flows.RunStatus) {
 func (r *flowRun) Status() flows.RunStatus { return r.status }
 func (r *flowRun) SetStatus(status flows.RunStatus) {
 	r.status = status
-	r.modifiedOn = dates.Now()
+	r.modifiedOn = r.Now()
 }
 
 // ParentInSession returns the parent of the run within the same session if one exists
@@ -135,16 +134,16 @@ func (r *flowRun) LogEvent(s flows.Step, event flows.Event) {
 	}
 
 	r.events = append(r.events, event)
-	r.modifiedOn = dates.Now()
+	r.modifiedOn = r.Now()
 }
 
 func (r *flowRun) LogError(step flows.Step, err error) {
-	r.LogEvent(step, events.NewError(err))
+	r.LogEvent(step, events.NewError(r.Now(), err))
 }
 
 func (r *flowRun) Path() []flows.Step { return r.path }
 func (r *flowRun) CreateStep(node flows.Node) flows.Step {
-	now := dates.Now()
+	now := r.Now()
 	step := NewStep(node, now)
 	r.path = append(r.path, step)
 	r.modifiedOn = now
@@ -173,7 +172,7 @@ func (r *flowRun) ExpiresOn() *time.Time { return r.expiresOn }
 func (r *flowRun) ResetExpiration(from *time.Time) {
 	if r.Flow() != nil && r.Flow().ExpireAfterMinutes() >= 0 {
 		if from == nil {
-			now := dates.Now()
+			now := r.Now()
 			from = &now
 		}
 
@@ -181,7 +180,7 @@ func (r *flowRun) ResetExpiration(from *time.Time) {
 		expiresOn := from.Add(expiresAfterMinutes * time.Minute)
 
 		r.expiresOn = &expiresOn
-		r.modifiedOn = dates.Now()
+		r.modifiedOn = r.Now()
 	}
 
 	if r.ParentInSession() != nil {
@@ -191,6 +190,11 @@ func (r *flowRun) ResetExpiration(from *time.Time) {
 
 func (r *flowRun) ExitedOn() *time.Time { return r.exitedOn }
 
+// Now gets the current time according to the clock of our session's engine
+func (r *flowRun) Now() time.Time {
+	return r.session.Engine().NowSource().Now()
+}
+
 // RootContext returns the root context for expression evaluation
 //
 //   contact:contact -> the contact
@@ -203,6 +207,7 @@ func (r *flowRun) ExitedOn() *time.Time { return r.exitedOn }
 //   parent:related_run -> the parent of the run
 //   webhook:any -> the parsed JSON response of the last webhook call
 //   trigger:trigger -> the trigger that started this session
+//   resume:resume -> the resume that resumed this session
 //
 // @context root
 func (r *flowRun) RootContext(env envs.Environment) map[string]types.XValue {
@@ -230,6 +235,7 @@ func (r *flowRun) RootContext(env envs.Environment) map[string]types.XValue {
 
 		// other
 		"trigger":      flows.Context(env, r.Session().Trigger()),
+		"resume":       flows.Context(env, r.Session().CurrentResume()),
 		"input":        flows.Context(env, r.Session().Input()),
 		"legacy_extra": r.legacyExtra.ToXValue(env),
 	}
@@ -281,29 +287,34 @@ func (r *flowRun) Context(env envs.Environment) map[string]types.XValue {
 // EvaluateTemplate evaluates the given template in the context of this run
 func (r *flowRun) EvaluateTemplateValue(template string) (types.XValue, error) {
 	context := types.NewXObject(r.RootContext(r.Environment()))
+	compiled := r.Session().Engine().TemplateCache().Get(strings.TrimSpace(template), context.Properties())
 
-	return excellent.EvaluateTemplateValue(r.Environment(), context, template)
+	return compiled.EvaluateValue(r.Environment(), context)
 }
 
 // EvaluateTemplateAsString evaluates the given template as a string in the context of this run
 func (r *flowRun) EvaluateTemplate(template string) (string, error) {
 	context := types.NewXObject(r.RootContext(r.Environment()))
+	compiled := r.Session().Engine().TemplateCache().Get(template, context.Properties())
 
-	return excellent.EvaluateTemplate(r.Environment(), context, template)
+	return compiled.Evaluate(r.Environment(), context)
 }
 
 // get the ordered list of languages to be used for localization in this run
 func (r *flowRun) getLanguages() []envs.Language {
 	// TODO cache this this?
 
-	contact := r.Contact()
+	contactLanguage := envs.NilLanguage
+	if r.Contact() != nil {
+		contactLanguage = r.Contact().Language()
+	}
 	languages := make([]envs.Language, 0, 3)
 
 	// if contact has a allowed language, it takes priority
-	if contact != nil && contact.Language() != envs.NilLanguage {
+	if contactLanguage != envs.NilLanguage {
 		for _, l := range r.Environment().AllowedLanguages() {
-			if l == contact.Language() {
-				languages = append(languages, contact.Language())
+			if l == contactLanguage {
+				languages = append(languages, contactLanguage)
 				break
 			}
 		}
@@ -311,13 +322,19 @@ func (r *flowRun) getLanguages() []envs.Language {
 
 	// next we include the default language if it's different to the contact language
 	defaultLanguage := r.Environment().DefaultLanguage()
-	if defaultLanguage != envs.NilLanguage && defaultLanguage != contact.Language() {
+	if defaultLanguage != envs.NilLanguage && defaultLanguage != contactLanguage {
 		languages = append(languages, defaultLanguage)
 	}
 
-	// finally we include the flow native language if it isn't an allowed language - because it's the only
+	// finally we include the flow native language if it isn't already included - because it's the only
 	// one guaranteed to have translations
-	return append(languages, r.flow.Language())
+	flowLanguage := r.flow.Language()
+	for _, l := range languages {
+		if l == flowLanguage {
+			return languages
+		}
+	}
+	return append(languages, flowLanguage)
 }
 
 func (r *flowRun) GetText(uuid uuids.UUID, key string, native string) string {
@@ -251,3 +251,67 @@ func TestMissingRelatedRunContext(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, types.NewXErrorf("null doesn't support lookups"), val)
 }
+
+func TestRunEnvironmentLanguages(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	runEnv := run.Environment()
+
+	// contact language is eng which is also the default and the flow language
+	assert.Equal(t, []envs.Language{"eng"}, runEnv.Languages())
+
+	// tests which read numbers in words only look in the languages of the run
+	value, err := run.EvaluateTemplate(`@(has_number("veinticinco"))`)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", value)
+
+	// as do tests which read dates in words
+	value, err = run.EvaluateTemplate(`@(has_date("pasado mañana"))`)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", value)
+
+	run.Contact().SetLanguage("spa")
+	assert.Equal(t, []envs.Language{"spa", "eng"}, runEnv.Languages())
+
+	value, err = run.EvaluateTemplate(`@(has_number("veinticinco").match)`)
+	assert.NoError(t, err)
+	assert.Equal(t, "25", value)
+
+	value, err = run.EvaluateTemplate(`@(has_date("pasado mañana").match != null)`)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", value)
+
+	// languages which aren't allowed in the environment are ignored
+	run.Contact().SetLanguage("fra")
+	assert.Equal(t, []envs.Language{"eng"}, runEnv.Languages())
+}
+
+func TestRunEnvironmentLocale(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	runEnv := run.Environment()
+
+	// workspace has no default country, so it comes from the contact's phone number
+	assert.Equal(t, envs.NewLocale("eng", "US"), runEnv.Locale())
+
+	evaluate := func() string {
+		value, err := run.EvaluateTemplate(`@(format_currency(1234.5, "USD")) @(number_words(21)) @(format_list(array("a", "b", "c")))`)
+		require.NoError(t, err)
+		return value
+	}
+
+	assert.Equal(t, "$1,234.50 twenty-one a, b and c", evaluate())
+
+	// a contact whose language differs from the workspace default gets things formatted in their language
+	run.Contact().SetLanguage("spa")
+	assert.Equal(t, envs.NewLocale("spa", "US"), runEnv.Locale())
+	assert.Equal(t, "$1,234.50 veintiuno a, b y c", evaluate())
+
+	// languages which aren't allowed in the environment are ignored
+	run.Contact().SetLanguage("fra")
+	assert.Equal(t, envs.NewLocale("eng", "US"), runEnv.Locale())
+}
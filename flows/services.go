@@ -1,6 +1,7 @@
 package flows
 
 import (
+	"bytes"
 	"net/http"
 	"time"
 
@@ -15,8 +16,12 @@ type Services interface {
 	Webhook(Session) (WebhookService, error)
 	Classification(Session, *Classifier) (ClassificationService, error)
 	Airtime(Session) (AirtimeService, error)
+	LLM(Session) (LLMService, error)
+	Credentials(Session) (CredentialService, error)
 }
 
+const redactionMask = "********"
+
 // CallStatus represents the status of a call to an external service
 type CallStatus string
 
@@ -30,6 +35,9 @@ const (
 	// CallStatusResponseError represents that the webhook response had a non 2xx status code
 	CallStatusResponseError CallStatus = "response_error"
 
+	// CallStatusAccessDenied represents that the webhook wasn't made because the host isn't allowed
+	CallStatusAccessDenied CallStatus = "access_denied"
+
 	// CallStatusSubscriberGone represents a special state of resthook responses which indicate the caller must remove that subscriber
 	CallStatusSubscriberGone CallStatus = "subscriber_gone"
 )
@@ -45,6 +53,25 @@ type WebhookCall struct {
 	Response    []byte
 	BodyIgnored bool
 	Resthook    string
+	Cached      bool
+
+	// Attempts holds the earlier attempts which were retried, in the order they were made
+	Attempts []*WebhookCall
+}
+
+// RedactSecrets replaces the given secret values in the request and response traces of this call and its attempts
+func (w *WebhookCall) RedactSecrets(secrets []string) {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		w.Request = bytes.Replace(w.Request, []byte(secret), []byte(redactionMask), -1)
+		w.Response = bytes.Replace(w.Response, []byte(secret), []byte(redactionMask), -1)
+	}
+
+	for _, attempt := range w.Attempts {
+		attempt.RedactSecrets(secrets)
+	}
 }
 
 // WebhookService provides webhook functionality to the engine
@@ -99,6 +126,30 @@ type AirtimeService interface {
 	Transfer(session Session, sender urns.URN, recipient urns.URN, amounts map[string]decimal.Decimal, logHTTP HTTPLogCallback) (*AirtimeTransfer, error)
 }
 
+// LLMResponse is the response from an LLM service
+type LLMResponse struct {
+	Output string
+}
+
+// LLMService provides text generation and transformation functionality to the engine
+type LLMService interface {
+	// Response generates a response for the given instructions and input
+	Response(session Session, instructions string, input string, logHTTP HTTPLogCallback) (*LLMResponse, error)
+}
+
+// Credential is a named secret which can be used to authorize HTTP requests without the secret appearing in
+// flow definitions
+type Credential interface {
+	// Authorize modifies the given request to include authorization, returning any secret values which were
+	// added to it and so should be redacted from traces
+	Authorize(request *http.Request) ([]string, error)
+}
+
+// CredentialService provides named credentials to the engine
+type CredentialService interface {
+	Credential(session Session, name string) (Credential, error)
+}
+
 // HTTPLog describes an HTTP request/response
 type HTTPLog struct {
 	URL       string     `json:"url" validate:"required"`
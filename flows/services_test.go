@@ -40,3 +40,22 @@ func TestHTTPLogs(t *testing.T) {
 	log3 := flows.NewHTTPLog(trace3, flows.HTTPStatusFromCode)
 	assert.Equal(t, flows.CallStatusConnectionError, log3.Status)
 }
+
+func TestWebhookCallRedactSecrets(t *testing.T) {
+	call := &flows.WebhookCall{
+		Request:  []byte("GET / HTTP/1.1\r\nAuthorization: Bearer 1234567890\r\n\r\n"),
+		Response: []byte("HTTP/1.1 200 OK\r\n\r\n{\"token\":\"1234567890\",\"secret\":\"sesame\"}"),
+	}
+	call.Attempts = []*flows.WebhookCall{
+		{
+			Request:  []byte("GET / HTTP/1.1\r\nAuthorization: Bearer 1234567890\r\n\r\n"),
+			Response: []byte("HTTP/1.1 503 Service Unavailable\r\n\r\n"),
+		},
+	}
+
+	call.RedactSecrets([]string{"1234567890", "sesame", ""})
+
+	assert.Equal(t, "GET / HTTP/1.1\r\nAuthorization: Bearer ********\r\n\r\n", string(call.Request))
+	assert.Equal(t, "HTTP/1.1 200 OK\r\n\r\n{\"token\":\"********\",\"secret\":\"********\"}", string(call.Response))
+	assert.Equal(t, "GET / HTTP/1.1\r\nAuthorization: Bearer ********\r\n\r\n", string(call.Attempts[0].Request))
+}
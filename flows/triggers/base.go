@@ -35,9 +35,13 @@ type baseTrigger struct {
 	triggeredOn time.Time
 }
 
-// create a new base trigger
+// create a new base trigger, timestamped by the clock of its environment if it has one
 func newBaseTrigger(typeName string, env envs.Environment, flow *assets.FlowReference, contact *flows.Contact, connection *flows.Connection, params *types.XObject) baseTrigger {
-	return baseTrigger{type_: typeName, environment: env, flow: flow, contact: contact, connection: connection, params: params, triggeredOn: dates.Now()}
+	triggeredOn := dates.Now()
+	if env != nil {
+		triggeredOn = env.Now()
+	}
+	return baseTrigger{type_: typeName, environment: env, flow: flow, contact: contact, connection: connection, params: params, triggeredOn: triggeredOn}
 }
 
 // Type returns the type of this trigger
@@ -106,12 +110,12 @@ func EnsureDynamicGroups(session flows.Session, logEvent flows.EventCallback) {
 
 	// add error event for each group we couldn't re-evaluate
 	for _, err := range errors {
-		logEvent(events.NewError(err))
+		logEvent(events.NewError(session.Engine().NowSource().Now(), err))
 	}
 
 	// add groups changed event for the groups we were added/removed to/from
 	if len(added) > 0 || len(removed) > 0 {
-		logEvent(events.NewContactGroupsChanged(added, removed))
+		logEvent(events.NewContactGroupsChanged(session.Engine().NowSource().Now(), added, removed))
 	}
 }
 
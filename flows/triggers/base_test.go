@@ -67,7 +67,7 @@ func TestTriggerMarshaling(t *testing.T) {
 	flow := assets.NewFlowReference(assets.FlowUUID("7c37d7e5-6468-4b31-8109-ced2ef8b5ddc"), "Registration")
 	channel := assets.NewChannelReference("3a05eaf5-cb1b-4246-bef1-f277419c83a7", "Nexmo")
 
-	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+12065551212"), nil))
 
 	triggerTests := []struct {
@@ -417,7 +417,7 @@ func TestTriggerSessionInitialization(t *testing.T) {
 
 	flow := assets.NewFlowReference(assets.FlowUUID("7c37d7e5-6468-4b31-8109-ced2ef8b5ddc"), "Registration")
 
-	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil, dates.Now())
 	contact.AddURN(flows.NewContactURN(urns.URN("tel:+12065551212"), nil))
 
 	params := types.NewXObject(map[string]types.XValue{"foo": types.NewXText("bar")})
@@ -441,7 +441,7 @@ func TestTriggerSessionInitialization(t *testing.T) {
 
 	assert.Equal(t, flows.FlowTypeMessaging, session.Type())
 	assert.Equal(t, contact, session.Contact())
-	assert.Equal(t, env, session.Environment())
+	assert.True(t, env.Equal(session.Environment()))
 	assert.Equal(t, flow, session.Runs()[0].FlowReference())
 
 	// contact, environment and params are optional
@@ -462,5 +462,5 @@ func TestTriggerSessionInitialization(t *testing.T) {
 
 	assert.Equal(t, flows.FlowTypeMessaging, session.Type())
 	assert.Nil(t, session.Contact())
-	assert.Equal(t, defaultEnv, session.Environment()) // uses defaults
+	assert.True(t, defaultEnv.Equal(session.Environment())) // uses defaults
 }
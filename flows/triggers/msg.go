@@ -87,7 +87,7 @@ func (t *MsgTrigger) InitializeRun(run flows.FlowRun, logEvent flows.EventCallba
 	}
 
 	run.Session().SetInput(input)
-	logEvent(events.NewMsgReceived(t.msg))
+	logEvent(events.NewMsgReceived(run.Now(), t.msg))
 
 	return t.baseTrigger.InitializeRun(run, logEvent)
 }
@@ -605,7 +605,7 @@ func migrateRuleSet(lang envs.Language, r RuleSet, validDests map[flows.NodeUUID
 		}
 
 		newActions = []flows.Action{
-			actions.NewCallWebhook(flows.ActionUUID(uuids.New()), method, migratedURL, headers, body, resultName),
+			actions.NewCallWebhook(flows.ActionUUID(uuids.New()), method, migratedURL, headers, body, resultName, "", nil),
 		}
 
 		// webhook rulesets operate on the webhook status, saved as category
@@ -30,6 +30,7 @@ import (
 	"github.com/nyaruka/goflow/flows/routers/waits"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/dates"
 
 	"github.com/Masterminds/semver"
 )
@@ -115,7 +116,7 @@ type Contact struct {
 // NewEmptyContact creates a new contact
 func NewEmptyContact(sa *SessionAssets) *Contact {
 	return &Contact{
-		target: flows.NewEmptyContact(sa.target, "", envs.NilLanguage, nil),
+		target: flows.NewEmptyContact(sa.target, "", envs.NilLanguage, nil, dates.Now()),
 	}
 }
 
@@ -271,6 +272,15 @@ func (s *Session) Resume(resume *Resume) (*Sprint, error) {
 	return &Sprint{target: sprint}, nil
 }
 
+// Interrupt interrupts this session
+func (s *Session) Interrupt(reason string) (*Sprint, error) {
+	sprint, err := s.target.Interrupt(reason)
+	if err != nil {
+		return nil, err
+	}
+	return &Sprint{target: sprint}, nil
+}
+
 // GetWait gets the current wait of this session.. can't call this Wait() because Object in Java already has a wait() method
 func (s *Session) GetWait() *Wait {
 	if s.target.Wait() != nil {
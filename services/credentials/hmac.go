@@ -0,0 +1,69 @@
+package credentials
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+type hmacSigner struct {
+	key    []byte
+	header string
+}
+
+// NewHMACSigner creates a new credential which signs request bodies with HMAC-SHA256 using the given key, and
+// adds the signature to requests as the given header, e.g. X-Signature: sha256=8ea9f53e...
+func NewHMACSigner(key, header string) flows.Credential {
+	return &hmacSigner{key: []byte(key), header: header}
+}
+
+func (c *hmacSigner) Authorize(request *http.Request) ([]string, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(body)
+
+	request.Header.Set(c.header, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	// the signature can't be used to recover the key so nothing needs redacted
+	return nil, nil
+}
+
+// reads the body of the given request without consuming it
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		reader, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return ioutil.ReadAll(reader)
+	}
+
+	// body can only be read once so read it and replace it with one that can be re-read
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
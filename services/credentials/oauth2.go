@@ -0,0 +1,128 @@
+package credentials
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/httpx"
+
+	"github.com/pkg/errors"
+)
+
+// how long before a token expires that we consider it expired and fetch a new one
+const tokenExpiryMargin = 30 * time.Second
+
+// how long we use a token for if the server doesn't tell us when it expires
+const defaultTokenLifetime = 5 * time.Minute
+
+type oauth2ClientCredentials struct {
+	httpClient   *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+
+	mutex   sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewOAuth2ClientCredentials creates a new credential which fetches access tokens from the given token URL using
+// the OAuth2 client credentials grant, and adds them to requests as bearer tokens. Tokens are reused until they
+// are about to expire, or for a default lifetime if the server doesn't say when they expire.
+func NewOAuth2ClientCredentials(httpClient *http.Client, tokenURL, clientID, clientSecret string, scopes []string) flows.Credential {
+	return &oauth2ClientCredentials{
+		httpClient:   httpClient,
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+	}
+}
+
+func (c *oauth2ClientCredentials) Authorize(request *http.Request) ([]string, error) {
+	token, err := c.getToken()
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	return []string{token}, nil
+}
+
+// gets our current token, fetching a new one if we don't have one or it's about to expire
+func (c *oauth2ClientCredentials) getToken() (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := dates.Now()
+
+	if c.token != "" && now.Before(c.expires) {
+		return c.token, nil
+	}
+
+	token, expiresIn, err := c.fetchToken()
+	if err != nil {
+		return "", err
+	}
+
+	// expires_in is optional, and short lifetimes can't have our margin taken off them
+	if expiresIn <= 0 {
+		expiresIn = defaultTokenLifetime
+	} else if expiresIn > tokenExpiryMargin {
+		expiresIn -= tokenExpiryMargin
+	}
+
+	c.token = token
+	c.expires = now.Add(expiresIn)
+
+	return c.token, nil
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// fetches a new token from the token URL
+func (c *oauth2ClientCredentials) fetchToken() (string, time.Duration, error) {
+	form := url.Values{"grant_type": []string{"client_credentials"}}
+	if len(c.scopes) > 0 {
+		form.Set("scope", strings.Join(c.scopes, " "))
+	}
+
+	// client ID and secret are sent using basic auth (https://tools.ietf.org/html/rfc6749#section-2.3.1)
+	basicAuth := url.QueryEscape(c.clientID) + ":" + url.QueryEscape(c.clientSecret)
+
+	headers := map[string]string{
+		"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(basicAuth)),
+		"Content-Type":  "application/x-www-form-urlencoded",
+	}
+
+	trace, err := httpx.DoTrace(c.httpClient, "POST", c.tokenURL, strings.NewReader(form.Encode()), headers)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "unable to fetch OAuth2 token")
+	}
+	if trace.Response.StatusCode/100 != 2 {
+		return "", 0, errors.Errorf("unable to fetch OAuth2 token, server returned status %d", trace.Response.StatusCode)
+	}
+
+	response := &tokenResponse{}
+	if err := json.Unmarshal(trace.ResponseBody, response); err != nil || response.AccessToken == "" {
+		return "", 0, errors.New("unable to fetch OAuth2 token, server returned invalid response")
+	}
+	if response.TokenType != "" && !strings.EqualFold(response.TokenType, "bearer") {
+		return "", 0, errors.Errorf("unable to use OAuth2 token of type '%s'", response.TokenType)
+	}
+
+	return response.AccessToken, time.Duration(response.ExpiresIn) * time.Second, nil
+}
@@ -0,0 +1,36 @@
+package credentials
+
+import (
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/engine"
+
+	"github.com/pkg/errors"
+)
+
+type service struct {
+	credentials map[string]flows.Credential
+}
+
+// NewServiceFactory creates a new credential service factory which provides the given named credentials to all sessions
+func NewServiceFactory(credentials map[string]flows.Credential) engine.CredentialServiceFactory {
+	svc := NewService(credentials)
+
+	return func(flows.Session) (flows.CredentialService, error) {
+		return svc, nil
+	}
+}
+
+// NewService creates a new credential service which provides the given named credentials
+func NewService(credentials map[string]flows.Credential) flows.CredentialService {
+	return &service{credentials: credentials}
+}
+
+func (s *service) Credential(session flows.Session, name string) (flows.Credential, error) {
+	credential := s.credentials[name]
+	if credential == nil {
+		return nil, errors.Errorf("no credential named '%s'", name)
+	}
+	return credential, nil
+}
+
+var _ flows.CredentialService = (*service)(nil)
@@ -0,0 +1,159 @@
+package credentials_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/services/credentials"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/httpx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	hmac := credentials.NewHMACSigner("sesame", "X-Signature")
+	factory := credentials.NewServiceFactory(map[string]flows.Credential{"acme": hmac})
+
+	svc, err := factory(nil)
+	require.NoError(t, err)
+
+	credential, err := svc.Credential(nil, "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, hmac, credential)
+
+	credential, err = svc.Credential(nil, "xyz")
+	assert.EqualError(t, err, "no credential named 'xyz'")
+	assert.Nil(t, credential)
+}
+
+func TestHMACSigner(t *testing.T) {
+	hmac := credentials.NewHMACSigner("sesame", "X-Signature")
+
+	// request with a re-readable body
+	request, _ := http.NewRequest("POST", "http://temba.io/", strings.NewReader("Hi there!"))
+	secrets, err := hmac.Authorize(request)
+
+	assert.NoError(t, err)
+	assert.Nil(t, secrets)
+	assert.Equal(t, "sha256=45b76e4a71ddcd4050c4d3bbafae4c1444c9aa18794312baa62bfca542de62a3", request.Header.Get("X-Signature"))
+
+	// request with a body that can only be read once, which should be replaced
+	request, _ = http.NewRequest("POST", "http://temba.io/", ioutil.NopCloser(strings.NewReader("Hi there!")))
+	_, err = hmac.Authorize(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256=45b76e4a71ddcd4050c4d3bbafae4c1444c9aa18794312baa62bfca542de62a3", request.Header.Get("X-Signature"))
+
+	body, _ := ioutil.ReadAll(request.Body)
+	assert.Equal(t, "Hi there!", string(body))
+
+	// request without a body
+	request, _ = http.NewRequest("GET", "http://temba.io/", nil)
+	_, err = hmac.Authorize(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256=078b0d9eda4d2e3884b71a9842f50df717cc64aab0088eb68a5cf783c578c4a7", request.Header.Get("X-Signature"))
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 15, 21, 30, 0, time.UTC)))
+
+	mocks := httpx.NewMockRequestor(map[string][]httpx.MockResponse{
+		"http://oauth.temba.io/token": []httpx.MockResponse{
+			httpx.NewMockResponse(200, `{"access_token": "1234567890", "token_type": "bearer", "expires_in": 3600}`),
+			httpx.NewMockResponse(200, `{"access_token": "ABCDEFGHIJ", "token_type": "Bearer", "expires_in": 3600}`),
+			httpx.NewMockResponse(401, `{"error": "invalid_client"}`),
+			httpx.NewMockResponse(200, `{"error": "huh"}`),
+			httpx.NewMockResponse(200, `{"access_token": "1234567890", "token_type": "mac"}`),
+			httpx.MockConnectionError,
+			httpx.NewMockResponse(200, `{"access_token": "KLMNOPQRST", "token_type": "bearer"}`),
+			httpx.NewMockResponse(200, `{"access_token": "UVWXYZ1234", "token_type": "bearer", "expires_in": 20}`),
+			httpx.NewMockResponse(200, `{"access_token": "5678901234", "token_type": "bearer", "expires_in": 3600}`),
+		},
+	})
+	httpx.SetRequestor(mocks)
+
+	oauth := credentials.NewOAuth2ClientCredentials(http.DefaultClient, "http://oauth.temba.io/token", "goflow", "sesame", []string{"webhooks"})
+
+	authorize := func() (string, []string, error) {
+		request, _ := http.NewRequest("GET", "http://temba.io/", nil)
+		secrets, err := oauth.Authorize(request)
+		return request.Header.Get("Authorization"), secrets, err
+	}
+
+	// first request fetches a token
+	header, secrets, err := authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 1234567890", header)
+	assert.Equal(t, []string{"1234567890"}, secrets)
+
+	// which is reused until it's about to expire
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 16, 20, 30, 0, time.UTC)))
+
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 1234567890", header)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 16, 21, 0, 0, time.UTC)))
+
+	header, secrets, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer ABCDEFGHIJ", header)
+	assert.Equal(t, []string{"ABCDEFGHIJ"}, secrets)
+
+	// check errors fetching tokens, which are tried again each time since we have no valid token
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 18, 0, 0, 0, time.UTC)))
+
+	_, _, err = authorize()
+	assert.EqualError(t, err, "unable to fetch OAuth2 token, server returned status 401")
+
+	_, _, err = authorize()
+	assert.EqualError(t, err, "unable to fetch OAuth2 token, server returned invalid response")
+
+	_, _, err = authorize()
+	assert.EqualError(t, err, "unable to use OAuth2 token of type 'mac'")
+
+	_, _, err = authorize()
+	assert.EqualError(t, err, "unable to fetch OAuth2 token: unable to connect to server")
+
+	// tokens without an expiry are reused for a default lifetime
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer KLMNOPQRST", header)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 18, 4, 59, 0, time.UTC)))
+
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer KLMNOPQRST", header)
+
+	// and tokens which expire sooner than our margin are used until they expire
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 18, 5, 0, 0, time.UTC)))
+
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer UVWXYZ1234", header)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 18, 5, 19, 0, time.UTC)))
+
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer UVWXYZ1234", header)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 18, 5, 20, 0, time.UTC)))
+
+	header, _, err = authorize()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 5678901234", header)
+
+	assert.False(t, mocks.HasUnused())
+}
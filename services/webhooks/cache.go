@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils/dates"
+)
+
+// Cache is a cache of successful GET webhook calls which can be shared by all sessions of an engine, or partitioned
+// so that calls are only reused within the same session
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	perSession bool
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used at front
+}
+
+type cacheEntry struct {
+	key     string
+	call    *flows.WebhookCall
+	expires time.Time
+}
+
+// NewCache creates a new cache whose entries live for the given TTL, and which holds at most maxEntries calls
+func NewCache(ttl time.Duration, maxEntries int, perSession bool) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		perSession: perSession,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Len returns the number of calls in this cache, which may include expired calls
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+// generates the key for the given request, or empty string if it isn't cacheable. The key is a hash so that
+// header values like credentials aren't kept in memory.
+func (c *Cache) key(session flows.Session, request *http.Request, resthook string) string {
+	if request.Method != http.MethodGet || resthook != "" {
+		return ""
+	}
+
+	b := &strings.Builder{}
+	if c.perSession && session != nil {
+		b.WriteString(string(session.UUID()))
+		b.WriteString("\n")
+	}
+	b.WriteString(request.Method)
+	b.WriteString(" ")
+	b.WriteString(request.URL.String())
+
+	names := make([]string, 0, len(request.Header))
+	for name := range request.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.WriteString("\n")
+		b.WriteString(name)
+		b.WriteString(": ")
+		b.WriteString(strings.Join(request.Header[name], ", "))
+	}
+
+	hash := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(hash[:])
+}
+
+// gets the call with the given key, or nil if there isn't one or it has expired
+func (c *Cache) get(key string) *flows.WebhookCall {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem := c.entries[key]
+	if elem == nil {
+		return nil
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !dates.Now().Before(entry.expires) {
+		c.remove(elem)
+		return nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	// return a copy of the call marked as cached
+	cached := *entry.call
+	cached.Cached = true
+	cached.TimeTaken = 0
+	cached.Attempts = nil
+	return &cached
+}
+
+// puts the given call in the cache, evicting the least recently used call if we're full
+func (c *Cache) put(key string, call *flows.WebhookCall) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// store a copy so that changes made to the call by the caller, e.g. redacting secrets, don't affect the cache
+	stored := *call
+	stored.Request = append([]byte(nil), call.Request...)
+	stored.Response = append([]byte(nil), call.Response...)
+	stored.Attempts = nil
+
+	entry := &cacheEntry{key: key, call: &stored, expires: dates.Now().Add(c.ttl)}
+
+	if elem := c.entries[key]; elem != nil {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+// RetryConfig configures if and how webhook calls which fail are retried
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts to make, including the first
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry, which is doubled for each subsequent retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum time to wait before a retry, or zero for no limit
+	MaxBackoff time.Duration
+
+	// Statuses are the response status codes which should be retried (connection errors are always retried)
+	Statuses []int
+
+	// Methods are the request methods which can be retried, or empty for all methods
+	Methods []string
+
+	// IdempotencyHeader is the header to add to requests with a key which is the same for every attempt
+	IdempotencyHeader string
+}
+
+// NewDefaultRetryConfig creates a new retry config with sensible defaults, which only retries idempotent methods
+func NewDefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:       3,
+		InitialBackoff:    time.Second,
+		MaxBackoff:        10 * time.Second,
+		Statuses:          []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		Methods:           []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete},
+		IdempotencyHeader: "Idempotency-Key",
+	}
+}
+
+// Backoff returns the time to wait after the given attempt (1-based) before making the next
+func (c *RetryConfig) Backoff(attempt int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+
+		if c.MaxBackoff > 0 && backoff >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// AllowsMethod returns whether requests with the given method can be retried
+func (c *RetryConfig) AllowsMethod(method string) bool {
+	if len(c.Methods) == 0 {
+		return true
+	}
+	for _, m := range c.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldRetry returns whether the given call, which was the given attempt (1-based), should be retried
+func (c *RetryConfig) ShouldRetry(call *flows.WebhookCall, attempt int) bool {
+	if attempt >= c.MaxAttempts || !c.AllowsMethod(call.Method) {
+		return false
+	}
+	if call.Status == flows.CallStatusConnectionError {
+		return true
+	}
+	for _, s := range c.Statuses {
+		if call.StatusCode == s {
+			return true
+		}
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/httpx"
+	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/pkg/errors"
 )
@@ -32,18 +33,28 @@ var fetchResponseContentTypes = map[string]bool{
 type service struct {
 	defaultUserAgent string
 	maxBodyBytes     int
+	retries          *RetryConfig
+	access           *httpx.AccessConfig
+	cache            *Cache
 }
 
-// NewServiceFactory creates a new webhook service factory
-func NewServiceFactory(defaultUserAgent string, maxBodyBytes int) engine.WebhookServiceFactory {
+// NewServiceFactory creates a new webhook service factory. If retries is nil then failed calls won't be retried,
+// if access is nil then calls can be made to any host, and if cache is nil then responses won't be cached.
+func NewServiceFactory(defaultUserAgent string, maxBodyBytes int, retries *RetryConfig, access *httpx.AccessConfig, cache *Cache) engine.WebhookServiceFactory {
 	return func(flows.Session) (flows.WebhookService, error) {
-		return NewService(defaultUserAgent, maxBodyBytes), nil
+		return NewService(defaultUserAgent, maxBodyBytes, retries, access, cache), nil
 	}
 }
 
 // NewService creates a new default webhook service
-func NewService(defaultUserAgent string, maxBodyBytes int) flows.WebhookService {
-	return &service{defaultUserAgent: defaultUserAgent, maxBodyBytes: maxBodyBytes}
+func NewService(defaultUserAgent string, maxBodyBytes int, retries *RetryConfig, access *httpx.AccessConfig, cache *Cache) flows.WebhookService {
+	return &service{
+		defaultUserAgent: defaultUserAgent,
+		maxBodyBytes:     maxBodyBytes,
+		retries:          retries,
+		access:           access,
+		cache:            cache,
+	}
 }
 
 func (s *service) Call(session flows.Session, request *http.Request, resthook string) (*flows.WebhookCall, error) {
@@ -52,16 +63,97 @@ func (s *service) Call(session flows.Session, request *http.Request, resthook st
 		request.Header.Set(httpHeaderUserAgent, s.defaultUserAgent)
 	}
 
+	if s.cache == nil {
+		return s.callWithRetries(session, request, resthook)
+	}
+
+	// check if we have a cached response to this request
+	cacheKey := s.cache.key(session, request, resthook)
+	if cacheKey != "" {
+		if cached := s.cache.get(cacheKey); cached != nil {
+			return cached, nil
+		}
+	}
+
+	call, err := s.callWithRetries(session, request, resthook)
+
+	if cacheKey != "" && err == nil && call.Status == flows.CallStatusSuccess {
+		s.cache.put(cacheKey, call)
+	}
+
+	return call, err
+}
+
+// makes the given request, retrying it if we're configured to
+func (s *service) callWithRetries(session flows.Session, request *http.Request, resthook string) (*flows.WebhookCall, error) {
+	if s.retries == nil {
+		return s.attempt(session, request, resthook)
+	}
+
+	// add an idempotency key which stays the same across attempts so that the server can ignore duplicates
+	if s.retries.IdempotencyHeader != "" && request.Header.Get(s.retries.IdempotencyHeader) == "" {
+		request.Header.Set(s.retries.IdempotencyHeader, string(uuids.New()))
+	}
+
+	// the earlier attempts which we retried
+	var attempts []*flows.WebhookCall
+
+	for attempt := 1; ; attempt++ {
+		call, err := s.attempt(session, request, resthook)
+		if err != nil {
+			return call, err
+		}
+
+		var retry *http.Request
+		if s.retries.ShouldRetry(call, attempt) {
+			retry = rewindRequest(request)
+		}
+
+		if retry == nil {
+			// only record attempts if we actually retried
+			if len(attempts) > 0 {
+				call.Attempts = attempts
+			}
+			return call, nil
+		}
+
+		attempts = append(attempts, call)
+
+		time.Sleep(s.retries.Backoff(attempt))
+		request = retry
+	}
+}
+
+// makes a single attempt to make the given request
+func (s *service) attempt(session flows.Session, request *http.Request, resthook string) (*flows.WebhookCall, error) {
 	dump, err := httputil.DumpRequestOut(request, true)
 	if err != nil {
 		return nil, err
 	}
 
+	client := session.Engine().HTTPClient()
+
+	if s.access != nil {
+		if err := s.access.Allow(request); err != nil {
+			return newDeniedCall(request, dump), err
+		}
+
+		// use a copy of the client which checks the addresses it dials and any redirects
+		client, err = s.access.Client(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	start := dates.Now()
-	response, err := httpx.Do(session.Engine().HTTPClient(), request)
+	response, err := httpx.Do(client, request)
 	timeTaken := dates.Now().Sub(start)
 
 	if err != nil {
+		if accessErr := httpx.AsAccessDenied(err); accessErr != nil {
+			return newDeniedCall(request, dump), accessErr
+		}
+
 		return &flows.WebhookCall{
 			URL:        request.URL.String(),
 			Method:     request.Method,
@@ -75,6 +167,16 @@ func (s *service) Call(session flows.Session, request *http.Request, resthook st
 	return s.newCallFromResponse(dump, response, s.maxBodyBytes, timeTaken, resthook)
 }
 
+// creates a new call for a request which wasn't allowed by our access config
+func newDeniedCall(request *http.Request, requestTrace []byte) *flows.WebhookCall {
+	return &flows.WebhookCall{
+		URL:     request.URL.String(),
+		Method:  request.Method,
+		Status:  flows.CallStatusAccessDenied,
+		Request: requestTrace,
+	}
+}
+
 // creates a new call based on the passed in http response
 func (s *service) newCallFromResponse(requestTrace []byte, response *http.Response, maxBodyBytes int, timeTaken time.Duration, resthook string) (*flows.WebhookCall, error) {
 	defer response.Body.Close()
@@ -142,6 +244,23 @@ func (s *service) newCallFromResponse(requestTrace []byte, response *http.Respon
 	return w, nil
 }
 
+// returns a copy of the given request with a fresh body which can be sent again, or nil if that isn't possible
+func rewindRequest(request *http.Request) *http.Request {
+	rewound := request.Clone(request.Context())
+
+	if request.Body != nil && request.Body != http.NoBody {
+		if request.GetBody == nil {
+			return nil
+		}
+		body, err := request.GetBody()
+		if err != nil {
+			return nil
+		}
+		rewound.Body = body
+	}
+	return rewound
+}
+
 // determines the webhook status from the HTTP status code
 func statusFromCode(code int, isResthook bool) flows.CallStatus {
 	// https://zapier.com/developer/documentation/v2/rest-hooks/
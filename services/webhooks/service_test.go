@@ -2,13 +2,20 @@ package webhooks_test
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/services/webhooks"
 	"github.com/nyaruka/goflow/test"
 	"github.com/nyaruka/goflow/utils"
+	"github.com/nyaruka/goflow/utils/dates"
+	"github.com/nyaruka/goflow/utils/httpx"
+	"github.com/nyaruka/goflow/utils/uuids"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,3 +176,269 @@ func TestMockService(t *testing.T) {
 	assert.False(t, c.BodyIgnored)
 	assert.Equal(t, "myresthook", c.Resthook)
 }
+
+func TestRetries(t *testing.T) {
+	defer uuids.SetGenerator(uuids.DefaultGenerator)
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
+	mocks := httpx.NewMockRequestor(map[string][]httpx.MockResponse{
+		"http://temba.io/": []httpx.MockResponse{
+			httpx.NewMockResponse(503, `{"errors": ["unavailable"]}`),
+			httpx.MockConnectionError,
+			httpx.NewMockResponse(200, `{"ok": true}`),
+		},
+		"http://temba.io/bad": []httpx.MockResponse{
+			httpx.NewMockResponse(400, `{"errors": ["bad request"]}`),
+		},
+		"http://temba.io/flaky": []httpx.MockResponse{
+			httpx.NewMockResponse(502, `{"errors": ["bad gateway"]}`),
+			httpx.NewMockResponse(502, `{"errors": ["bad gateway"]}`),
+			httpx.NewMockResponse(502, `{"errors": ["bad gateway"]}`),
+			httpx.NewMockResponse(502, `{"errors": ["bad gateway"]}`),
+		},
+	})
+	httpx.SetRequestor(mocks)
+
+	retries := webhooks.NewDefaultRetryConfig()
+	retries.InitialBackoff = time.Millisecond
+	retries.MaxBackoff = 2 * time.Millisecond
+
+	svc := webhooks.NewService("goflow-testing", 10000, retries, nil, nil)
+
+	// call which succeeds on its third attempt, with the body re-sent each time
+	request, _ := http.NewRequest("PUT", "http://temba.io/", strings.NewReader(`{"foo":"bar"}`))
+	c, err := svc.Call(session, request, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.CallStatusSuccess, c.Status)
+	assert.Equal(t, 200, c.StatusCode)
+	assert.Equal(t, 2, len(c.Attempts))
+	assert.Equal(t, flows.CallStatusResponseError, c.Attempts[0].Status)
+	assert.Equal(t, 503, c.Attempts[0].StatusCode)
+	assert.Equal(t, flows.CallStatusConnectionError, c.Attempts[1].Status)
+
+	// the final call isn't one of its own earlier attempts
+	for _, a := range c.Attempts {
+		assert.True(t, a != c)
+		assert.Nil(t, a.Attempts)
+	}
+
+	// every attempt has the same idempotency key and body
+	for _, a := range append(c.Attempts, c) {
+		assert.Equal(t, "PUT / HTTP/1.1\r\nHost: temba.io\r\nUser-Agent: goflow-testing\r\nContent-Length: 13\r\nIdempotency-Key: 1ae96956-4b34-433e-8d1a-f05fe6923d6d\r\nAccept-Encoding: gzip\r\n\r\n{\"foo\":\"bar\"}", string(a.Request))
+	}
+
+	event := events.NewWebhookCalled(dates.Now(), c)
+	assert.Equal(t, 3, len(event.Attempts))
+	assert.Equal(t, 503, event.Attempts[0].StatusCode)
+	assert.Equal(t, flows.CallStatusSuccess, event.Attempts[2].Status)
+
+	// call which fails with a status we don't retry
+	request, _ = http.NewRequest("GET", "http://temba.io/bad", nil)
+	c, err = svc.Call(session, request, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.CallStatusResponseError, c.Status)
+	assert.Nil(t, c.Attempts)
+
+	// call with a method we don't retry
+	request, _ = http.NewRequest("POST", "http://temba.io/flaky", nil)
+	c, err = svc.Call(session, request, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 502, c.StatusCode)
+	assert.Nil(t, c.Attempts)
+
+	// call which gives up after max attempts
+	request, _ = http.NewRequest("GET", "http://temba.io/flaky", nil)
+	c, err = svc.Call(session, request, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 502, c.StatusCode)
+	assert.Equal(t, 2, len(c.Attempts))
+
+	event = events.NewWebhookCalled(dates.Now(), c)
+	assert.Equal(t, 3, len(event.Attempts))
+	for _, a := range event.Attempts {
+		assert.Equal(t, 502, a.StatusCode)
+	}
+	assert.False(t, mocks.HasUnused())
+}
+
+func TestRetryConfig(t *testing.T) {
+	retries := webhooks.NewDefaultRetryConfig()
+
+	assert.Equal(t, time.Second, retries.Backoff(1))
+	assert.Equal(t, 2*time.Second, retries.Backoff(2))
+	assert.Equal(t, 4*time.Second, retries.Backoff(3))
+	assert.Equal(t, 8*time.Second, retries.Backoff(4))
+	assert.Equal(t, 10*time.Second, retries.Backoff(5))
+
+	assert.True(t, retries.AllowsMethod("PUT"))
+	assert.True(t, retries.AllowsMethod("get"))
+	assert.False(t, retries.AllowsMethod("POST"))
+	assert.False(t, retries.AllowsMethod("PATCH"))
+}
+
+func TestAccessConfig(t *testing.T) {
+	server := test.NewTestHTTPServer(49995)
+	defer server.Close()
+
+	redirector := httptest.NewServer(http.RedirectHandler("http://localhost:49995/?cmd=success", http.StatusFound))
+	defer redirector.Close()
+
+	session, _, err := test.CreateTestSession(server.URL, envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	// calls to private addresses are denied
+	svc := webhooks.NewService("goflow-testing", 10000, nil, httpx.NewAccessConfig(time.Second), nil)
+
+	request, _ := http.NewRequest("GET", "http://127.0.0.1:49995/?cmd=success", nil)
+	c, err := svc.Call(session, request, "")
+
+	assert.EqualError(t, err, "access denied: address 127.0.0.1 is denied")
+	assert.Equal(t, flows.CallStatusAccessDenied, c.Status)
+	assert.Equal(t, "http://127.0.0.1:49995/?cmd=success", c.URL)
+	assert.Equal(t, 0, c.StatusCode)
+	assert.Equal(t, "GET /?cmd=success HTTP/1.1\r\nHost: 127.0.0.1:49995\r\nUser-Agent: goflow-testing\r\nAccept-Encoding: gzip\r\n\r\n", string(c.Request))
+	assert.Nil(t, c.Response)
+
+	// as are calls to host names which resolve to private addresses when they're dialled
+	request, _ = http.NewRequest("GET", "http://localhost:49995/?cmd=success", nil)
+	c, err = svc.Call(session, request, "")
+
+	assert.NotNil(t, httpx.AsAccessDenied(err))
+	assert.Equal(t, flows.CallStatusAccessDenied, c.Status)
+	assert.Equal(t, "http://localhost:49995/?cmd=success", c.URL)
+
+	// calls which redirect to denied hosts are also denied
+	svc = webhooks.NewService("goflow-testing", 10000, nil, &httpx.AccessConfig{DeniedHosts: []string{"localhost"}}, nil)
+
+	request, _ = http.NewRequest("GET", redirector.URL, nil)
+	c, err = svc.Call(session, request, "")
+
+	assert.EqualError(t, err, "access denied: host 'localhost' is denied")
+	assert.Equal(t, flows.CallStatusAccessDenied, c.Status)
+	assert.Equal(t, redirector.URL, c.URL)
+
+	// but calls to allowed hosts are made as usual
+	request, _ = http.NewRequest("GET", "http://127.0.0.1:49995/?cmd=success", nil)
+	c, err = svc.Call(session, request, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, flows.CallStatusSuccess, c.Status)
+}
+
+func TestCache(t *testing.T) {
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+	otherSession, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 15, 21, 30, 0, time.UTC)))
+
+	mocks := httpx.NewMockRequestor(map[string][]httpx.MockResponse{
+		"http://temba.io/lookup?urn=tel:+1234": []httpx.MockResponse{
+			httpx.NewMockResponse(200, `{"name": "Bob"}`),
+			httpx.NewMockResponse(200, `{"name": "Robert"}`),
+			httpx.NewMockResponse(200, `{"name": "Bobby"}`),
+		},
+		"http://temba.io/lookup?urn=tel:+5678": []httpx.MockResponse{
+			httpx.NewMockResponse(500, `{"errors": ["boom"]}`),
+			httpx.NewMockResponse(200, `{"name": "Jim"}`),
+		},
+		"http://temba.io/save": []httpx.MockResponse{
+			httpx.NewMockResponse(200, `{"ok": true}`),
+			httpx.NewMockResponse(200, `{"ok": true}`),
+		},
+		"http://temba.io/other": []httpx.MockResponse{
+			httpx.NewMockResponse(200, `{"ok": true}`),
+			httpx.NewMockResponse(200, `{"ok": true}`),
+		},
+	})
+	httpx.SetRequestor(mocks)
+
+	cache := webhooks.NewCache(time.Minute, 2, true)
+	svc := webhooks.NewService("goflow-testing", 10000, nil, nil, cache)
+
+	call := func(method, url string, headers map[string]string) *flows.WebhookCall {
+		request, _ := http.NewRequest(method, url, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		c, err := svc.Call(session, request, "")
+		require.NoError(t, err)
+		return c
+	}
+
+	// first call is made for real and cached
+	c := call("GET", "http://temba.io/lookup?urn=tel:+1234", nil)
+	assert.False(t, c.Cached)
+	assert.Equal(t, `{"name": "Bob"}`, string(utils.ExtractResponseJSON(c.Response)))
+	assert.Equal(t, 1, cache.Len())
+
+	// redacting the returned call doesn't change what's cached
+	c.RedactSecrets([]string{"Bob"})
+
+	// second call is served from the cache
+	c = call("GET", "http://temba.io/lookup?urn=tel:+1234", nil)
+	assert.True(t, c.Cached)
+	assert.Equal(t, `{"name": "Bob"}`, string(utils.ExtractResponseJSON(c.Response)))
+	c.RedactSecrets([]string{"Bob"})
+
+	c = call("GET", "http://temba.io/lookup?urn=tel:+1234", nil)
+	assert.True(t, c.Cached)
+	assert.Equal(t, `{"name": "Bob"}`, string(utils.ExtractResponseJSON(c.Response)))
+
+	event := events.NewWebhookCalled(dates.Now(), c)
+	assert.True(t, event.Cached)
+	assert.Equal(t, 0, event.ElapsedMS)
+
+	// but not if the headers are different
+	c = call("GET", "http://temba.io/lookup?urn=tel:+1234", map[string]string{"Authorization": "Token 123"})
+	assert.False(t, c.Cached)
+	assert.Equal(t, `{"name": "Robert"}`, string(utils.ExtractResponseJSON(c.Response)))
+	assert.Equal(t, 2, cache.Len())
+
+	// failed calls aren't cached
+	c = call("GET", "http://temba.io/lookup?urn=tel:+5678", nil)
+	assert.Equal(t, flows.CallStatusResponseError, c.Status)
+	c = call("GET", "http://temba.io/lookup?urn=tel:+5678", nil)
+	assert.False(t, c.Cached)
+	assert.Equal(t, flows.CallStatusSuccess, c.Status)
+
+	// and we're limited to 2 entries, so the first call has been evicted
+	assert.Equal(t, 2, cache.Len())
+
+	// non-GET calls aren't cached
+	call("POST", "http://temba.io/save", nil)
+	c = call("POST", "http://temba.io/save", nil)
+	assert.False(t, c.Cached)
+
+	// cached calls expire after the TTL
+	c = call("GET", "http://temba.io/lookup?urn=tel:+5678", nil)
+	assert.True(t, c.Cached)
+
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2019, 10, 7, 15, 22, 30, 0, time.UTC)))
+
+	c = call("GET", "http://temba.io/lookup?urn=tel:+1234", nil)
+	assert.False(t, c.Cached)
+	assert.Equal(t, `{"name": "Bobby"}`, string(utils.ExtractResponseJSON(c.Response)))
+
+	// calls with a per-session cache aren't shared with other sessions
+	c = call("GET", "http://temba.io/other", nil)
+	assert.False(t, c.Cached)
+
+	request, _ := http.NewRequest("GET", "http://temba.io/other", nil)
+	c, err = svc.Call(otherSession, request, "")
+	assert.NoError(t, err)
+	assert.False(t, c.Cached)
+	assert.False(t, mocks.HasUnused())
+}
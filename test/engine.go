@@ -1,11 +1,15 @@
 package test
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/services/airtime/dtone"
+	"github.com/nyaruka/goflow/services/credentials"
 	"github.com/nyaruka/goflow/services/webhooks"
 
 	"github.com/pkg/errors"
@@ -15,14 +19,35 @@ import (
 // NewEngine creates an engine instance for testing
 func NewEngine() flows.Engine {
 	return engine.NewBuilder().
-		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000)).
+		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000, nil, nil, nil)).
 		WithClassificationServiceFactory(func(s flows.Session, c *flows.Classifier) (flows.ClassificationService, error) {
 			return newClassificationService(c), nil
 		}).
 		WithAirtimeServiceFactory(func(flows.Session) (flows.AirtimeService, error) { return newAirtimeService("RWF"), nil }).
+		WithLLMServiceFactory(func(flows.Session) (flows.LLMService, error) { return NewLLMService(), nil }).
+		WithCredentialServiceFactory(credentials.NewServiceFactory(map[string]flows.Credential{
+			"acme_hmac": credentials.NewHMACSigner("sesame", "X-Signature"),
+		})).
 		Build()
 }
 
+// NewRunnerEngine returns a builder for the engine used by the flow runner tests, which anything generating fixtures
+// for those tests should also use so that the fixtures replay the same way
+func NewRunnerEngine() *engine.Builder {
+	return engine.NewBuilder().
+		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000, nil, nil, nil)).
+		WithClassificationServiceFactory(func(s flows.Session, c *flows.Classifier) (flows.ClassificationService, error) {
+			return newClassificationService(c), nil
+		}).
+		WithAirtimeServiceFactory(func(flows.Session) (flows.AirtimeService, error) {
+			return dtone.NewService("nyaruka", "123456789", "RWF"), nil
+		}).
+		WithLLMServiceFactory(func(flows.Session) (flows.LLMService, error) { return NewLLMService(), nil }).
+		WithCredentialServiceFactory(credentials.NewServiceFactory(map[string]flows.Credential{
+			"acme_hmac": credentials.NewHMACSigner("sesame", "X-Signature"),
+		}))
+}
+
 // implementation of an NLU service for testing which always returns the first intent
 type nluService struct {
 	classifier *flows.Classifier
@@ -100,3 +125,41 @@ func (s *airtimeService) Transfer(session flows.Session, sender urns.URN, recipi
 }
 
 var _ flows.AirtimeService = (*airtimeService)(nil)
+
+// implementation of an LLM service for testing which responds with the input in uppercase, or fails if the input is "fail"
+type llmService struct{}
+
+// NewLLMService creates a new LLM service for testing
+func NewLLMService() flows.LLMService {
+	return &llmService{}
+}
+
+func (s *llmService) Response(session flows.Session, instructions string, input string, logHTTP flows.HTTPLogCallback) (*flows.LLMResponse, error) {
+	if input == "fail" {
+		logHTTP(&flows.HTTPLog{
+			URL:       "http://test.acme.ai/response",
+			Request:   "POST /response HTTP/1.1\r\nHost: test.acme.ai\r\nUser-Agent: Go-http-client/1.1\r\nAccept-Encoding: gzip\r\n\r\n",
+			Response:  "HTTP/1.0 500 Internal Server Error\r\nContent-Length: 0\r\n\r\n",
+			Status:    flows.CallStatusResponseError,
+			CreatedOn: time.Date(2019, 10, 16, 13, 59, 30, 123456789, time.UTC),
+			ElapsedMS: 1000,
+		})
+
+		return nil, errors.New("LLM service returned 500 response")
+	}
+
+	output := strings.ToUpper(input)
+
+	logHTTP(&flows.HTTPLog{
+		URL:       "http://test.acme.ai/response",
+		Request:   "POST /response HTTP/1.1\r\nHost: test.acme.ai\r\nUser-Agent: Go-http-client/1.1\r\nAccept-Encoding: gzip\r\n\r\n",
+		Response:  fmt.Sprintf("HTTP/1.0 200 OK\r\nContent-Length: %d\r\n\r\n%s", len(output), output),
+		Status:    flows.CallStatusSuccess,
+		CreatedOn: time.Date(2019, 10, 16, 13, 59, 30, 123456789, time.UTC),
+		ElapsedMS: 1000,
+	})
+
+	return &flows.LLMResponse{Output: output}, nil
+}
+
+var _ flows.LLMService = (*llmService)(nil)
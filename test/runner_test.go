@@ -17,8 +17,6 @@ import (
 	"github.com/nyaruka/goflow/flows/resumes"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/legacy"
-	"github.com/nyaruka/goflow/services/airtime/dtone"
-	"github.com/nyaruka/goflow/services/webhooks"
 	"github.com/nyaruka/goflow/utils"
 	"github.com/nyaruka/goflow/utils/dates"
 	"github.com/nyaruka/goflow/utils/httpx"
@@ -154,7 +152,7 @@ func loadAssets(path string) (flows.SessionAssets, error) {
 	return engine.NewSessionAssets(source)
 }
 
-func runFlow(assetsPath string, rawTrigger json.RawMessage, rawResumes []json.RawMessage) (runResult, error) {
+func runFlow(eng flows.Engine, assetsPath string, rawTrigger json.RawMessage, rawResumes []json.RawMessage) (runResult, error) {
 	// load the test specific assets
 	sa, err := loadAssets(assetsPath)
 	if err != nil {
@@ -166,16 +164,6 @@ func runFlow(assetsPath string, rawTrigger json.RawMessage, rawResumes []json.Ra
 		return runResult{}, errors.Wrapf(err, "error unmarshalling trigger")
 	}
 
-	eng := engine.NewBuilder().
-		WithWebhookServiceFactory(webhooks.NewServiceFactory("goflow-testing", 10000)).
-		WithClassificationServiceFactory(func(s flows.Session, c *flows.Classifier) (flows.ClassificationService, error) {
-			return newClassificationService(c), nil
-		}).
-		WithAirtimeServiceFactory(func(flows.Session) (flows.AirtimeService, error) {
-			return dtone.NewService("nyaruka", "123456789", "RWF"), nil
-		}).
-		Build()
-
 	session, sprint, err := eng.NewSession(sa, trigger)
 	if err != nil {
 		return runResult{}, err
@@ -264,7 +252,7 @@ func TestFlows(t *testing.T) {
 		}
 
 		// run our flow
-		runResult, err := runFlow(tc.assetsFile, flowTest.Trigger, flowTest.Resumes)
+		runResult, err := runFlow(NewRunnerEngine().Build(), tc.assetsFile, flowTest.Trigger, flowTest.Resumes)
 		if err != nil {
 			t.Errorf("error running flow for flow '%s' and output '%s': %s", tc.assetsFile, tc.outputFile, err)
 			continue
@@ -316,8 +304,19 @@ func TestFlows(t *testing.T) {
 }
 
 func BenchmarkFlows(b *testing.B) {
+	benchmarkFlows(b, NewRunnerEngine().Build())
+}
+
+func BenchmarkFlowsWithoutTemplateCache(b *testing.B) {
+	benchmarkFlows(b, NewRunnerEngine().WithTemplateCacheSize(0).Build())
+}
+
+// runs all our runner tests with the given engine, which like a real engine is shared by all sessions
+func benchmarkFlows(b *testing.B, eng flows.Engine) {
 	testCases, _ := loadTestCases()
 
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
 	for n := 0; n < b.N; n++ {
 		for _, tc := range testCases {
 			testJSON, err := ioutil.ReadFile(tc.outputFile)
@@ -327,8 +326,84 @@ func BenchmarkFlows(b *testing.B) {
 			err = json.Unmarshal(json.RawMessage(testJSON), &flowTest)
 			require.NoError(b, err, "error unmarshalling output file %s", tc.outputFile)
 
-			_, err = runFlow(tc.assetsFile, flowTest.Trigger, flowTest.Resumes)
+			if flowTest.HTTPMocks != nil {
+				httpx.SetRequestor(flowTest.HTTPMocks)
+			} else {
+				httpx.SetRequestor(httpx.DefaultRequestor)
+			}
+
+			_, err = runFlow(eng, tc.assetsFile, flowTest.Trigger, flowTest.Resumes)
 			require.NoError(b, err, "error running flow %s", tc.testName)
 		}
 	}
 }
+
+// runs all our runner tests to get a set of sessions to benchmark session encodings with
+func loadBenchmarkSessions(b *testing.B) []flows.Session {
+	testCases, _ := loadTestCases()
+	sessions := make([]flows.Session, 0, len(testCases))
+
+	for _, tc := range testCases {
+		testJSON, err := ioutil.ReadFile(tc.outputFile)
+		require.NoError(b, err, "error reading output file %s", tc.outputFile)
+
+		flowTest := &FlowTest{}
+		err = json.Unmarshal(json.RawMessage(testJSON), &flowTest)
+		require.NoError(b, err, "error unmarshalling output file %s", tc.outputFile)
+
+		if flowTest.HTTPMocks != nil {
+			httpx.SetRequestor(flowTest.HTTPMocks)
+		} else {
+			httpx.SetRequestor(httpx.DefaultRequestor)
+		}
+
+		result, err := runFlow(NewRunnerEngine().Build(), tc.assetsFile, flowTest.Trigger, flowTest.Resumes)
+		require.NoError(b, err, "error running flow %s", tc.testName)
+
+		sessions = append(sessions, result.session)
+	}
+
+	httpx.SetRequestor(httpx.DefaultRequestor)
+	return sessions
+}
+
+func benchmarkSessionEncoding(b *testing.B, marshal func(flows.Session) ([]byte, error)) {
+	sessions := loadBenchmarkSessions(b)
+	encoded := make([][]byte, len(sessions))
+	totalSize := 0
+
+	for i, session := range sessions {
+		data, err := marshal(session)
+		require.NoError(b, err)
+
+		encoded[i] = data
+		totalSize += len(data)
+	}
+
+	b.Run("marshal", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, session := range sessions {
+				_, err := marshal(session)
+				require.NoError(b, err)
+			}
+		}
+		b.ReportMetric(float64(totalSize)/float64(len(sessions)), "bytes/session")
+	})
+
+	b.Run("read", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i, session := range sessions {
+				_, err := session.Engine().ReadSession(session.Assets(), encoded[i], assets.PanicOnMissing)
+				require.NoError(b, err)
+			}
+		}
+	})
+}
+
+func BenchmarkSessionJSON(b *testing.B) {
+	benchmarkSessionEncoding(b, func(s flows.Session) ([]byte, error) { return json.Marshal(s) })
+}
+
+func BenchmarkSessionCompact(b *testing.B) {
+	benchmarkSessionEncoding(b, engine.MarshalCompactSession)
+}
@@ -30,6 +30,16 @@ func SetNowSource(source NowSource) {
 	currentNowSource = source
 }
 
+// a source which defers to whatever the current global source is
+type globalNowSource struct{}
+
+func (s globalNowSource) Now() time.Time {
+	return Now()
+}
+
+// GlobalNowSource is a time source which returns the same as Now()
+var GlobalNowSource NowSource = globalNowSource{}
+
 // a source which returns a fixed time
 type fixedNowSource struct {
 	now time.Time
@@ -0,0 +1,222 @@
+package httpx
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AccessDeniedError is the error returned when a request is not allowed by an access config
+type AccessDeniedError struct {
+	reason string
+}
+
+func newAccessDeniedError(reason string, args ...interface{}) *AccessDeniedError {
+	return &AccessDeniedError{reason: fmt.Sprintf(reason, args...)}
+}
+
+func (e *AccessDeniedError) Error() string {
+	return "access denied: " + e.reason
+}
+
+// AsAccessDenied returns the access denied error if the given error is one, or wraps one, e.g. an error
+// from a redirect check or from dialing a denied address, otherwise nil
+func AsAccessDenied(err error) *AccessDeniedError {
+	for err != nil {
+		if accessErr, isAccessErr := err.(*AccessDeniedError); isAccessErr {
+			return accessErr
+		}
+
+		wrapper, isWrapper := err.(interface{ Unwrap() error })
+		if !isWrapper {
+			return nil
+		}
+		err = wrapper.Unwrap()
+	}
+	return nil
+}
+
+// PrivateNetworks are the loopback, private, link-local, multicast and other non-public IP ranges which
+// shouldn't normally be reachable from user provided URLs
+var PrivateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// AccessConfig configures which hosts and IP addresses requests are allowed to be made to
+type AccessConfig struct {
+	// AllowedHosts if non-empty are the only hosts requests can be made to, e.g. "example.com" or ".example.com" to
+	// also allow any subdomain
+	AllowedHosts []string
+
+	// DeniedHosts are hosts requests can't be made to, using the same matching as AllowedHosts
+	DeniedHosts []string
+
+	// DeniedNetworks are IP ranges which requests can't be made to
+	DeniedNetworks []*net.IPNet
+
+	// DialTimeout is the maximum time to spend resolving and connecting to a host
+	DialTimeout time.Duration
+
+	// restricted copies of clients, so that each has one transport whose connections are reused
+	clientsMutex sync.Mutex
+	clients      map[*http.Client]*http.Client
+}
+
+// NewAccessConfig creates a new access config which denies requests to private networks
+func NewAccessConfig(dialTimeout time.Duration) *AccessConfig {
+	return &AccessConfig{DeniedNetworks: PrivateNetworks, DialTimeout: dialTimeout}
+}
+
+// Allow returns an access denied error if the given request isn't allowed. Hosts are only checked against
+// the denied networks here if they're IP addresses - host names are checked when they're dialled by a
+// client from Client, as they may resolve to a different address by then.
+func (c *AccessConfig) Allow(request *http.Request) error {
+	host := strings.ToLower(request.URL.Hostname())
+
+	if len(c.AllowedHosts) > 0 && !matchesHost(host, c.AllowedHosts) {
+		return newAccessDeniedError("host '%s' is not allowed", host)
+	}
+	if matchesHost(host, c.DeniedHosts) {
+		return newAccessDeniedError("host '%s' is denied", host)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return c.allowIP(ip)
+	}
+
+	return nil
+}
+
+// Client returns a copy of the given client which checks every address it dials against our denied networks,
+// and applies this access config to any redirects. Note that when the client uses a proxy, it's the address of
+// the proxy which is dialled. The copy is only created once for each client and then reused.
+func (c *AccessConfig) Client(client *http.Client) (*http.Client, error) {
+	c.clientsMutex.Lock()
+	defer c.clientsMutex.Unlock()
+
+	if restricted := c.clients[client]; restricted != nil {
+		return restricted, nil
+	}
+
+	restricted, err := c.restrict(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.clients == nil {
+		c.clients = make(map[*http.Client]*http.Client)
+	}
+	c.clients[client] = restricted
+	return restricted, nil
+}
+
+// creates a copy of the given client with a transport that checks dialled addresses
+func (c *AccessConfig) restrict(client *http.Client) (*http.Client, error) {
+	var transport *http.Transport
+
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, errors.Errorf("can't restrict access of client with transport of type %T", client.Transport)
+	}
+
+	dialer := &net.Dialer{Timeout: c.DialTimeout, KeepAlive: 30 * time.Second, Control: c.control}
+	transport.DialContext = dialer.DialContext
+
+	restricted := *client
+	restricted.Transport = transport
+	restricted.CheckRedirect = c.CheckRedirect(client.CheckRedirect)
+	return &restricted, nil
+}
+
+// checks the address being dialled by a client, after its host has been resolved
+func (c *AccessConfig) control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return newAccessDeniedError("unable to parse address %s", address)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return newAccessDeniedError("unable to parse address %s", address)
+	}
+	return c.allowIP(ip)
+}
+
+func (c *AccessConfig) allowIP(ip net.IP) error {
+	for _, network := range c.DeniedNetworks {
+		if network.Contains(ip) {
+			return newAccessDeniedError("address %s is denied", ip)
+		}
+	}
+	return nil
+}
+
+// CheckRedirect returns a redirect policy which applies this access config to each redirect, and then the
+// given existing policy, if any
+func (c *AccessConfig) CheckRedirect(existing func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(request *http.Request, via []*http.Request) error {
+		if err := c.Allow(request); err != nil {
+			return err
+		}
+		if existing != nil {
+			return existing(request, via)
+		}
+		// mimic the default behaviour of stopping after 10 redirects
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// checks whether the given host matches any of the given patterns, where a pattern starting with a period
+// matches the domain itself and any subdomain
+func matchesHost(host string, patterns []string) bool {
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+
+		if strings.HasPrefix(p, ".") {
+			if host == p[1:] || strings.HasSuffix(host, p) {
+				return true
+			}
+		} else if host == p {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
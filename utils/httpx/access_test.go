@@ -0,0 +1,142 @@
+package httpx_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/goflow/utils/httpx"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessConfig(t *testing.T) {
+	access := httpx.NewAccessConfig(5 * time.Second)
+	access.DeniedHosts = []string{"evil.io", ".nasty.com"}
+
+	tests := []struct {
+		url string
+		err string
+	}{
+		{"http://8.8.8.8/foo", ""},
+		{"http://[2001:4860:4860::8888]/foo", ""},
+		{"http://10.1.2.3/foo", "access denied: address 10.1.2.3 is denied"},
+		{"http://127.0.0.1:8080/foo", "access denied: address 127.0.0.1 is denied"},
+		{"http://169.254.169.254/latest/meta-data", "access denied: address 169.254.169.254 is denied"},
+		{"http://192.168.1.1/", "access denied: address 192.168.1.1 is denied"},
+		{"http://198.18.0.1/", "access denied: address 198.18.0.1 is denied"},
+		{"http://224.0.0.1/", "access denied: address 224.0.0.1 is denied"},
+		{"http://[::1]/foo", "access denied: address ::1 is denied"},
+		{"http://[::ffff:10.1.2.3]/foo", "access denied: address 10.1.2.3 is denied"},
+		{"http://[64:ff9b::a9fe:a9fe]/foo", "access denied: address 64:ff9b::a9fe:a9fe is denied"},
+		{"http://[ff02::1]/foo", "access denied: address ff02::1 is denied"},
+		{"http://localhost/foo", ""}, // host names are checked when dialled
+		{"http://EVIL.io/foo", "access denied: host 'evil.io' is denied"},
+		{"http://nasty.com/foo", "access denied: host 'nasty.com' is denied"},
+		{"http://www.nasty.com/foo", "access denied: host 'www.nasty.com' is denied"},
+	}
+
+	for _, tc := range tests {
+		request, _ := http.NewRequest("GET", tc.url, nil)
+		err := access.Allow(request)
+
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, "error mismatch for %s", tc.url)
+		} else {
+			assert.NoError(t, err, "unexpected error for %s", tc.url)
+		}
+	}
+
+	// when allowed hosts are specified, only those can be called
+	access = &httpx.AccessConfig{AllowedHosts: []string{"temba.io", ".nyaruka.com"}}
+
+	for _, allowed := range []string{"http://temba.io", "http://nyaruka.com/", "https://api.nyaruka.com/"} {
+		request, _ := http.NewRequest("GET", allowed, nil)
+		assert.NoError(t, access.Allow(request), "unexpected error for %s", allowed)
+	}
+
+	request, _ := http.NewRequest("GET", "http://xtemba.io/", nil)
+	assert.EqualError(t, access.Allow(request), "access denied: host 'xtemba.io' is not allowed")
+}
+
+func TestAccessConfigRedirects(t *testing.T) {
+	access := &httpx.AccessConfig{DeniedHosts: []string{"evil.io"}}
+	checkRedirect := access.CheckRedirect(nil)
+
+	good, _ := http.NewRequest("GET", "http://temba.io/", nil)
+	evil, _ := http.NewRequest("GET", "http://evil.io/", nil)
+
+	assert.NoError(t, checkRedirect(good, []*http.Request{good}))
+	assert.EqualError(t, checkRedirect(evil, []*http.Request{good}), "access denied: host 'evil.io' is denied")
+	assert.EqualError(t, checkRedirect(good, make([]*http.Request, 10)), "stopped after 10 redirects")
+
+	// an existing policy is also applied
+	checkRedirect = access.CheckRedirect(func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse })
+	assert.Equal(t, http.ErrUseLastResponse, checkRedirect(good, nil))
+	assert.EqualError(t, checkRedirect(evil, nil), "access denied: host 'evil.io' is denied")
+}
+
+func TestAccessConfigClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// a host name which passes Allow but resolves to a denied address is denied when it's dialled
+	access := httpx.NewAccessConfig(time.Second)
+	base := &http.Client{}
+	client, err := access.Client(base)
+	assert.NoError(t, err)
+
+	// the restricted copy of a client is reused so that its connections are pooled
+	again, err := access.Client(base)
+	assert.NoError(t, err)
+	assert.True(t, client == again)
+	assert.True(t, client.Transport == again.Transport)
+
+	request, _ := http.NewRequest("GET", strings.Replace(server.URL, "127.0.0.1", "localhost", 1), nil)
+	assert.NoError(t, access.Allow(request))
+
+	_, err = client.Do(request)
+	accessErr := httpx.AsAccessDenied(err)
+	if assert.NotNil(t, accessErr) {
+		assert.Regexp(t, `access denied: address (127\.0\.0\.1|::1) is denied`, accessErr.Error())
+	}
+
+	// the original client is unchanged and can still make the request
+	response, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+
+	// and clients without private networks denied can also make the request
+	client, err = (&httpx.AccessConfig{DeniedHosts: []string{"evil.io"}}).Client(&http.Client{})
+	assert.NoError(t, err)
+
+	response, err = client.Do(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+
+	// clients with other types of transports can't be restricted
+	_, err = access.Client(&http.Client{Transport: roundTripperFunc(nil)})
+	assert.EqualError(t, err, "can't restrict access of client with transport of type httpx_test.roundTripperFunc")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestAsAccessDenied(t *testing.T) {
+	request, _ := http.NewRequest("GET", "http://evil.io/", nil)
+	err := (&httpx.AccessConfig{DeniedHosts: []string{"evil.io"}}).Allow(request)
+
+	assert.Equal(t, err, httpx.AsAccessDenied(err))
+	assert.Equal(t, err, httpx.AsAccessDenied(&url.Error{Op: "Get", URL: "http://evil.io/", Err: err}))
+	assert.Equal(t, err, httpx.AsAccessDenied(&url.Error{Op: "Get", URL: "http://evil.io/", Err: &net.OpError{Op: "dial", Err: err}}))
+	assert.Nil(t, httpx.AsAccessDenied(errors.New("boom")))
+	assert.Nil(t, httpx.AsAccessDenied(&url.Error{Op: "Get", URL: "http://evil.io/", Err: errors.New("boom")}))
+}
@@ -78,3 +78,52 @@ func Indent(s string, prefix string) string {
 	}
 	return output.String()
 }
+
+// EditDistance returns the optimal string alignment distance between s1 and s2, i.e. the number of single
+// character insertions, deletions, substitutions or transpositions needed to turn one into the other
+func EditDistance(s1, s2 string) int {
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	d := make([][]int, len(r1)+1)
+	for i := range d {
+		d[i] = make([]int, len(r2)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			d[i][j] = MinInt(MinInt(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] && r1[i-2] == r2[j-1] {
+				d[i][j] = MinInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(r1)][len(r2)]
+}
+
+// ClosestString returns the candidate which is closest to s (case-insensitive) and close enough to be a
+// likely misspelling of it, or empty string if there is no such candidate
+func ClosestString(s string, candidates []string) string {
+	s = strings.ToLower(s)
+	maxDistance := MaxInt(1, len([]rune(s))/3)
+
+	closest := ""
+	closestDistance := maxDistance + 1
+	for _, c := range candidates {
+		d := EditDistance(s, strings.ToLower(c))
+		if d > 0 && d < closestDistance {
+			closest = c
+			closestDistance = d
+		}
+	}
+	return closest
+}
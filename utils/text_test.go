@@ -103,3 +103,25 @@ func TestIndent(t *testing.T) {
 	assert.Equal(t, "  x\n\n  y", utils.Indent("x\n\ny", "  "))
 	assert.Equal(t, ">>>x", utils.Indent("x", ">>>"))
 }
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, utils.EditDistance("", ""))
+	assert.Equal(t, 3, utils.EditDistance("", "abc"))
+	assert.Equal(t, 0, utils.EditDistance("abc", "abc"))
+	assert.Equal(t, 1, utils.EditDistance("abc", "abd"))
+	assert.Equal(t, 1, utils.EditDistance("abc", "ac"))
+	assert.Equal(t, 3, utils.EditDistance("kitten", "sitting"))
+	assert.Equal(t, 1, utils.EditDistance("héllo", "hello"))
+	assert.Equal(t, 1, utils.EditDistance("name", "nmae"))
+}
+
+func TestClosestString(t *testing.T) {
+	candidates := []string{"contact", "fields", "results", "upper", "url_encode"}
+
+	assert.Equal(t, "contact", utils.ClosestString("contct", candidates))
+	assert.Equal(t, "upper", utils.ClosestString("UPER", candidates))
+	assert.Equal(t, "results", utils.ClosestString("result", candidates))
+	assert.Equal(t, "", utils.ClosestString("contact", candidates)) // exact matches aren't suggestions
+	assert.Equal(t, "", utils.ClosestString("xyz", candidates))
+	assert.Equal(t, "", utils.ClosestString("x", nil))
+}